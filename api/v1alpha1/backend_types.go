@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	BackendReadyCondition = "BackendReadyCondition"
+)
+
+// BackendCredential maps a connection key to a value stored in a Secret
+type BackendCredential struct {
+	// Name is the connection key the secret value is exposed as, e.g. "pat" or "apikey"
+	Name string `json:"name"`
+	// SecretKeyRef selects the key of a Secret in the Backend's namespace
+	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// BackendHTTPClient tunes the HTTP client used to talk to the backend.
+// Fields left unset fall back to the operator-wide httpClient configuration.
+type BackendHTTPClient struct {
+	TimeoutMs                   int `json:"timeoutMs,omitempty"`
+	KeepAliveTimeoutMs          int `json:"keepAliveTimeoutMs,omitempty"`
+	MaxIdleConnections          int `json:"maxIdleConnections,omitempty"`
+	MaxConcurrentRequests       int `json:"maxConcurrentRequests,omitempty"`
+	RequestVolumeThreshold      int `json:"requestVolumeThreshold,omitempty"`
+	CircuitBreakerSleepWindowMs int `json:"circuitBreakerSleepWindowMs,omitempty"`
+	ErrorPercentThreshold       int `json:"errorPercentThreshold,omitempty"`
+	CircuitBreakerTimeoutMs     int `json:"circuitBreakerTimeoutMs,omitempty"`
+}
+
+// BackendSpec defines the desired state of Backend
+type BackendSpec struct {
+	// Type is the backend type, e.g. fivetran, rover or snowflake
+	Type string `json:"type"`
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Connection holds the non-sensitive connection settings of the backend
+	// +optional
+	Connection map[string]string `json:"connection,omitempty"`
+	// Credentials holds the sensitive connection settings of the backend
+	// +optional
+	Credentials []BackendCredential `json:"credentials,omitempty"`
+	// +optional
+	HTTPClient *BackendHTTPClient `json:"httpClient,omitempty"`
+}
+
+// BackendConnectionStatus defines the observed state of Backend
+type BackendConnectionStatus struct {
+	Connected          bool               `json:"connected"`
+	Message            string             `json:"message,omitempty"`
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Connected",type=boolean,JSONPath=`.status.connected`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`
+
+// Backend is the Schema for the backends API
+type Backend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackendSpec             `json:"spec,omitempty"`
+	Status BackendConnectionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackendList contains a list of Backend
+type BackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Backend `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Backend{}, &BackendList{})
+}
+
+// IsEnabled reports whether the backend is enabled, defaulting to true
+func (b *Backend) IsEnabled() bool {
+	return b.Spec.Enabled == nil || *b.Spec.Enabled
+}
+
+func (b *Backend) UpdateStatus(connected bool, message string) {
+	if connected {
+		b.setStatus(metav1.ConditionTrue, BackendConnected, message)
+	} else {
+		b.setStatus(metav1.ConditionFalse, BackendConnectionFailed, message)
+	}
+}
+
+// UpdateStatusUnverified reports the backend as registered without its connectivity being
+// checked, e.g. when it lists neither its users nor its teams, leaving the condition unknown
+func (b *Backend) UpdateStatusUnverified(message string) {
+	b.setStatus(metav1.ConditionUnknown, BackendUnverified, message)
+}
+
+func (b *Backend) setStatus(status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               BackendReadyCondition,
+		Status:             status,
+		Reason:             reason,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: b.Generation,
+		Message:            message,
+	}
+	b.Status.Connected = status == metav1.ConditionTrue
+	b.Status.Message = message
+	b.Status.ObservedGeneration = b.Generation
+	for i, currentCondition := range b.Status.Conditions {
+		if currentCondition.Type == condition.Type {
+			if currentCondition.Status == condition.Status {
+				condition.LastTransitionTime = currentCondition.LastTransitionTime
+			}
+			b.Status.Conditions[i] = condition
+			return
+		}
+	}
+	b.Status.Conditions = append(b.Status.Conditions, condition)
+}
@@ -1,6 +1,10 @@
 package v1alpha1
 
 const (
-	SuccessfullyReconciled = "SuccessfullyReconciled"
-	ReconcileFailed        = "ReconcileFailed"
+	SuccessfullyReconciled  = "SuccessfullyReconciled"
+	ReconcileFailed         = "ReconcileFailed"
+	BackendConnected        = "Connected"
+	BackendConnectionFailed = "ConnectionFailed"
+	// BackendUnverified is the reason of the backends registered without contacting them
+	BackendUnverified = "ConnectionUnverified"
 )
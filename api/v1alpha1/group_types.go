@@ -29,21 +29,78 @@ type BackendStatus struct {
 	Type    string `json:"type"`
 	Status  bool   `json:"status"`
 	Message string `json:"message"`
+	// GrantsDrift lists the grants of the team found diverging from the spec on the
+	// last reconciliation, they were corrected unless the backend failed
+	GrantsDrift []string `json:"grantsDrift,omitempty"`
 }
 
-type Backend struct {
+// GroupBackend references a configured backend that the Group is synced to
+type GroupBackend struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
+	// Grants lists the privileges of the team on the objects of the backend, for the
+	// backends managing them. When set, even empty, the privileges not listed are revoked.
+	// +optional
+	Grants []BackendGrant `json:"grants,omitempty"`
+	// UserProperties are set on the users created for the Group, overriding the ones of the
+	// backend, e.g. default_warehouse or type for Snowflake
+	// +optional
+	UserProperties map[string]string `json:"user_properties,omitempty"`
+	// MemberRoles gives members of the Group, by user name, their own role in the team on the
+	// backends supporting it, e.g. maintainer for GitLab. The other members get the default role
+	// of the backend.
+	// +optional
+	MemberRoles map[string]string `json:"member_roles,omitempty"`
+	// FivetranPermissions lists the destinations and connectors the team is a member of on a
+	// fivetran backend. When set, even empty, the other memberships of the team are removed.
+	// +optional
+	FivetranPermissions *FivetranPermissions `json:"fivetran_permissions,omitempty"`
+}
+
+// FivetranPermissions lists the memberships of a fivetran team on destinations and connectors
+type FivetranPermissions struct {
+	// Destinations are the fivetran groups, identified by their ID
+	// +optional
+	Destinations []FivetranMembership `json:"destinations,omitempty"`
+	// Connectors are identified by their ID
+	// +optional
+	Connectors []FivetranMembership `json:"connectors,omitempty"`
+}
+
+// FivetranMembership gives the team a role on a destination or a connector
+type FivetranMembership struct {
+	ID string `json:"id"`
+	// Role of the team, e.g. Destination Administrator or Connector Reviewer
+	Role string `json:"role"`
+}
+
+// BackendGrant grants privileges on an object of the backend to the team of the Group
+type BackendGrant struct {
+	// ObjectType is the type of the object, warehouse, database or schema for Snowflake
+	ObjectType string `json:"object_type"`
+	// ObjectName is the name of the object, schemas are qualified with their database as database.schema
+	ObjectName string `json:"object_name"`
+	// Privileges granted on the object, e.g. USAGE
+	// +optional
+	Privileges []string `json:"privileges,omitempty"`
+	// TablePrivileges granted on the tables of a schema, e.g. SELECT
+	// +optional
+	TablePrivileges []string `json:"table_privileges,omitempty"`
+	// FutureTables grants the table privileges on the tables created in the schema later as well
+	// +optional
+	FutureTables bool `json:"future_tables,omitempty"`
 }
 
 // GroupSpec defines the desired state of Group
 type GroupSpec struct {
-	GroupName string    `json:"group_name"`
-	Members   Members   `json:"members"`
-	Backends  []Backend `json:"backends"`
+	GroupName string         `json:"group_name"`
+	Members   Members        `json:"members"`
+	Backends  []GroupBackend `json:"backends"`
 }
 
 type Members struct {
+	// Groups lists the nested Groups whose members are included, either by name
+	// for a Group in the same namespace or as namespace/name
 	Groups []string `json:"groups,omitempty"`
 	Users  []string `json:"users"`
 }
@@ -19,19 +19,21 @@ package main
 import (
 	"context"
 	"crypto/tls"
-	"encoding/json"
 	"flag"
+	"fmt"
 	"os"
+	"reflect"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
-	k8sCache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -42,6 +44,7 @@ import (
 	"github.com/redhat-data-and-ai/usernaut/internal/controller"
 	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/builtin"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
@@ -68,6 +71,10 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(validateConfig(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
@@ -135,10 +142,12 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
-	watchedNs := os.Getenv("WATCHED_NAMESPACE")
-	if watchedNs == "" {
-		watchedNs = "usernaut"
+	watchNamespaces, err := controller.WatchNamespacesFromEnv()
+	if err != nil {
+		setupLog.Error(err, "invalid watched namespaces")
+		os.Exit(1)
 	}
+	setupLog.Info("watching namespaces", "namespaces", watchNamespaces.String())
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
@@ -147,10 +156,11 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "dd1e5158.operator.dataverse.redhat.com",
-		Cache: k8sCache.Options{
-			DefaultNamespaces: map[string]k8sCache.Config{
-				watchedNs: {},
-			},
+		Cache:                  watchNamespaces.CacheOptions(),
+		// the kubernetes backend manages RoleBindings outside of the watched namespaces,
+		// and before the cache is started when preloading it
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&rbacv1.RoleBinding{}}},
 		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
@@ -169,6 +179,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	// resolve 'secret|namespace/name/key' config values, the manager cache isn't started yet
+	config.RegisterResolver(config.SecretResolver, config.NewSecretResolver(mgr.GetAPIReader()))
+
+	clients.SetKubernetesClient(mgr.GetClient())
+
 	appConf, err := config.GetConfig()
 	if err != nil {
 		setupLog.Error(err, "unable to create config")
@@ -191,16 +206,37 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.GroupReconciler{
+	groupReconciler := &controller.GroupReconciler{
 		Client:    mgr.GetClient(),
 		Scheme:    mgr.GetScheme(),
 		AppConfig: appConf,
 		Cache:     cache,
 		LdapConn:  ldapConn,
-	}).SetupWithManager(mgr); err != nil {
+
+		NamespaceSelector: watchNamespaces.Selector,
+	}
+	if err = groupReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Group")
 		os.Exit(1)
 	}
+	backendReconciler := &controller.BackendReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		AppConfig: appConf,
+		Cache:     cache,
+
+		NamespaceSelector: watchNamespaces.Selector,
+	}
+	if err = backendReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Backend")
+		os.Exit(1)
+	}
+	if err = (&controller.ConfigSecretReconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ConfigSecret")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -213,6 +249,20 @@ func main() {
 	}
 
 	apiServer := server.NewAPIServer(appConf)
+
+	// apply reloaded configurations to the running components
+	store := cache
+	config.OnChange(func(oldConfig, newConfig *config.AppConfig) {
+		store = applyConfigChange(oldConfig, newConfig, store)
+		groupReconciler.UpdateConfig(newConfig, store)
+		backendReconciler.UpdateConfig(newConfig, store)
+		apiServer.UpdateConfig(newConfig)
+	})
+	if err := mgr.Add(config.NewWatcher()); err != nil {
+		setupLog.Error(err, "unable to set up config watcher")
+		os.Exit(1)
+	}
+
 	go func() {
 		if err := apiServer.Start(); err != nil {
 			setupLog.Error(err, "failed to start HTTP API server")
@@ -232,113 +282,96 @@ func main() {
 // This is done only once at the start of the application
 // and the cache is flushed when the application is restarted
 func preloadCache(appConfig config.AppConfig, store cache.Cache) error {
+	for _, backend := range appConfig.Backends {
+		if err := preloadBackend(appConfig, backend, store); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+func preloadBackend(appConfig config.AppConfig, backend config.Backend, store cache.Cache) error {
 	ctx := context.Background()
 
-	for _, backend := range appConfig.Backends {
-		log := logger.Logger(ctx).
-			WithFields(logrus.Fields{
-				"backend":   backend.Name,
-				"type":      backend.Type,
-				"component": "preloadCache",
-			})
-
-		// don't preload the cache in case of a disabled backend
-		if !backend.Enabled {
-			log.Warn("Backend is disabled, skipping preload")
-			continue
-		}
+	log := logger.Logger(ctx).
+		WithFields(logrus.Fields{
+			"backend":   backend.Name,
+			"type":      backend.Type,
+			"component": "preloadCache",
+		})
+
+	// don't preload the cache in case of a disabled backend
+	if !backend.Enabled {
+		log.Warn("Backend is disabled, skipping preload")
+		return nil
+	}
 
-		log.Info("preloading cache with users and teams from backend")
+	log.Info("preloading cache with users and teams from backend")
 
-		backendClient, err := clients.New(backend.Name, backend.Type, appConfig.BackendMap)
-		if err != nil {
-			log.WithError(err).Error("failed to create backend client")
-			return err
-		}
+	backendClient, err := clients.New(backend.Name, backend.Type, &appConfig)
+	if err != nil {
+		log.WithError(err).Error("failed to create backend client")
+		return err
+	}
 
-		// Fetch all the users and store them in the cache
-		users, _, err := backendClient.FetchAllUsers(ctx)
+	return controller.PreloadBackendCache(ctx, backend, backendClient, store)
+}
+
+// applyConfigChange prepares the cache for a reloaded configuration and returns the cache to use.
+// A new cache is built and fully preloaded when the cache settings changed, otherwise only
+// the backends that were added or modified are preloaded into the existing cache.
+// The previous cache is kept when the new one can't be built.
+func applyConfigChange(oldConfig, newConfig *config.AppConfig, store cache.Cache) cache.Cache {
+	log := logger.Logger(context.Background()).WithField("component", "configReload")
+
+	if !reflect.DeepEqual(oldConfig.LDAP, newConfig.LDAP) {
+		log.Warn("LDAP settings changed, restart required to take effect")
+	}
+
+	if !reflect.DeepEqual(oldConfig.Cache, newConfig.Cache) {
+		log.Info("cache settings changed, rebuilding the cache")
+		newStore, err := cache.New(&newConfig.Cache)
 		if err != nil {
-			log.WithError(err).Error("failed to fetch users from backend")
-			return err
+			log.WithError(err).Error("failed to initialize cache, keeping the previous one")
+			return store
 		}
-		for _, user := range users {
-			userMap := make(map[string]string)
-			userInCache, err := store.Get(ctx, user.GetEmail())
-			// if user is already in the cache, we will update the user details
-			// with the new user ID from the backend
-			if err == nil {
-				// process user details
-				err = json.Unmarshal([]byte(userInCache.(string)), &userMap)
-				if err != nil {
-					log.WithError(err).Error("failed to unmarshal user details from cache")
-					return err
-				}
-			}
-			userMap[backend.Name+"_"+backend.Type] = user.ID
-			newUserValue, err := json.Marshal(userMap)
-			if err != nil {
-				log.WithError(err).Error("failed to marshal user details to JSON")
-				return err
-			}
-			// Store the user in the cache with email as key
-			// and user ID as value
-			// This is done to avoid hitting the backend for every request
-			// and to improve the performance of the application
-			// The user ID is stored in the cache as a JSON string
-			// so that it can be easily retrieved later
-			// The user ID is stored in the cache with the backend name and type as key
-			err = store.Set(ctx, user.Email, string(newUserValue), cache.NoExpiration)
-			if err != nil {
-				log.WithError(err).Error("failed to store user in cache")
-				return err
-			}
+		if err := preloadCache(*newConfig, newStore); err != nil {
+			log.WithError(err).Error("failed to preload cache, keeping the previous one")
+			return store
 		}
+		return newStore
+	}
 
-		// Fetch all the teams and store them in the cache
-		teams, err := backendClient.FetchAllTeams(ctx)
-		if err != nil {
-			log.WithError(err).Error("failed to fetch teams from backend")
-			return err
+	for _, backend := range newConfig.Backends {
+		oldBackend, found := oldConfig.BackendMap[backend.Type][backend.Name]
+		if found && reflect.DeepEqual(oldBackend, backend) {
+			continue
 		}
-		for _, team := range teams {
-			teamMap := make(map[string]string)
-			teamInCache, err := store.Get(ctx, team.GetName())
-			// if team is already in the cache, we will update the team details
-			// with the new team ID from the backend
-			if err == nil {
-				// process user details
-				err = json.Unmarshal([]byte(teamInCache.(string)), &teamMap)
-				if err != nil {
-					log.WithError(err).Error("failed to unmarshal team details from cache")
-					return err
-				}
-			}
-			teamMap[backend.Name+"_"+backend.Type] = team.ID
-			newTeamValue, err := json.Marshal(teamMap)
-			if err != nil {
-				log.WithError(err).Error("failed to marshal team details to JSON")
-				return err
-			}
-
-			// Store the team in the cache with name as key
-			// and team ID as value
-			// This is done to avoid hitting the backend for every request
-			// and to improve the performance of the application
-			// The team ID is stored in the cache as a JSON string
-			// so that it can be easily retrieved later
-			// The team ID is stored in the cache with the backend name and type as key
-			err = store.Set(ctx, team.Name, string(newTeamValue), cache.NoExpiration)
-			if err != nil {
-				log.WithError(err).Error("failed to store team in cache")
-				return err
-			}
+		if err := preloadBackend(*newConfig, backend, store); err != nil {
+			log.WithError(err).WithField("backend", backend.Name).Error("failed to preload reloaded backend")
 		}
-		log.WithFields(logrus.Fields{
-			"users": len(users),
-			"teams": len(teams),
-		}).Debug("fetched users and teams from backend")
 	}
-	return nil
+	return store
+}
+
+// validateConfig implements the 'validate' subcommand, checking a configuration directory
+// without connecting to the cluster or the backends. It returns the process exit code.
+func validateConfig(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	configDir := fs.String("config-dir", config.DefaultConfigDir, "The directory containing the configuration files.")
+	env := fs.String("env", os.Getenv("APP_ENV"), "The environment to validate, defaults to $APP_ENV.")
+	_ = fs.Parse(args)
+
+	if *env == "" {
+		*env = config.DefaultConfigFileName
+	}
+
+	loader := config.NewConfig(config.NewOptions(config.DefaultConfigType, *configDir, config.DefaultConfigFileName))
+	if _, err := config.LoadAppConfig(loader, *env); err != nil {
+		fmt.Fprintf(os.Stderr, "configuration %s (env %s) is invalid:\n%v\n", *configDir, *env, err)
+		return 1
+	}
+
+	fmt.Printf("configuration %s (env %s) is valid\n", *configDir, *env)
+	return 0
 }
@@ -0,0 +1,34 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The memory plugin is the reference plugin, serving a backend that keeps its users and teams in memory.
+// Configure it as a backend of type plugin with 'command' set to the path of the binary.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin/memory"
+)
+
+func main() {
+	if err := plugin.Serve(memory.New); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
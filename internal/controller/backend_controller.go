@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// backendSecretIndexField indexes Backend CRs by the Secrets their credentials reference
+	backendSecretIndexField = "spec.credentials.secretKeyRef.name"
+	// backendRetryInterval is how long to wait before checking a failing backend again
+	backendRetryInterval = time.Minute
+)
+
+// BackendReconciler reconciles a Backend object
+type BackendReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	AppConfig *config.AppConfig
+	Cache     cache.Cache
+	// NamespaceSelector, when set, restricts the reconciled Backends to the namespaces with matching labels
+	NamespaceSelector labels.Selector
+	// configMu guards AppConfig and Cache which are replaced on configuration reload
+	configMu sync.RWMutex
+}
+
+//nolint:lll
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,resources=backends,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,resources=backends/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",namespace=usernaut,resources=secrets,verbs=get;list;watch
+
+func (r *BackendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.configMu.RLock()
+	defer r.configMu.RUnlock()
+
+	ctx = logger.WithRequestId(ctx, controller.ReconcileIDFromContext(ctx))
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"request":   req.NamespacedName.String(),
+		"component": "backendController",
+	})
+
+	backendCR := &usernautdevv1alpha1.Backend{}
+	if err := r.Get(ctx, req.NamespacedName, backendCR); err != nil {
+		if apierrors.IsNotFound(err) {
+			if backend, ok := config.UnregisterBackend(req.NamespacedName.String()); ok {
+				log.WithFields(logrus.Fields{
+					"backend": backend.Name,
+					"type":    backend.Type,
+				}).Info("backend removed")
+			}
+			return ctrl.Result{}, nil
+		}
+		log.WithError(err).Error("Unable to fetch Backend CR")
+		return ctrl.Result{}, err
+	}
+
+	log = log.WithFields(logrus.Fields{
+		"backend": backendCR.Name,
+		"type":    backendCR.Spec.Type,
+	})
+
+	backend, err := r.buildBackendConfig(ctx, backendCR)
+	if err != nil {
+		log.WithError(err).Error("error building backend configuration")
+		config.UnregisterBackend(req.NamespacedName.String())
+		return ctrl.Result{RequeueAfter: backendRetryInterval}, r.updateStatus(ctx, backendCR, false, err.Error())
+	}
+
+	if err := r.AppConfig.RegisterBackend(req.NamespacedName.String(), backend); err != nil {
+		// retried until the other definition is removed, the Groups can't tell both apart
+		log.WithError(err).Error("error registering backend")
+		config.UnregisterBackend(req.NamespacedName.String())
+		return ctrl.Result{RequeueAfter: backendRetryInterval}, r.updateStatus(ctx, backendCR, false, err.Error())
+	}
+	log.Info("backend registered")
+
+	if !backend.Enabled {
+		return ctrl.Result{}, r.updateStatus(ctx, backendCR, false, "Backend is disabled")
+	}
+
+	backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig)
+	if err != nil {
+		log.WithError(err).Error("error creating backend client")
+		return ctrl.Result{RequeueAfter: backendRetryInterval}, r.updateStatus(ctx, backendCR, false, err.Error())
+	}
+
+	// preloading the cache doubles as the connectivity check of the backend
+	if err := PreloadBackendCache(ctx, backend, backendClient, r.Cache); err != nil {
+		log.WithError(err).Error("error connecting to the backend")
+		return ctrl.Result{RequeueAfter: backendRetryInterval}, r.updateStatus(ctx, backendCR, false, err.Error())
+	}
+
+	// the backends listing neither their users nor their teams weren't contacted by the preload
+	if capabilities := clients.GetCapabilities(backendClient); !capabilities.UserListing && !capabilities.TeamListing {
+		backendCR.UpdateStatusUnverified("Registered, the connectivity isn't verified as the backend " +
+			"lists neither its users nor its teams")
+		return ctrl.Result{}, r.Status().Update(ctx, backendCR)
+	}
+
+	return ctrl.Result{}, r.updateStatus(ctx, backendCR, true, "Connected successfully")
+}
+
+// UpdateConfig replaces the configuration and cache used by the reconciler,
+// waiting for the in-flight reconcile to finish
+func (r *BackendReconciler) UpdateConfig(appConfig *config.AppConfig, store cache.Cache) {
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+	r.AppConfig = appConfig
+	r.Cache = store
+}
+
+// buildBackendConfig converts the Backend CR into the backend configuration used by the clients,
+// resolving the credentials from the referenced Secrets
+func (r *BackendReconciler) buildBackendConfig(ctx context.Context,
+	backendCR *usernautdevv1alpha1.Backend) (config.Backend, error) {
+	backend := config.Backend{
+		Name:       backendCR.Name,
+		Type:       strings.ToLower(backendCR.Spec.Type),
+		Enabled:    backendCR.IsEnabled(),
+		Connection: make(map[string]interface{}, len(backendCR.Spec.Connection)+len(backendCR.Spec.Credentials)),
+	}
+
+	for key, value := range backendCR.Spec.Connection {
+		backend.Connection[key] = value
+	}
+
+	for _, credential := range backendCR.Spec.Credentials {
+		secret := &corev1.Secret{}
+		secretKey := types.NamespacedName{Namespace: backendCR.Namespace, Name: credential.SecretKeyRef.Name}
+		if err := r.Get(ctx, secretKey, secret); err != nil {
+			if apierrors.IsNotFound(err) && credential.SecretKeyRef.Optional != nil && *credential.SecretKeyRef.Optional {
+				continue
+			}
+			return config.Backend{}, fmt.Errorf("failed to fetch secret %s for credential %s: %w",
+				secretKey.String(), credential.Name, err)
+		}
+		value, ok := secret.Data[credential.SecretKeyRef.Key]
+		if !ok {
+			if credential.SecretKeyRef.Optional != nil && *credential.SecretKeyRef.Optional {
+				continue
+			}
+			return config.Backend{}, fmt.Errorf("key %s not found in secret %s for credential %s",
+				credential.SecretKeyRef.Key, secretKey.String(), credential.Name)
+		}
+		backend.Connection[credential.Name] = strings.TrimSpace(string(value))
+	}
+
+	if backendCR.Spec.HTTPClient != nil {
+		httpClientConfig := mergeHttpClientConfig(r.AppConfig.HttpClient, backendCR.Spec.HTTPClient)
+		backend.HttpClient = &httpClientConfig
+	}
+
+	if errs := config.ValidateBackend(backend); len(errs) > 0 {
+		return config.Backend{}, fmt.Errorf("invalid backend: %w", errors.Join(errs...))
+	}
+
+	return backend, nil
+}
+
+// mergeHttpClientConfig overrides the application wide HTTP client settings
+// with the ones set on the Backend CR
+func mergeHttpClientConfig(base config.HttpClientConfig,
+	override *usernautdevv1alpha1.BackendHTTPClient) config.HttpClientConfig {
+	setIfPositive := func(target *int, value int) {
+		if value > 0 {
+			*target = value
+		}
+	}
+
+	pool := &base.ConnectionPoolConfig
+	setIfPositive(&pool.Timeout, override.TimeoutMs)
+	setIfPositive(&pool.KeepAliveTimeout, override.KeepAliveTimeoutMs)
+	setIfPositive(&pool.MaxIdleConnections, override.MaxIdleConnections)
+
+	hystrix := &base.HystrixResiliencyConfig
+	setIfPositive(&hystrix.MaxConcurrentRequests, override.MaxConcurrentRequests)
+	setIfPositive(&hystrix.RequestVolumeThreshold, override.RequestVolumeThreshold)
+	setIfPositive(&hystrix.CircuitBreakerSleepWindow, override.CircuitBreakerSleepWindowMs)
+	setIfPositive(&hystrix.ErrorPercentThreshold, override.ErrorPercentThreshold)
+	setIfPositive(&hystrix.CircuitBreakerTimeout, override.CircuitBreakerTimeoutMs)
+
+	return base
+}
+
+func (r *BackendReconciler) updateStatus(ctx context.Context,
+	backendCR *usernautdevv1alpha1.Backend, connected bool, message string) error {
+	backendCR.UpdateStatus(connected, message)
+	return r.Status().Update(ctx, backendCR)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackendReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	indexFunc := func(obj client.Object) []string {
+		backendCR := obj.(*usernautdevv1alpha1.Backend)
+		secrets := make([]string, 0, len(backendCR.Spec.Credentials))
+		for _, credential := range backendCR.Spec.Credentials {
+			secrets = append(secrets, credential.SecretKeyRef.Name)
+		}
+		return secrets
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&usernautdevv1alpha1.Backend{}, backendSecretIndexField, indexFunc); err != nil {
+		return err
+	}
+
+	// Find all the Backend CRs referencing a changed Secret, so rotated credentials are picked up
+	mapFunc := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var backends usernautdevv1alpha1.BackendList
+		if err := r.List(ctx, &backends, client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{backendSecretIndexField: obj.GetName()}); err != nil {
+			logger.Logger(ctx).WithError(err).Error("error listing backends referencing secret")
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(backends.Items))
+		for _, backendCR := range backends.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      backendCR.Name,
+					Namespace: backendCR.Namespace,
+				},
+			})
+		}
+		return requests
+	}
+
+	backendPredicates := []predicate.Predicate{predicate.GenerationChangedPredicate{}}
+	secretPredicates := []predicate.Predicate{}
+	if r.NamespaceSelector != nil {
+		selectorPredicate := namespaceSelectorPredicate(mgr.GetClient(), r.NamespaceSelector)
+		backendPredicates = append(backendPredicates, selectorPredicate)
+		secretPredicates = append(secretPredicates, selectorPredicate)
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&usernautdevv1alpha1.Backend{}, builder.WithPredicates(backendPredicates...)).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(mapFunc),
+			builder.WithPredicates(secretPredicates...),
+		)
+	if r.NamespaceSelector != nil {
+		controllerBuilder = controllerBuilder.Watches(
+			&corev1.Namespace{},
+			enqueueMatchingNamespace(mgr.GetClient(), r.NamespaceSelector, &usernautdevv1alpha1.BackendList{}),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		)
+	}
+	return controllerBuilder.Complete(r)
+}
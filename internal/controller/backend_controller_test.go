@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+// teamsClient is a client of the backendtest backends, only listing a team named after its token,
+// or nothing at all for the "unlisted" token
+type teamsClient struct {
+	clients.Client
+	token string
+}
+
+func (c *teamsClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{TeamListing: c.token != "unlisted"}
+}
+
+func (c *teamsClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	return map[string]structs.Team{c.token: {ID: c.token + "-id", Name: c.token}}, nil
+}
+
+func init() {
+	clients.Register("backendtest", func(backend config.Backend, _ config.HttpClientConfig) (clients.Client, error) {
+		return &teamsClient{token: backend.GetStringConnection("token", "")}, nil
+	}, "token")
+}
+
+func newBackendCR(namespace, secret string) *usernautdevv1alpha1.Backend {
+	return &usernautdevv1alpha1.Backend{
+		ObjectMeta: metav1.ObjectMeta{Name: "analytics", Namespace: namespace},
+		Spec: usernautdevv1alpha1.BackendSpec{
+			Type: "backendtest",
+			Credentials: []usernautdevv1alpha1.BackendCredential{{
+				Name: "token",
+				SecretKeyRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+					Key:                  "token",
+				},
+			}},
+		},
+	}
+}
+
+func TestBackendReconcile(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, usernautdevv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	first, second := newBackendCR("team-a", "token"), newBackendCR("team-b", "token")
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&usernautdevv1alpha1.Backend{}).
+		WithObjects(first, second,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "team-a"},
+				Data:       map[string][]byte{"token": []byte("reporting\n")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "team-b"},
+				Data:       map[string][]byte{"token": []byte("finance")},
+			}).
+		Build()
+	store, err := cache.New(&cache.Config{
+		Driver:   "memory",
+		InMemory: &inmemory.Config{DefaultExpiration: -1, CleanupInterval: -1},
+	})
+	require.NoError(t, err)
+	r := &BackendReconciler{Client: kubeClient, AppConfig: &config.AppConfig{}, Cache: store}
+	t.Cleanup(func() {
+		config.UnregisterBackend("team-a/analytics")
+		config.UnregisterBackend("team-b/analytics")
+	})
+
+	reconcile := func(backendCR *usernautdevv1alpha1.Backend) (ctrl.Result, *usernautdevv1alpha1.Backend) {
+		key := client.ObjectKeyFromObject(backendCR)
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		reconciled := &usernautdevv1alpha1.Backend{}
+		if err := kubeClient.Get(ctx, key, reconciled); err != nil {
+			return result, nil
+		}
+		return result, reconciled
+	}
+
+	// the credentials are read from the Secret and the teams of the backend preloaded
+	result, reconciled := reconcile(first)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.True(t, reconciled.Status.Connected)
+	backend := r.AppConfig.GetBackendMap()["backendtest"]["analytics"]
+	assert.Equal(t, "reporting", backend.Connection["token"])
+	assert.Equal(t, "team-a/analytics", backend.Source)
+	cached, err := store.Get(ctx, "reporting")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"analytics_backendtest":"reporting-id"}`, cached.(string))
+
+	// a Backend of the same name in another namespace is reported and retried, the first one is kept
+	result, reconciled = reconcile(second)
+	assert.Equal(t, ctrl.Result{RequeueAfter: backendRetryInterval}, result)
+	assert.False(t, reconciled.Status.Connected)
+	assert.Contains(t, reconciled.Status.Message, "backendtest backend analytics is defined by team-a/analytics")
+	assert.Equal(t, "reporting", r.AppConfig.GetBackendMap()["backendtest"]["analytics"].Connection["token"])
+
+	// it is registered once the first one is deleted
+	require.NoError(t, kubeClient.Delete(ctx, first))
+	_, reconciled = reconcile(first)
+	assert.Nil(t, reconciled)
+	assert.NotContains(t, r.AppConfig.GetBackendMap(), "backendtest")
+	_, reconciled = reconcile(second)
+	assert.True(t, reconciled.Status.Connected)
+	assert.Equal(t, "finance", r.AppConfig.GetBackendMap()["backendtest"]["analytics"].Connection["token"])
+
+	// a missing Secret is reported in the status
+	require.NoError(t, kubeClient.Delete(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "team-b"},
+	}))
+	result, reconciled = reconcile(second)
+	assert.Equal(t, ctrl.Result{RequeueAfter: backendRetryInterval}, result)
+	assert.False(t, reconciled.Status.Connected)
+	assert.Contains(t, reconciled.Status.Message, "failed to fetch secret team-b/token")
+	assert.NotContains(t, r.AppConfig.GetBackendMap(), "backendtest")
+
+	// a backend the preload doesn't contact is registered, without claiming it is connected
+	require.NoError(t, kubeClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "team-b"},
+		Data:       map[string][]byte{"token": []byte("unlisted")},
+	}))
+	result, reconciled = reconcile(second)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.False(t, reconciled.Status.Connected)
+	assert.Equal(t, metav1.ConditionUnknown, reconciled.Status.Conditions[0].Status)
+	assert.Equal(t, usernautdevv1alpha1.BackendUnverified, reconciled.Status.Conditions[0].Reason)
+	assert.Contains(t, r.AppConfig.GetBackendMap(), "backendtest")
+}
+
+func TestGroupBackendNamespace(t *testing.T) {
+	r := newNestingReconciler(t)
+	require.NoError(t, r.AppConfig.RegisterBackend("team-a/reporting", config.Backend{
+		Name: "reporting", Type: "snowflake", Enabled: true,
+	}))
+	t.Cleanup(func() { config.UnregisterBackend("team-a/reporting") })
+	backend := usernautdevv1alpha1.GroupBackend{Name: "reporting", Type: "snowflake"}
+
+	// the backend of a Backend CR, and its credentials, are only used by the Groups of its namespace
+	group := newGroup("analysts", nil, nil, backend)
+	group.Namespace = "team-b"
+	_, err := r.newBackendClient(group, backend)
+	assert.ErrorIs(t, err, errForeignBackend)
+	assert.ErrorContains(t, err, "defined by team-a/reporting")
+
+	group.Namespace = "team-a"
+	_, err = r.newBackendClient(group, backend)
+	assert.NotErrorIs(t, err, errForeignBackend)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+)
+
+// unauthorizedRequeueAfter is the delay the Groups are reconciled again after when the backends
+// rejected the credentials, which are retried at that pace until they are fixed or rotated
+const unauthorizedRequeueAfter = 30 * time.Minute
+
+// backendsResult returns the result of a reconciliation the backends failed with errs.
+// It is retried with backoff unless every backend either asked to wait before retrying, in
+// which case it is requeued once the longest delay elapsed, rejected the credentials,
+// which are retried after unauthorizedRequeueAfter rather than hammering the backend, or
+// found a cyclic group nesting, which isn't retried until the Groups change.
+func backendsResult(errs []error) (ctrl.Result, error) {
+	if len(errs) == 0 {
+		return ctrl.Result{}, nil
+	}
+	err := fmt.Errorf("failed to reconcile all backends: %w", errors.Join(errs...))
+
+	var requeueAfter time.Duration
+	unauthorized := false
+	for _, backendErr := range errs {
+		switch {
+		case errors.Is(backendErr, errNestingCycle):
+		case errors.Is(backendErr, clients.ErrUnauthorized):
+			unauthorized = true
+		case clients.RetryAfter(backendErr) > 0:
+			requeueAfter = max(requeueAfter, clients.RetryAfter(backendErr))
+		default:
+			return ctrl.Result{}, err
+		}
+	}
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	if unauthorized {
+		return ctrl.Result{RequeueAfter: unauthorizedRequeueAfter}, nil
+	}
+	return ctrl.Result{}, reconcile.TerminalError(err)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+)
+
+func TestBackendsResult(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"30"}}
+	rateLimited := clients.StatusError(http.StatusTooManyRequests, header, "slow down")
+	unauthorized := clients.StatusError(http.StatusUnauthorized, nil, "invalid token")
+	transient := clients.Transient(errors.New("connection reset"))
+
+	result, err := backendsResult(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+
+	// rate limited backends are requeued once the longest delay elapsed
+	result, err = backendsResult([]error{rateLimited, unauthorized})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, result)
+
+	// rejected credentials are retried slowly, until they are fixed or rotated
+	result, err = backendsResult([]error{unauthorized})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: unauthorizedRequeueAfter}, result)
+	result, err = backendsResult([]error{clients.StatusError(http.StatusForbidden, nil, "no permission")})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: unauthorizedRequeueAfter}, result)
+
+	// any other error is retried with backoff
+	_, err = backendsResult([]error{rateLimited, transient})
+	assert.ErrorIs(t, err, clients.ErrTransient)
+	assert.NotErrorIs(t, err, reconcile.TerminalError(nil))
+
+	// a rate limit without delay is retried with backoff as well
+	_, err = backendsResult([]error{clients.StatusError(http.StatusTooManyRequests, nil, "slow down")})
+	assert.ErrorIs(t, err, clients.ErrRateLimited)
+	assert.NotErrorIs(t, err, reconcile.TerminalError(nil))
+
+	// cyclic group nestings aren't retried until the Groups change
+	cycle := fmt.Errorf("%w: default/a -> default/b -> default/a", errNestingCycle)
+	_, err = backendsResult([]error{cycle})
+	assert.ErrorIs(t, err, errNestingCycle)
+	assert.ErrorIs(t, err, reconcile.TerminalError(nil))
+	result, err = backendsResult([]error{cycle, unauthorized})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: unauthorizedRequeueAfter}, result)
+	_, err = backendsResult([]error{cycle, transient})
+	assert.ErrorIs(t, err, clients.ErrTransient)
+	assert.NotErrorIs(t, err, reconcile.TerminalError(nil))
+}
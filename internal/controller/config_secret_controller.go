@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// ConfigSecretReconciler reloads the application configuration when a value of a Secret
+// referenced through a 'secret|namespace/name/key' value changes
+type ConfigSecretReconciler struct {
+	client.Client
+}
+
+func (r *ConfigSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = logger.WithRequestId(ctx, controller.ReconcileIDFromContext(ctx))
+	log := logger.Logger(ctx).WithField("secret", req.NamespacedName.String())
+
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	changed, err := r.referencedValuesChanged(appConfig, req.NamespacedName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// the create events sent when the cache starts, and the updates of unreferenced
+	// keys, leave the values the active configuration was loaded with unchanged
+	if !changed {
+		log.Debug("referenced secret values unchanged, skipping the configuration reload")
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("referenced secret changed, reloading configuration")
+	if _, err := config.Reload(); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// referencedValuesChanged reports whether a value the active configuration references in the
+// Secret differs from the one it was loaded with, a missing Secret or key reading as empty
+func (r *ConfigSecretReconciler) referencedValuesChanged(appConfig *config.AppConfig,
+	secretKey types.NamespacedName) (bool, error) {
+	resolve := config.NewSecretResolver(r.Client)
+	for _, reference := range appConfig.References(config.SecretResolver) {
+		referencedKey, _, err := config.ParseSecretReference(reference)
+		if err != nil || referencedKey != secretKey {
+			continue
+		}
+		value, err := resolve(reference)
+		if err != nil && !errors.Is(err, config.ErrValueNotFound) {
+			return false, err
+		}
+		if loaded, _ := appConfig.ResolvedValue(config.SecretResolver, reference); loaded != value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isReferencedSecret reports whether the active configuration references the Secret
+func isReferencedSecret(obj client.Object) bool {
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		return false
+	}
+	for _, reference := range appConfig.References(config.SecretResolver) {
+		secretKey, _, err := config.ParseSecretReference(reference)
+		if err == nil && secretKey.Namespace == obj.GetNamespace() && secretKey.Name == obj.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ConfigSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("config-secret").
+		For(&corev1.Secret{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(isReferencedSecret),
+			predicate.ResourceVersionChangedPredicate{},
+		)).
+		Complete(r)
+}
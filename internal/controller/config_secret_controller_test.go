@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+const configSecretTestConfig = `
+app:
+  name: usernaut
+cache:
+  driver: memory
+pattern:
+  default:
+    - input: "^team-(.*)$"
+      output: "$1"
+backends:
+  - name: fivetran
+    type: fivetran
+    enabled: true
+    connection:
+      apikey: secret|usernaut/credentials/apikey
+      apisecret: secret|usernaut/credentials/apisecret:-none
+`
+
+func TestReferencedValuesChanged(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "usernaut", Name: "credentials"},
+		Data:       map[string][]byte{"apikey": []byte("key\n"), "unused": []byte("value")},
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(secret).Build()
+	config.RegisterResolver(config.SecretResolver, config.NewSecretResolver(kubeClient))
+
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "default.yaml"), []byte(configSecretTestConfig), 0o600))
+	loader := config.NewConfig(config.NewOptions(config.DefaultConfigType, configDir, config.DefaultConfigFileName))
+	appConfig, err := config.LoadAppConfig(loader, config.DefaultConfigFileName)
+	require.NoError(t, err)
+
+	r := &ConfigSecretReconciler{Client: kubeClient}
+	secretKey := types.NamespacedName{Namespace: "usernaut", Name: "credentials"}
+	assertChanged := func(expected bool) {
+		t.Helper()
+		changed, err := r.referencedValuesChanged(appConfig, secretKey)
+		require.NoError(t, err)
+		assert.Equal(t, expected, changed)
+	}
+
+	// the Secret the configuration was loaded with, as seen on the create events at startup
+	assertChanged(false)
+
+	// keys the configuration doesn't reference, and whitespace around the values, are ignored
+	secret.Data["unused"] = []byte("changed")
+	secret.Data["apikey"] = []byte("  key")
+	require.NoError(t, kubeClient.Update(ctx, secret))
+	assertChanged(false)
+
+	// a key replaced by its fallback while loading gets a value
+	secret.Data["apisecret"] = []byte("secret")
+	require.NoError(t, kubeClient.Update(ctx, secret))
+	assertChanged(true)
+	delete(secret.Data, "apisecret")
+
+	// a referenced value changes
+	secret.Data["apikey"] = []byte("rotated")
+	require.NoError(t, kubeClient.Update(ctx, secret))
+	assertChanged(true)
+
+	// the referenced Secret is deleted
+	require.NoError(t, kubeClient.Delete(ctx, secret))
+	assertChanged(true)
+
+	// a Secret the configuration doesn't reference
+	changed, err := r.referencedValuesChanged(appConfig, types.NamespacedName{Namespace: "usernaut", Name: "other"})
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
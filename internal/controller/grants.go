@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// managesGrants returns whether the Group lists the grants of its team on the backend, even none
+func managesGrants(backend usernautdevv1alpha1.GroupBackend) bool {
+	return backend.Grants != nil || backend.FivetranPermissions != nil
+}
+
+// desiredGrants expands the grants of the Group on the backend into one grant per privilege,
+// the fivetran memberships are grants of their role
+func desiredGrants(backend usernautdevv1alpha1.GroupBackend) []structs.Grant {
+	grants := make([]structs.Grant, 0, len(backend.Grants))
+	if permissions := backend.FivetranPermissions; permissions != nil {
+		for _, membership := range permissions.Destinations {
+			grants = append(grants, structs.Grant{
+				ObjectType: structs.DestinationObjectType,
+				ObjectName: membership.ID,
+				Privilege:  membership.Role,
+			})
+		}
+		for _, membership := range permissions.Connectors {
+			grants = append(grants, structs.Grant{
+				ObjectType: structs.ConnectorObjectType,
+				ObjectName: membership.ID,
+				Privilege:  membership.Role,
+			})
+		}
+	}
+	for _, grant := range backend.Grants {
+		for _, privilege := range grant.Privileges {
+			grants = append(grants, structs.Grant{
+				ObjectType: strings.ToLower(grant.ObjectType),
+				ObjectName: grant.ObjectName,
+				Privilege:  privilege,
+			})
+		}
+		for _, privilege := range grant.TablePrivileges {
+			tableGrant := structs.Grant{
+				ObjectType: structs.TableObjectType,
+				ObjectName: grant.ObjectName,
+				Privilege:  privilege,
+			}
+			grants = append(grants, tableGrant)
+			if grant.FutureTables {
+				tableGrant.Future = true
+				grants = append(grants, tableGrant)
+			}
+		}
+	}
+	return grants
+}
+
+// syncGrants grants the privileges listed by the Group to its team and revokes the others.
+// The grants found diverging are returned as drift, even when they couldn't be corrected.
+func (r *GroupReconciler) syncGrants(ctx context.Context, teamID string,
+	backend usernautdevv1alpha1.GroupBackend, manager clients.GrantManager) ([]string, error) {
+	existing, err := manager.FetchTeamGrants(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	// object names and privileges aren't case sensitive
+	existingKeys := make(map[string]struct{}, len(existing))
+	for _, grant := range existing {
+		existingKeys[strings.ToLower(grant.String())] = struct{}{}
+	}
+
+	drift := make([]string, 0)
+	desiredKeys := make(map[string]struct{})
+	grantsToAdd := make([]structs.Grant, 0)
+	for _, grant := range desiredGrants(backend) {
+		key := strings.ToLower(grant.String())
+		if _, found := desiredKeys[key]; found {
+			continue
+		}
+		desiredKeys[key] = struct{}{}
+		if _, found := existingKeys[key]; !found {
+			grantsToAdd = append(grantsToAdd, grant)
+			drift = append(drift, "missing "+grant.String())
+		}
+	}
+
+	grantsToRevoke := make([]structs.Grant, 0)
+	for _, grant := range existing {
+		if _, found := desiredKeys[strings.ToLower(grant.String())]; !found {
+			grantsToRevoke = append(grantsToRevoke, grant)
+			drift = append(drift, "unexpected "+grant.String())
+		}
+	}
+
+	// the backends holding a single privilege per object change it in place rather than
+	// revoking it and granting the new one, which would remove the access for a moment
+	grantsToModify := make([]structs.Grant, 0)
+	modifier, modifies := manager.(clients.GrantModifier)
+	if modifies {
+		revokedObjects := make(map[string]struct{}, len(grantsToRevoke))
+		for _, grant := range grantsToRevoke {
+			revokedObjects[grantObjectKey(grant)] = struct{}{}
+		}
+		modifiedObjects := make(map[string]struct{})
+		grantsToAdd = slices.DeleteFunc(grantsToAdd, func(grant structs.Grant) bool {
+			key := grantObjectKey(grant)
+			if _, found := revokedObjects[key]; !found {
+				return false
+			}
+			modifiedObjects[key] = struct{}{}
+			grantsToModify = append(grantsToModify, grant)
+			return true
+		})
+		grantsToRevoke = slices.DeleteFunc(grantsToRevoke, func(grant structs.Grant) bool {
+			_, found := modifiedObjects[grantObjectKey(grant)]
+			return found
+		})
+	}
+
+	if len(grantsToAdd) > 0 {
+		r.backendLogger.WithField("grant_count", len(grantsToAdd)).Info("granting privileges to the team")
+		if err := manager.GrantToTeam(ctx, teamID, grantsToAdd); err != nil {
+			return drift, err
+		}
+	}
+
+	if len(grantsToModify) > 0 {
+		r.backendLogger.WithField("grant_count", len(grantsToModify)).Info("changing privileges of the team")
+		if err := modifier.ModifyTeamGrants(ctx, teamID, grantsToModify); err != nil {
+			return drift, err
+		}
+	}
+
+	if len(grantsToRevoke) > 0 {
+		r.backendLogger.WithField("grant_count", len(grantsToRevoke)).Info("revoking privileges from the team")
+		if err := manager.RevokeFromTeam(ctx, teamID, grantsToRevoke); err != nil {
+			return drift, err
+		}
+	}
+
+	return drift, nil
+}
+
+// grantObjectKey identifies the object of a grant, whatever its privilege
+func grantObjectKey(grant structs.Grant) string {
+	grant.Privilege = ""
+	return strings.ToLower(grant.String())
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// fakeGrantManager records the privileges granted to and revoked from the team
+type fakeGrantManager struct {
+	grants  []structs.Grant
+	granted []structs.Grant
+	revoked []structs.Grant
+}
+
+func (f *fakeGrantManager) FetchTeamGrants(ctx context.Context, teamID string) ([]structs.Grant, error) {
+	return f.grants, nil
+}
+
+func (f *fakeGrantManager) GrantToTeam(ctx context.Context, teamID string, grants []structs.Grant) error {
+	f.granted = append(f.granted, grants...)
+	return nil
+}
+
+func (f *fakeGrantManager) RevokeFromTeam(ctx context.Context, teamID string, grants []structs.Grant) error {
+	f.revoked = append(f.revoked, grants...)
+	return nil
+}
+
+// fakeGrantModifier is a fakeGrantManager holding a single privilege per object
+type fakeGrantModifier struct {
+	fakeGrantManager
+	modified []structs.Grant
+}
+
+func (f *fakeGrantModifier) ModifyTeamGrants(ctx context.Context, teamID string, grants []structs.Grant) error {
+	f.modified = append(f.modified, grants...)
+	return nil
+}
+
+func TestSyncGrants(t *testing.T) {
+	backend := usernautdevv1alpha1.GroupBackend{
+		Name: "snowflake",
+		Type: "snowflake",
+		Grants: []usernautdevv1alpha1.BackendGrant{
+			{ObjectType: "warehouse", ObjectName: "compute_wh", Privileges: []string{"usage"}},
+			{
+				ObjectType:      "schema",
+				ObjectName:      "ANALYTICS.REPORTING",
+				TablePrivileges: []string{"SELECT"},
+				FutureTables:    true,
+			},
+		},
+	}
+	manager := &fakeGrantManager{grants: []structs.Grant{
+		{ObjectType: "warehouse", ObjectName: "COMPUTE_WH", Privilege: "USAGE"},
+		{ObjectType: structs.TableObjectType, ObjectName: "ANALYTICS.REPORTING", Privilege: "SELECT"},
+		{ObjectType: "database", ObjectName: "RAW", Privilege: "USAGE"},
+	}}
+	r := &GroupReconciler{backendLogger: logrus.NewEntry(logrus.New())}
+
+	// the names and privileges are matched regardless of their case
+	drift, err := r.syncGrants(context.Background(), "data-eng", backend, manager)
+	require.NoError(t, err)
+	assert.Equal(t, []structs.Grant{{
+		ObjectType: structs.TableObjectType,
+		ObjectName: "ANALYTICS.REPORTING",
+		Privilege:  "SELECT",
+		Future:     true,
+	}}, manager.granted)
+	assert.Equal(t, []structs.Grant{{ObjectType: "database", ObjectName: "RAW", Privilege: "USAGE"}}, manager.revoked)
+	assert.Equal(t, []string{
+		"missing SELECT on future tables in schema ANALYTICS.REPORTING",
+		"unexpected USAGE on database RAW",
+	}, drift)
+
+	// no drift once converged
+	manager = &fakeGrantManager{grants: desiredGrants(backend)}
+	drift, err = r.syncGrants(context.Background(), "data-eng", backend, manager)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+	assert.Empty(t, manager.granted)
+	assert.Empty(t, manager.revoked)
+}
+
+func TestDesiredGrantsFivetranPermissions(t *testing.T) {
+	backend := usernautdevv1alpha1.GroupBackend{
+		Name: "fivetran",
+		Type: "fivetran",
+		FivetranPermissions: &usernautdevv1alpha1.FivetranPermissions{
+			Destinations: []usernautdevv1alpha1.FivetranMembership{{ID: "warehouse", Role: "Destination Reviewer"}},
+			Connectors:   []usernautdevv1alpha1.FivetranMembership{{ID: "salesforce", Role: "Connector Administrator"}},
+		},
+	}
+	assert.True(t, managesGrants(backend))
+	assert.Equal(t, []structs.Grant{
+		{ObjectType: structs.DestinationObjectType, ObjectName: "warehouse", Privilege: "Destination Reviewer"},
+		{ObjectType: structs.ConnectorObjectType, ObjectName: "salesforce", Privilege: "Connector Administrator"},
+	}, desiredGrants(backend))
+
+	// a role change modifies the membership in place, the other memberships are revoked
+	manager := &fakeGrantModifier{fakeGrantManager: fakeGrantManager{grants: []structs.Grant{
+		{ObjectType: structs.ConnectorObjectType, ObjectName: "salesforce", Privilege: "Connector Reviewer"},
+		{ObjectType: structs.ConnectorObjectType, ObjectName: "hubspot", Privilege: "Connector Reviewer"},
+	}}}
+	r := &GroupReconciler{backendLogger: logrus.NewEntry(logrus.New())}
+	drift, err := r.syncGrants(context.Background(), "team", backend, manager)
+	require.NoError(t, err)
+	assert.Equal(t, []structs.Grant{
+		{ObjectType: structs.DestinationObjectType, ObjectName: "warehouse", Privilege: "Destination Reviewer"},
+	}, manager.granted)
+	assert.Equal(t, []structs.Grant{
+		{ObjectType: structs.ConnectorObjectType, ObjectName: "salesforce", Privilege: "Connector Administrator"},
+	}, manager.modified)
+	assert.Equal(t, manager.grants[1:], manager.revoked)
+	assert.Contains(t, drift, "missing Connector Administrator on connector salesforce")
+
+	// empty permissions remove every membership
+	assert.True(t, managesGrants(usernautdevv1alpha1.GroupBackend{
+		FivetranPermissions: &usernautdevv1alpha1.FivetranPermissions{},
+	}))
+	assert.False(t, managesGrants(usernautdevv1alpha1.GroupBackend{Name: "fivetran", Type: "fivetran"}))
+}
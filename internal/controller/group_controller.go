@@ -20,12 +20,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"slices"
+	"strings"
+	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -62,14 +68,25 @@ type GroupReconciler struct {
 	backendLogger   *logrus.Entry
 	LdapConn        ldap.LDAPClient
 	allLdapUserData map[string]*structs.LDAPUser
+	// ldapLeavers holds the lower cased members of the Group not found in LDAP anymore
+	ldapLeavers map[string]struct{}
+	// NamespaceSelector, when set, restricts the reconciled Groups to the namespaces with matching labels
+	NamespaceSelector labels.Selector
+	// configMu guards AppConfig and Cache which are replaced on configuration reload
+	configMu sync.RWMutex
 }
 
 //nolint:lll
-// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=groups,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=groups/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,namespace=usernaut,resources=groups/finalizers,verbs=update
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,resources=groups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,resources=groups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.dataverse.redhat.com,resources=groups/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// hold the configuration for the whole reconcile so that a reload can't swap it midway
+	r.configMu.RLock()
+	defer r.configMu.RUnlock()
+
 	ctx = logger.WithRequestId(ctx, controller.ReconcileIDFromContext(ctx))
 	r.log = logger.Logger(ctx).WithFields(logrus.Fields{
 		"request": req.NamespacedName.String(),
@@ -140,8 +157,12 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	// fetch all the data from LDAP for the users in the group
 	r.allLdapUserData = make(map[string]*structs.LDAPUser, 0)
+	r.ldapLeavers = make(map[string]struct{})
 	for _, user := range uniqueMembers {
 		ldapUserData, err := r.LdapConn.GetUserLDAPData(ctx, user)
+		if errors.Is(err, ldap.ErrNoUserFound) {
+			r.ldapLeavers[strings.ToLower(user)] = struct{}{}
+		}
 		if err != nil {
 			r.log.WithError(err).Error("error fetching user data from LDAP")
 			continue
@@ -153,11 +174,17 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			r.log.WithError(err).Error("error converting LDAP user data to struct")
 			continue
 		}
+		ldapUser.Attributes = make(map[string]string, len(ldapUserData))
+		for attribute, value := range ldapUserData {
+			ldapUser.Attributes[attribute] = fmt.Sprint(value)
+		}
 
 		r.allLdapUserData[user] = ldapUser
 	}
 
 	backendErrors := make(map[string]string, 0)
+	backendErrs := make([]error, 0)
+	backendDrift := make(map[string][]string, 0)
 	backendStatus := make([]usernautdevv1alpha1.BackendStatus, 0, len(groupCR.Spec.Backends))
 
 	for _, backend := range groupCR.Spec.Backends {
@@ -168,11 +195,12 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		})
 
 		// process each backend in the group CR
-		backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig.BackendMap)
+		backendClient, err := r.newBackendClient(groupCR, backend)
 		if err != nil {
 			r.backendLogger.WithError(err).Error("error creating backend client")
 			isError = true
 			backendErrors[backend.Type] = err.Error()
+			backendErrs = append(backendErrs, err)
 			continue
 		}
 		r.backendLogger.Debug("created backend client successfully")
@@ -182,16 +210,33 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		if err != nil {
 			r.backendLogger.WithError(err).Error("error fetching or creating team")
 			backendErrors[backend.Type] = err.Error()
+			backendErrs = append(backendErrs, err)
 			isError = true
 			continue
 		}
 		r.backendLogger.WithField("team_id", teamID).Info("fetched or created team successfully")
 
+		// backends nesting their teams get the nested Groups synced to them as member teams
+		backendMembers := uniqueMembers
+		memberGroups := []string{}
+		nester, nesting := clients.GetTeamNester(backendClient)
+		if nesting {
+			backendMembers, memberGroups, err = r.nestedMembers(ctx, groupCR, backend)
+			if err != nil {
+				r.backendLogger.WithError(err).Error("error fetching nested group members")
+				backendErrors[backend.Type] = err.Error()
+				backendErrs = append(backendErrs, err)
+				isError = true
+				continue
+			}
+		}
+
 		// create the users in backend and cache if they don't exist
-		err = r.createUsersInBackendAndCache(ctx, uniqueMembers, backend.Name, backend.Type, backendClient)
+		unresolvedUsers, err := r.createUsersInBackendAndCache(ctx, backendMembers, backend, teamID, backendClient)
 		if err != nil {
 			r.backendLogger.WithError(err).Error("error creating users in backend and cache")
 			backendErrors[backend.Type] = err.Error()
+			backendErrs = append(backendErrs, err)
 			isError = true
 			continue
 		}
@@ -199,9 +244,17 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 		// fetch the existing team members in the backend
 		members, err := backendClient.FetchTeamMembersByTeamID(ctx, teamID)
+		if errors.Is(err, clients.ErrNotFound) {
+			// the team was deleted from the backend, it is created again on the next reconciliation
+			r.backendLogger.WithError(err).Warn("team not found in backend, removing it from cache")
+			if cacheErr := r.forgetTeam(ctx, groupCR.Spec.GroupName, backend.Name, backend.Type); cacheErr != nil {
+				err = cacheErr
+			}
+		}
 		if err != nil {
 			r.backendLogger.WithError(err).Error("error fetching team members")
 			backendErrors[backend.Type] = err.Error()
+			backendErrs = append(backendErrs, err)
 			isError = true
 			continue
 		}
@@ -209,11 +262,13 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		// members field doesn't contains an email mapped to the user, we need to map it before finding the diff
 		r.backendLogger.WithField("team_members_count", len(members)).Info("fetched team members successfully")
 
-		usersToAdd, usersToRemove, err := r.processUsers(ctx, uniqueMembers, members, backend.Name, backend.Type)
+		usersToAdd, usersToRemove, err := r.processUsers(ctx, backendMembers, unresolvedUsers, members,
+			backend.Name, backend.Type)
 
 		if err != nil {
 			r.backendLogger.WithError(err).Error("error processing users")
 			backendErrors[backend.Type] = err.Error()
+			backendErrs = append(backendErrs, err)
 			isError = true
 			continue
 		}
@@ -224,24 +279,83 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			err := backendClient.AddUserToTeam(ctx, teamID, usersToAdd)
 			if err != nil {
 				r.backendLogger.WithError(err).Error("error while adding users to the team")
-				return ctrl.Result{}, err
+				backendErrors[backend.Type] = err.Error()
+				backendErrs = append(backendErrs, err)
+				isError = true
+				continue
 			}
 		}
 
 		r.backendLogger.WithField("users_to_add", usersToAdd).Info("added users to team successfully")
 
+		if manager, ok := clients.GetMemberRoleManager(backendClient); ok {
+			err := r.syncMemberRoles(ctx, teamID, backendMembers, unresolvedUsers, members, usersToAdd, backend, manager)
+			if err != nil {
+				r.backendLogger.WithError(err).Error("error syncing member roles")
+				backendErrors[backend.Type] = err.Error()
+				backendErrs = append(backendErrs, err)
+				isError = true
+				continue
+			}
+		} else if len(backend.MemberRoles) > 0 {
+			r.backendLogger.Warn("backend doesn't give members their own role, ignoring member_roles")
+		}
+
+		// the leavers are disabled before being removed, to be retried while they are in the team
+		if err := r.disableLeavers(ctx, usersToRemove, backend, backendClient); err != nil {
+			r.backendLogger.WithError(err).Error("error disabling leavers")
+			backendErrors[backend.Type] = err.Error()
+			backendErrs = append(backendErrs, err)
+			isError = true
+			continue
+		}
+
 		if len(usersToRemove) > 0 {
 			r.backendLogger.WithField("user_count", len(usersToRemove)).Info("removing users from a team")
 
 			err := backendClient.RemoveUserFromTeam(ctx, teamID, usersToRemove)
 			if err != nil {
 				r.backendLogger.WithError(err).Error("error while removing users from the team")
-				return ctrl.Result{}, err
+				backendErrors[backend.Type] = err.Error()
+				backendErrs = append(backendErrs, err)
+				isError = true
+				continue
 			}
 
 		}
 
 		r.backendLogger.WithField("users_to_remove", usersToRemove).Info("removed users from team successfully")
+
+		if nesting {
+			if err := r.syncMemberTeams(ctx, teamID, memberGroups, backend, backendClient, nester); err != nil {
+				r.backendLogger.WithError(err).Error("error syncing member teams")
+				backendErrors[backend.Type] = err.Error()
+				backendErrs = append(backendErrs, err)
+				isError = true
+				continue
+			}
+			r.backendLogger.WithField("member_groups", memberGroups).Info("synced member teams successfully")
+		}
+
+		// the privileges of the team are managed once the Group lists its grants, even none
+		if !managesGrants(backend) {
+			continue
+		}
+		manager, ok := clients.GetGrantManager(backendClient)
+		if !ok {
+			r.backendLogger.Warn("backend doesn't manage grants, ignoring them")
+			continue
+		}
+		drift, err := r.syncGrants(ctx, teamID, backend, manager)
+		backendDrift[backend.Type] = drift
+		if err != nil {
+			r.backendLogger.WithError(err).Error("error syncing team grants")
+			backendErrors[backend.Type] = err.Error()
+			backendErrs = append(backendErrs, err)
+			isError = true
+			continue
+		}
+		r.backendLogger.WithField("grants_drift", drift).Info("synced team grants successfully")
 	}
 
 	// Updating status
@@ -258,6 +372,7 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			status.Status = true
 			status.Message = "Successful"
 		}
+		status.GrantsDrift = backendDrift[backend.Type]
 		backendStatus = append(backendStatus, status)
 	}
 	groupCR.Status.BackendsStatus = backendStatus
@@ -266,11 +381,33 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		r.log.WithError(updateStatusErr).Error("error while updating final status")
 	}
 
-	if len(backendErrors) > 0 {
-		return ctrl.Result{}, errors.New("failed to reconcile all backends")
-	}
+	return backendsResult(backendErrs)
+}
 
-	return ctrl.Result{}, nil
+// UpdateConfig replaces the configuration and cache used by the reconciler,
+// waiting for the in-flight reconcile to finish
+func (r *GroupReconciler) UpdateConfig(appConfig *config.AppConfig, store cache.Cache) {
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+	r.AppConfig = appConfig
+	r.Cache = store
+}
+
+// errForeignBackend is returned when a Group uses the backend of a Backend CR of another namespace
+var errForeignBackend = errors.New("backend is only available to the Groups of the namespace defining it")
+
+// newBackendClient returns the client of a backend of the Group. The backends of Backend CRs are only
+// available to the Groups of their namespace, as are the credentials they are defined with.
+func (r *GroupReconciler) newBackendClient(groupCR *usernautdevv1alpha1.Group,
+	backend usernautdevv1alpha1.GroupBackend) (clients.Client, error) {
+	backendConfig, found := r.AppConfig.GetBackendMap()[backend.Type][backend.Name]
+	if found && backendConfig.Source != "" {
+		if namespace, _, _ := strings.Cut(backendConfig.Source, "/"); namespace != groupCR.Namespace {
+			return nil, fmt.Errorf("%w: %s backend %s is defined by %s", errForeignBackend,
+				backend.Type, backend.Name, backendConfig.Source)
+		}
+	}
+	return clients.New(backend.Name, backend.Type, r.AppConfig)
 }
 
 func (r *GroupReconciler) deleteBackendsTeam(ctx context.Context, groupCR *usernautdevv1alpha1.Group) error {
@@ -290,7 +427,12 @@ func (r *GroupReconciler) deleteBackendsTeam(ctx context.Context, groupCR *usern
 			return err
 		}
 
-		backendClient, err := clients.New(backend.Name, backend.Type, r.AppConfig.BackendMap)
+		backendClient, err := r.newBackendClient(groupCR, backend)
+		if errors.Is(err, errForeignBackend) {
+			// the Group never synced a team to the backend
+			backendLoggerInfo.WithError(err).Warn("Finalizer: skipping the backend of another namespace")
+			continue
+		}
 		if err != nil {
 			backendLoggerInfo.WithError(err).Errorf("Finalizer: error creating client for backend %s", backend.Name)
 			return err
@@ -345,6 +487,7 @@ func (r *GroupReconciler) deleteBackendsTeam(ctx context.Context, groupCR *usern
 
 func (r *GroupReconciler) processUsers(ctx context.Context,
 	groupUsers []string,
+	unresolvedUsers map[string]struct{},
 	existingTeamMembers map[string]*structs.User,
 	backendName, backendType string) ([]string, []string, error) {
 
@@ -364,28 +507,13 @@ func (r *GroupReconciler) processUsers(ctx context.Context,
 			}
 			continue
 		}
-
-		userDetailsMap := make(map[string]string)
-		userDetailsInCache, err := r.Cache.Get(ctx, userDetails.GetEmail())
-		if err != nil && err != redis.Nil || userDetailsInCache == "" {
-			r.backendLogger.WithError(err).Error("error fetching user details from cache")
-			return nil, nil, err
-		}
-
-		userDetailsStr, ok := userDetailsInCache.(string)
-		if !ok {
-			r.backendLogger.WithField("user", user).Error("user details in cache are not of type string")
-			return nil, nil, errors.New("user details in cache are not of type string")
+		if _, unresolved := unresolvedUsers[user]; unresolved {
+			continue
 		}
 
-		if jErr := json.Unmarshal([]byte(userDetailsStr), &userDetailsMap); jErr != nil {
-			r.backendLogger.WithField("user", user).WithError(jErr).Error("error unmarshalling user details from cache")
-			return nil, nil, jErr
-		}
-		userID := userDetailsMap[backendName+"_"+backendType]
-		if userID == "" {
-			r.backendLogger.WithField("user", user).Warn("user ID not found in cache, will create user in backend")
-			return nil, nil, errors.New("user ID not found in cache")
+		userID, err := r.cachedUserID(ctx, user, userDetails, backendName, backendType)
+		if err != nil {
+			return nil, nil, err
 		}
 		userIDsToSync = append(userIDsToSync, userID)
 	}
@@ -408,11 +536,45 @@ func (r *GroupReconciler) processUsers(ctx context.Context,
 	return usersToAdd, usersToRemove, nil
 }
 
+// cachedUserID returns the ID of the user in the backend, as stored in the cache under its email
+func (r *GroupReconciler) cachedUserID(ctx context.Context, user string, userDetails *structs.LDAPUser,
+	backendName, backendType string) (string, error) {
+	userDetailsMap := make(map[string]string)
+	userDetailsInCache, err := r.Cache.Get(ctx, userDetails.GetEmail())
+	if err != nil && err != redis.Nil || userDetailsInCache == "" {
+		r.backendLogger.WithError(err).Error("error fetching user details from cache")
+		return "", err
+	}
+
+	userDetailsStr, ok := userDetailsInCache.(string)
+	if !ok {
+		r.backendLogger.WithField("user", user).Error("user details in cache are not of type string")
+		return "", errors.New("user details in cache are not of type string")
+	}
+
+	if jErr := json.Unmarshal([]byte(userDetailsStr), &userDetailsMap); jErr != nil {
+		r.backendLogger.WithField("user", user).WithError(jErr).Error("error unmarshalling user details from cache")
+		return "", jErr
+	}
+	userID := userDetailsMap[backendName+"_"+backendType]
+	if userID == "" {
+		r.backendLogger.WithField("user", user).Warn("user ID not found in cache, will create user in backend")
+		return "", errors.New("user ID not found in cache")
+	}
+	return userID, nil
+}
+
+// createUsersInBackendAndCache creates the users missing from the cache in the backend, with the
+// user properties of the Group and its team. On backends not provisioning users the accounts are only
+// looked up, the users not found are returned as unresolved and left out of the team instead of failing
+// the reconciliation.
 func (r *GroupReconciler) createUsersInBackendAndCache(ctx context.Context,
 	users []string,
-	backendName, backendType string,
-	backendClient clients.Client) error {
+	backend usernautdevv1alpha1.GroupBackend, teamID string,
+	backendClient clients.Client) (map[string]struct{}, error) {
 
+	userProvisioning := clients.GetCapabilities(backendClient).UserProvisioning
+	unresolvedUsers := make(map[string]struct{})
 	for _, user := range users {
 		userDetails := r.allLdapUserData[user]
 		if userDetails == nil {
@@ -426,9 +588,9 @@ func (r *GroupReconciler) createUsersInBackendAndCache(ctx context.Context,
 			// handle error for below statement
 			if jErr := json.Unmarshal([]byte(userDetailsInCache.(string)), &userDetailsMap); jErr != nil {
 				r.backendLogger.WithField("user", user).WithError(jErr).Error("error unmarshalling user details from cache")
-				return jErr
+				return nil, jErr
 			}
-			userID := userDetailsMap[backendName+"_"+backendType]
+			userID := userDetailsMap[backend.Name+"_"+backend.Type]
 			if userID != "" {
 				r.backendLogger.WithField("user", user).Debug("user already exists in cache")
 				continue
@@ -442,23 +604,35 @@ func (r *GroupReconciler) createUsersInBackendAndCache(ctx context.Context,
 			Role:      fivetran.AccountReviewerRole,
 			FirstName: userDetails.GetDisplayName(),
 			LastName:  userDetails.GetSN(),
+
+			Attributes: userDetails.GetAttributes(),
+			Team:       teamID,
+			Properties: backend.UserProperties,
 		})
+		if errors.Is(err, clients.ErrAlreadyExists) {
+			// the user is missing from the cache only, e.g. after it was flushed
+			newUser, err = r.adoptUser(ctx, userDetails.GetEmail(), backendClient, err)
+		}
+		if errors.Is(err, clients.ErrNotFound) && !userProvisioning {
+			r.backendLogger.WithField("user", user).WithError(err).Warn("user has no account in backend, skipping")
+			unresolvedUsers[user] = struct{}{}
+			continue
+		}
 		if err != nil {
-			// TODO: handle the error in case user already exists in backend, we need to again populate the cache
 			r.backendLogger.WithField("user", user).WithError(err).Error("error creating user in backend")
-			return err
+			return nil, err
 		}
 		r.backendLogger.WithField("user", user).Info("created user in backend successfully")
 
-		userDetailsMap[backendName+"_"+backendType] = newUser.ID
+		userDetailsMap[backend.Name+"_"+backend.Type] = newUser.ID
 		toBeUpdated, _ := json.Marshal(userDetailsMap)
 		if err := r.Cache.Set(ctx, userDetails.GetEmail(), string(toBeUpdated), cache.NoExpiration); err != nil {
 			r.backendLogger.Error(err, "error updating user details in cache")
-			return err
+			return nil, err
 		}
 		r.backendLogger.WithField("user", user).Info("updated user details in cache successfully")
 	}
-	return nil
+	return unresolvedUsers, nil
 }
 
 func (r *GroupReconciler) fetchOrCreateTeam(ctx context.Context,
@@ -495,8 +669,11 @@ func (r *GroupReconciler) fetchOrCreateTeam(ctx context.Context,
 		Description: "team for " + groupName,
 		Role:        fivetran.AccountReviewerRole,
 	})
+	if errors.Is(err, clients.ErrAlreadyExists) {
+		// the team is missing from the cache only, e.g. after it was flushed
+		newTeam, err = r.adoptTeam(ctx, transformed_group_name, backendClient, err)
+	}
 	if err != nil {
-		// TODO: handle the error in case team already exists in backend, we need to again populate the cache
 		r.backendLogger.WithError(err).Error("error creating team in backend")
 		return "", err
 	}
@@ -516,6 +693,76 @@ func (r *GroupReconciler) fetchOrCreateTeam(ctx context.Context,
 	return newTeam.ID, nil
 }
 
+// adoptTeam returns the team of the backend with the name, for the backends listing their teams.
+// createErr is returned when the team can't be found.
+func (r *GroupReconciler) adoptTeam(ctx context.Context, name string,
+	backendClient clients.Client, createErr error) (*structs.Team, error) {
+	if !clients.GetCapabilities(backendClient).TeamListing {
+		return nil, createErr
+	}
+
+	teams, err := backendClient.FetchAllTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for teamName, team := range teams {
+		if strings.EqualFold(teamName, name) {
+			r.backendLogger.WithField("team_id", team.ID).Info("team already exists in backend, adopting it")
+			return &team, nil
+		}
+	}
+	return nil, createErr
+}
+
+// adoptUser returns the user of the backend with the email, for the backends listing their users.
+// createErr is returned when the user can't be found.
+func (r *GroupReconciler) adoptUser(ctx context.Context, email string,
+	backendClient clients.Client, createErr error) (*structs.User, error) {
+	if !clients.GetCapabilities(backendClient).UserListing {
+		return nil, createErr
+	}
+
+	// the maps aren't keyed the same way by every backend, the users are matched on their email
+	users, _, err := backendClient.FetchAllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if strings.EqualFold(user.GetEmail(), email) {
+			r.backendLogger.WithField("user_id", user.ID).Info("user already exists in backend, adopting it")
+			return user, nil
+		}
+	}
+	return nil, createErr
+}
+
+// forgetTeam removes the ID of the team in the backend from the cache, for the team to be created again
+func (r *GroupReconciler) forgetTeam(ctx context.Context, groupName, backendName, backendType string) error {
+	transformedGroupName, err := utils.GetTransformedGroupName(r.AppConfig, backendType, groupName)
+	if err != nil {
+		return err
+	}
+
+	teamDetailsInCache, err := r.Cache.Get(ctx, transformedGroupName)
+	if err != nil || teamDetailsInCache == "" {
+		return nil
+	}
+	teamDetailsMap := make(map[string]string)
+	if err := json.Unmarshal([]byte(teamDetailsInCache.(string)), &teamDetailsMap); err != nil {
+		return err
+	}
+
+	delete(teamDetailsMap, backendName+"_"+backendType)
+	if len(teamDetailsMap) == 0 {
+		return r.Cache.Delete(ctx, transformedGroupName)
+	}
+	toBeUpdated, err := json.Marshal(teamDetailsMap)
+	if err != nil {
+		return err
+	}
+	return r.Cache.Set(ctx, transformedGroupName, string(toBeUpdated), cache.NoExpiration)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Add an index field for referenced groups
@@ -523,7 +770,12 @@ func (r *GroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	groupType := &usernautdevv1alpha1.Group{}
 	indexFunc := func(obj client.Object) []string {
 		group := obj.(*usernautdevv1alpha1.Group)
-		return group.Spec.Members.Groups
+		// index by namespace/name so that references across namespaces are found
+		refs := make([]string, 0, len(group.Spec.Members.Groups))
+		for _, ref := range group.Spec.Members.Groups {
+			refs = append(refs, groupRefKey(ref, group.Namespace).String())
+		}
+		return refs
 	}
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), groupType, indexField, indexFunc); err != nil {
 		return err
@@ -536,7 +788,7 @@ func (r *GroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 		// Find all Group CRs that reference this Group in their spec.members.groups
 		if err := r.List(ctx, &referencingGroups, client.MatchingFields{
-			indexField: group.Name,
+			indexField: client.ObjectKeyFromObject(group).String(),
 		}); err != nil {
 			r.log.WithError(err).Error("error listing referencing groups")
 			return nil
@@ -555,31 +807,44 @@ func (r *GroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return requests
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&usernautdevv1alpha1.Group{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+	groupPredicates := []predicate.Predicate{predicate.GenerationChangedPredicate{}}
+	if r.NamespaceSelector != nil {
+		groupPredicates = append(groupPredicates, namespaceSelectorPredicate(mgr.GetClient(), r.NamespaceSelector))
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&usernautdevv1alpha1.Group{}, builder.WithPredicates(groupPredicates...)).
 		Watches(
 			client.Object(&usernautdevv1alpha1.Group{}),
 			handler.EnqueueRequestsFromMapFunc(mapFunc),
-		).
-		Complete(r)
+			builder.WithPredicates(groupPredicates...),
+		)
+	if r.NamespaceSelector != nil {
+		controllerBuilder = controllerBuilder.Watches(
+			&corev1.Namespace{},
+			enqueueMatchingNamespace(mgr.GetClient(), r.NamespaceSelector, &usernautdevv1alpha1.GroupList{}),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		)
+	}
+	return controllerBuilder.Complete(r)
 }
 
 func (r *GroupReconciler) fetchUniqueGroupMembers(ctx context.Context, groupName,
 	namespace string, visitedOnPath map[string]struct{}) ([]string, error) {
 
-	r.log.WithField("group", groupName).Info("fetching group members")
+	groupKey := types.NamespacedName{Namespace: namespace, Name: groupName}
+	r.log.WithField("group", groupKey.String()).Info("fetching group members")
 
 	// Handle cyclic dependencies for the current recursion path.
-	if _, ok := visitedOnPath[groupName]; ok {
-		r.log.WithField("group", groupName).Warn("cyclic group dependency detected; returning empty member list")
+	if _, ok := visitedOnPath[groupKey.String()]; ok {
+		r.log.WithField("group", groupKey.String()).Warn("cyclic group dependency detected; returning empty member list")
 		return []string{}, nil
 	}
-	visitedOnPath[groupName] = struct{}{}
-	defer delete(visitedOnPath, groupName) // Remove from path when returning.
+	visitedOnPath[groupKey.String()] = struct{}{}
+	defer delete(visitedOnPath, groupKey.String()) // Remove from path when returning.
 
 	groupCR := &usernautdevv1alpha1.Group{}
-	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: groupName}, groupCR); err != nil {
+	if err := r.Client.Get(ctx, groupKey, groupCR); err != nil {
 		r.log.WithError(err).Error("error fetching the group CR")
 		return nil, err
 	}
@@ -587,8 +852,10 @@ func (r *GroupReconciler) fetchUniqueGroupMembers(ctx context.Context, groupName
 	members := make([]string, 0)
 	members = append(members, groupCR.Spec.Members.Users...)
 
+	// nested groups are either in the same namespace or referenced as namespace/name
 	for _, subGroup := range groupCR.Spec.Members.Groups {
-		subMembers, err := r.fetchUniqueGroupMembers(ctx, subGroup, namespace, visitedOnPath)
+		subGroupKey := groupRefKey(subGroup, namespace)
+		subMembers, err := r.fetchUniqueGroupMembers(ctx, subGroupKey.Name, subGroupKey.Namespace, visitedOnPath)
 		if err != nil {
 			return nil, err
 		}
@@ -614,10 +881,14 @@ func (r *GroupReconciler) deduplicateMembers(members []string) []string {
 func (r *GroupReconciler) setOwnerReference(ctx context.Context, groupCR *usernautdevv1alpha1.Group) error {
 	// Determine the desired owner references from parent groups
 	desiredOwnerRefs := make(map[types.UID]metav1.OwnerReference)
-	for _, parentGroupName := range groupCR.Spec.Members.Groups {
+	for _, parentGroupRef := range groupCR.Spec.Members.Groups {
+		parentGroupKey := groupRefKey(parentGroupRef, groupCR.Namespace)
+		if parentGroupKey.Namespace != groupCR.Namespace {
+			// owner references can't cross namespaces
+			continue
+		}
 		parentGroupCR := &usernautdevv1alpha1.Group{}
-		if err := r.Client.Get(ctx,
-			client.ObjectKey{Namespace: groupCR.Namespace, Name: parentGroupName}, parentGroupCR); err != nil {
+		if err := r.Client.Get(ctx, parentGroupKey, parentGroupCR); err != nil {
 			r.log.WithError(err).Error("error fetching the parent group CR")
 			return err
 		}
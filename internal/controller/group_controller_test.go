@@ -70,7 +70,7 @@ var _ = Describe("Group Controller", func() {
 							Groups: []string{},
 							Users:  []string{"test-user-1", "test-user-2"},
 						},
-						Backends: []usernautdevv1alpha1.Backend{
+						Backends: []usernautdevv1alpha1.GroupBackend{
 							{
 								Name: "fivetran",
 								Type: "fivetran",
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+)
+
+// disableLeaversKey is the connection setting of the backends disabling the leavers
+const disableLeaversKey = "disable_leavers"
+
+// disableLeavers disables the users removed from the team because they left LDAP, on the backends
+// with the disable_leavers setting able to disable users, so that they are locked out of the
+// backend while keeping the objects they own
+func (r *GroupReconciler) disableLeavers(ctx context.Context, removedUserIDs []string,
+	backend usernautdevv1alpha1.GroupBackend, backendClient clients.Client) error {
+	backendConfig := r.AppConfig.GetBackendMap()[backend.Type][backend.Name]
+	if !backendConfig.GetBoolConnection(disableLeaversKey, false) {
+		return nil
+	}
+	disabler, ok := clients.GetUserDisabler(backendClient)
+	if !ok {
+		r.backendLogger.Warn("backend can't disable users, the leavers are only removed from the team")
+		return nil
+	}
+
+	var errs []error
+	disabled := make(map[string]struct{})
+	for _, userID := range removedUserIDs {
+		if _, leaver := r.ldapLeavers[strings.ToLower(userID)]; !leaver {
+			continue
+		}
+		if _, done := disabled[userID]; done {
+			continue
+		}
+		if err := disabler.DisableUser(ctx, userID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to disable leaver %s: %w", userID, err))
+			continue
+		}
+		disabled[userID] = struct{}{}
+		r.backendLogger.WithField("user", userID).Info("disabled leaver")
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+// fakeDisabler records the users it disabled, failing for the ones in failing
+type fakeDisabler struct {
+	clients.Client
+	disabled []string
+	failing  map[string]bool
+}
+
+func (f *fakeDisabler) Capabilities() structs.Capabilities {
+	capabilities := clients.DefaultCapabilities()
+	capabilities.UserDisable = true
+	return capabilities
+}
+
+func (f *fakeDisabler) DisableUser(ctx context.Context, userID string) error {
+	if f.failing[userID] {
+		return errors.New("user is locked")
+	}
+	f.disabled = append(f.disabled, userID)
+	return nil
+}
+
+func TestDisableLeavers(t *testing.T) {
+	ctx := context.Background()
+	snowflake := usernautdevv1alpha1.GroupBackend{Name: "snowflake", Type: "snowflake"}
+	r := newNestingReconciler(t)
+	r.ldapLeavers = map[string]struct{}{"jdoe": {}, "asmith": {}}
+	setDisableLeavers := func(value interface{}) {
+		r.AppConfig.BackendMap = map[string]map[string]config.Backend{"snowflake": {"snowflake": {
+			Name: "snowflake", Type: "snowflake", Enabled: true,
+			Connection: map[string]interface{}{disableLeaversKey: value},
+		}}}
+	}
+
+	// the leavers are only removed from the team unless the backend disables them
+	disabler := &fakeDisabler{}
+	setDisableLeavers(false)
+	assert.NoError(t, r.disableLeavers(ctx, []string{"JDoe", "bob"}, snowflake, disabler))
+	assert.Empty(t, disabler.disabled)
+
+	// the members removed from the Group but still in LDAP are kept enabled
+	setDisableLeavers("true")
+	assert.NoError(t, r.disableLeavers(ctx, []string{"JDoe", "bob", "JDoe"}, snowflake, disabler))
+	assert.Equal(t, []string{"JDoe"}, disabler.disabled)
+
+	disabler = &fakeDisabler{failing: map[string]bool{"asmith": true}}
+	assert.ErrorContains(t, r.disableLeavers(ctx, []string{"asmith", "jdoe"}, snowflake, disabler),
+		"failed to disable leaver asmith: user is locked")
+	assert.Equal(t, []string{"jdoe"}, disabler.disabled)
+
+	// the backends not able to disable users only remove them
+	assert.NoError(t, r.disableLeavers(ctx, []string{"jdoe"}, snowflake, &fakeNester{}))
+}
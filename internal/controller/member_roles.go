@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// syncMemberRoles gives the members of the team the role listed for them in the member_roles of
+// the Group backend, and the default role of the backend to the others. The members fetched before
+// adding the new ones are compared with their current role, the added ones have the default role.
+func (r *GroupReconciler) syncMemberRoles(ctx context.Context, teamID string, users []string,
+	unresolvedUsers map[string]struct{}, members map[string]*structs.User, addedUserIDs []string,
+	backend usernautdevv1alpha1.GroupBackend, manager clients.MemberRoleManager) error {
+	defaultRole, err := manager.MemberRole("")
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, user := range users {
+		userDetails := r.allLdapUserData[user]
+		if userDetails == nil {
+			continue
+		}
+		if _, unresolved := unresolvedUsers[user]; unresolved {
+			continue
+		}
+		userID, err := r.cachedUserID(ctx, user, userDetails, backend.Name, backend.Type)
+		if err != nil {
+			return err
+		}
+
+		role, err := manager.MemberRole(backend.MemberRoles[user])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid role of %s: %w", user, err))
+			continue
+		}
+
+		currentRole := defaultRole
+		if member, ok := members[userID]; ok {
+			currentRole = member.Role
+		} else if !slices.Contains(addedUserIDs, userID) {
+			continue
+		}
+		if role == currentRole {
+			continue
+		}
+		if err := manager.SetTeamMemberRole(ctx, teamID, userID, role); err != nil {
+			errs = append(errs, fmt.Errorf("failed to give %s the role %s: %w", user, role, err))
+			continue
+		}
+		r.backendLogger.WithFields(logrus.Fields{"user": user, "role": role}).Info("changed the role of a team member")
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// fakeRoleManager records the roles it gave, by user ID, failing for the users in failing
+type fakeRoleManager struct {
+	clients.Client
+	roles   map[string]string
+	failing map[string]bool
+}
+
+func (f *fakeRoleManager) Capabilities() structs.Capabilities {
+	capabilities := clients.DefaultCapabilities()
+	capabilities.MembershipRoles = true
+	return capabilities
+}
+
+func (f *fakeRoleManager) MemberRole(role string) (string, error) {
+	switch strings.ToLower(role) {
+	case "":
+		return "developer", nil
+	case "developer", "maintainer":
+		return strings.ToLower(role), nil
+	}
+	return "", errors.New("unknown role")
+}
+
+func (f *fakeRoleManager) SetTeamMemberRole(ctx context.Context, teamID, userID, role string) error {
+	if f.failing[userID] {
+		return errors.New("member is locked")
+	}
+	f.roles[userID] = role
+	return nil
+}
+
+func TestSyncMemberRoles(t *testing.T) {
+	ctx := context.Background()
+	r := newNestingReconciler(t)
+	r.allLdapUserData = map[string]*structs.LDAPUser{}
+	for i, user := range []string{"jdoe", "asmith", "bob", "carol"} {
+		email := user + "@example.com"
+		r.allLdapUserData[user] = &structs.LDAPUser{UID: user, Email: email}
+		details, err := json.Marshal(map[string]string{"gitlab_gitlab": string(rune('1' + i))})
+		require.NoError(t, err)
+		require.NoError(t, r.Cache.Set(ctx, email, string(details), cache.NoExpiration))
+	}
+
+	backend := usernautdevv1alpha1.GroupBackend{Name: "gitlab", Type: "gitlab", MemberRoles: map[string]string{
+		"jdoe":   "Maintainer",
+		"asmith": "maintainer",
+		"bob":    "owner",
+	}}
+	members := map[string]*structs.User{
+		"1": {ID: "1", Role: "developer"},
+		"2": {ID: "2", Role: "maintainer"},
+		"4": {ID: "4", Role: "maintainer"},
+	}
+
+	// jdoe is promoted, asmith already is a maintainer, bob has an unknown role and carol is
+	// back to the default role once dropped from member_roles
+	manager := &fakeRoleManager{roles: map[string]string{}}
+	err := r.syncMemberRoles(ctx, "team", []string{"jdoe", "asmith", "bob", "carol"}, nil, members, nil,
+		backend, manager)
+	assert.ErrorContains(t, err, "invalid role of bob")
+	assert.Equal(t, map[string]string{"1": "maintainer", "4": "developer"}, manager.roles)
+
+	// the added members have the default role, the unresolved users aren't in the team
+	manager = &fakeRoleManager{roles: map[string]string{}, failing: map[string]bool{"1": true}}
+	err = r.syncMemberRoles(ctx, "team", []string{"jdoe", "asmith", "carol"},
+		map[string]struct{}{"carol": {}}, map[string]*structs.User{}, []string{"1", "2"}, backend, manager)
+	assert.ErrorContains(t, err, "failed to give jdoe the role maintainer: member is locked")
+	assert.Equal(t, map[string]string{"2": "maintainer"}, manager.roles)
+}
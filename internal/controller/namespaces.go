@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	k8sCache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+const (
+	// WatchedNamespacesEnv is a comma separated list of the namespaces to watch, "*" watches the whole cluster
+	WatchedNamespacesEnv = "WATCHED_NAMESPACES"
+	// WatchedNamespaceEnv is the single namespace to watch, kept for backwards compatibility
+	WatchedNamespaceEnv = "WATCHED_NAMESPACE"
+	// WatchedNamespaceSelectorEnv restricts the watched namespaces to the ones matching the label selector
+	WatchedNamespaceSelectorEnv = "WATCHED_NAMESPACE_SELECTOR"
+
+	// SecretWatchLabel marks the Secrets cached when watching the whole cluster, the
+	// Secrets of the watched namespaces are cached otherwise
+	SecretWatchLabel = "usernaut.dataverse.redhat.com/watch"
+
+	// DefaultWatchedNamespace is watched when no namespace is configured
+	DefaultWatchedNamespace = "usernaut"
+	// allNamespaces is the value of WATCHED_NAMESPACES watching the whole cluster
+	allNamespaces = "*"
+)
+
+// WatchNamespaces describes the namespaces whose Group and Backend CRs are reconciled
+type WatchNamespaces struct {
+	// Namespaces lists the watched namespaces, all the namespaces are watched when empty
+	Namespaces []string
+	// Selector, when set, restricts the watched namespaces to the ones with matching labels
+	Selector labels.Selector
+}
+
+// WatchNamespacesFromEnv reads the namespaces to watch from the environment.
+// WATCHED_NAMESPACES takes precedence over WATCHED_NAMESPACE, and the "usernaut"
+// namespace is watched when neither is set.
+func WatchNamespacesFromEnv() (WatchNamespaces, error) {
+	var watch WatchNamespaces
+
+	namespaces := os.Getenv(WatchedNamespacesEnv)
+	if namespaces == "" {
+		namespaces = os.Getenv(WatchedNamespaceEnv)
+	}
+	if namespaces == "" {
+		namespaces = DefaultWatchedNamespace
+	}
+	if strings.TrimSpace(namespaces) != allNamespaces {
+		for _, namespace := range strings.Split(namespaces, ",") {
+			if namespace = strings.TrimSpace(namespace); namespace != "" {
+				watch.Namespaces = append(watch.Namespaces, namespace)
+			}
+		}
+	}
+
+	if selector := os.Getenv(WatchedNamespaceSelectorEnv); selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return WatchNamespaces{}, fmt.Errorf("invalid %s %q: %w", WatchedNamespaceSelectorEnv, selector, err)
+		}
+		watch.Selector = parsed
+	}
+
+	return watch, nil
+}
+
+// ClusterWide reports whether the CRs of all the namespaces are cached
+func (w WatchNamespaces) ClusterWide() bool {
+	return len(w.Namespaces) == 0
+}
+
+// CacheOptions returns the manager cache options restricted to the watched namespaces.
+// When watching the whole cluster, only the Secrets labelled with SecretWatchLabel
+// are cached instead of every Secret of the cluster, which are still listed at cluster
+// scope and require the cluster-secret-reader ClusterRole of config/rbac.
+func (w WatchNamespaces) CacheOptions() k8sCache.Options {
+	if w.ClusterWide() {
+		return k8sCache.Options{
+			ByObject: map[client.Object]k8sCache.ByObject{
+				&corev1.Secret{}: {Label: labels.SelectorFromSet(labels.Set{SecretWatchLabel: "true"})},
+			},
+		}
+	}
+	defaultNamespaces := make(map[string]k8sCache.Config, len(w.Namespaces))
+	for _, namespace := range w.Namespaces {
+		defaultNamespaces[namespace] = k8sCache.Config{}
+	}
+	return k8sCache.Options{DefaultNamespaces: defaultNamespaces}
+}
+
+// String describes the watched namespaces for logging
+func (w WatchNamespaces) String() string {
+	namespaces := allNamespaces
+	if !w.ClusterWide() {
+		namespaces = strings.Join(w.Namespaces, ",")
+	}
+	if w.Selector != nil {
+		return fmt.Sprintf("%s (selector %s)", namespaces, w.Selector.String())
+	}
+	return namespaces
+}
+
+// namespaceSelectorPredicate filters out the events of objects whose namespace labels
+// don't match the selector. Namespaces are read through the manager cache, the CRs
+// of relabelled namespaces are enqueued by enqueueMatchingNamespace.
+func namespaceSelectorPredicate(reader client.Reader, selector labels.Selector) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		namespace := &corev1.Namespace{}
+		if err := reader.Get(context.Background(), types.NamespacedName{Name: obj.GetNamespace()}, namespace); err != nil {
+			logger.Logger(context.Background()).WithError(err).WithField("namespace", obj.GetNamespace()).
+				Error("error fetching the namespace, ignoring the event")
+			return false
+		}
+		return selector.Matches(labels.Set(namespace.Labels))
+	})
+}
+
+// enqueueMatchingNamespace enqueues the objects of the list type found in a namespace
+// whose labels match the selector, so the CRs of a namespace labelled after they were
+// created are reconciled without waiting for them to change
+func enqueueMatchingNamespace(reader client.Reader, selector labels.Selector,
+	list client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if !selector.Matches(labels.Set(obj.GetLabels())) {
+			return nil
+		}
+
+		objects := list.DeepCopyObject().(client.ObjectList)
+		if err := reader.List(ctx, objects, client.InNamespace(obj.GetName())); err != nil {
+			logger.Logger(ctx).WithError(err).WithField("namespace", obj.GetName()).
+				Error("error listing the objects of the namespace")
+			return nil
+		}
+		items, err := meta.ExtractList(objects)
+		if err != nil {
+			logger.Logger(ctx).WithError(err).Error("error extracting the objects of the namespace")
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			if object, ok := item.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)})
+			}
+		}
+		return requests
+	})
+}
+
+// groupRefKey returns the Group referenced from Members.Groups, either as "name"
+// for a Group in the given namespace or as "namespace/name"
+func groupRefKey(ref, namespace string) types.NamespacedName {
+	if refNamespace, name, found := strings.Cut(ref, "/"); found {
+		return types.NamespacedName{Namespace: refNamespace, Name: name}
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+)
+
+func TestWatchNamespacesFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		namespaces  []string
+		clusterWide bool
+		selector    string
+	}{
+		{name: "default namespace", namespaces: []string{DefaultWatchedNamespace}},
+		{
+			name:       "single namespace",
+			env:        map[string]string{WatchedNamespaceEnv: "team-a"},
+			namespaces: []string{"team-a"},
+		},
+		{
+			name: "list of namespaces takes precedence",
+			env: map[string]string{
+				WatchedNamespaceEnv:  "usernaut",
+				WatchedNamespacesEnv: "team-a, team-b,,",
+			},
+			namespaces: []string{"team-a", "team-b"},
+		},
+		{name: "cluster wide", env: map[string]string{WatchedNamespacesEnv: "*"}, clusterWide: true},
+		{
+			name: "cluster wide with selector",
+			env: map[string]string{
+				WatchedNamespacesEnv:        "*",
+				WatchedNamespaceSelectorEnv: "usernaut.dataverse.redhat.com/enabled=true",
+			},
+			clusterWide: true,
+			selector:    "usernaut.dataverse.redhat.com/enabled=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{WatchedNamespaceEnv, WatchedNamespacesEnv, WatchedNamespaceSelectorEnv} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			watch, err := WatchNamespacesFromEnv()
+			require.NoError(t, err)
+			assert.Equal(t, tt.namespaces, watch.Namespaces)
+			assert.Equal(t, tt.clusterWide, watch.ClusterWide())
+			assert.Len(t, watch.CacheOptions().DefaultNamespaces, len(tt.namespaces))
+			if tt.clusterWide {
+				byObject := watch.CacheOptions().ByObject
+				require.Len(t, byObject, 1)
+				for object, secretCache := range byObject {
+					assert.IsType(t, &corev1.Secret{}, object)
+					assert.Equal(t, SecretWatchLabel+"=true", secretCache.Label.String())
+				}
+			}
+			if tt.selector == "" {
+				assert.Nil(t, watch.Selector)
+			} else {
+				assert.Equal(t, tt.selector, watch.Selector.String())
+			}
+		})
+	}
+
+	t.Run("invalid selector", func(t *testing.T) {
+		t.Setenv(WatchedNamespaceSelectorEnv, "a in (")
+		_, err := WatchNamespacesFromEnv()
+		assert.Error(t, err)
+	})
+}
+
+func TestEnqueueMatchingNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, usernautdevv1alpha1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&usernautdevv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "admins", Namespace: "team-a"}},
+		&usernautdevv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "readers", Namespace: "team-a"}},
+		&usernautdevv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "admins", Namespace: "team-b"}},
+	).Build()
+	selector, err := labels.Parse("usernaut.dataverse.redhat.com/enabled=true")
+	require.NoError(t, err)
+
+	eventHandler := enqueueMatchingNamespace(reader, selector, &usernautdevv1alpha1.GroupList{})
+	enqueued := func(namespace *corev1.Namespace) []reconcile.Request {
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer queue.ShutDown()
+		eventHandler.Create(context.Background(), event.CreateEvent{Object: namespace}, queue)
+
+		var requests []reconcile.Request
+		for queue.Len() > 0 {
+			request, _ := queue.Get()
+			requests = append(requests, request)
+			queue.Done(request)
+		}
+		return requests
+	}
+
+	labelled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "team-a",
+		Labels: map[string]string{"usernaut.dataverse.redhat.com/enabled": "true"},
+	}}
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "admins"}},
+		{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "readers"}},
+	}, enqueued(labelled))
+
+	assert.Empty(t, enqueued(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}}))
+}
+
+func TestGroupRefKey(t *testing.T) {
+	assert.Equal(t, types.NamespacedName{Namespace: "team-a", Name: "admins"}, groupRefKey("admins", "team-a"))
+	assert.Equal(t, types.NamespacedName{Namespace: "team-b", Name: "admins"}, groupRefKey("team-b/admins", "team-a"))
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
+)
+
+// errNestingCycle is returned when the team of a Group would be nested into itself, which the
+// backends reject. It is fixed by changing the Groups, which reconciles the parent ones again.
+var errNestingCycle = errors.New("cyclic group nesting")
+
+// nestedMembers returns the members of the Group for a backend nesting its teams: the nested
+// Groups synced to the same backend are returned by group name, their users being members
+// through their team, the users of the other nested Groups are flattened with the direct ones.
+// Nested Groups whose teams nest the team of the Group back fail with errNestingCycle.
+func (r *GroupReconciler) nestedMembers(ctx context.Context, groupCR *usernautdevv1alpha1.Group,
+	backend usernautdevv1alpha1.GroupBackend) ([]string, []string, error) {
+	groupKey := types.NamespacedName{Namespace: groupCR.Namespace, Name: groupCR.Name}
+
+	users := make([]string, 0, len(groupCR.Spec.Members.Users))
+	users = append(users, groupCR.Spec.Members.Users...)
+	memberGroups := make([]string, 0)
+
+	for _, ref := range groupCR.Spec.Members.Groups {
+		memberKey := groupRefKey(ref, groupCR.Namespace)
+		memberCR := &usernautdevv1alpha1.Group{}
+		if err := r.Client.Get(ctx, memberKey, memberCR); err != nil {
+			return nil, nil, err
+		}
+
+		visitedOnPath := map[string]struct{}{groupKey.String(): {}}
+		if hasBackend(memberCR, backend) {
+			cycle, err := r.nestingCycle(ctx, memberCR, groupKey, backend, visitedOnPath)
+			if err != nil {
+				return nil, nil, err
+			}
+			if cycle != nil {
+				path := strings.Join(append([]string{groupKey.String()}, cycle...), " -> ")
+				return nil, nil, fmt.Errorf("%w: %s", errNestingCycle, path)
+			}
+			memberGroups = append(memberGroups, memberCR.Spec.GroupName)
+			continue
+		}
+
+		memberUsers, err := r.fetchUniqueGroupMembers(ctx, memberKey.Name, memberKey.Namespace, visitedOnPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		users = append(users, memberUsers...)
+	}
+
+	return r.deduplicateMembers(users), memberGroups, nil
+}
+
+// nestingCycle returns the path through which the team of the member Group nests the team of
+// the Group back, following the nested Groups synced to the backend, nil when it doesn't. The
+// cycles not going through the Group are left to the reconciliation of their own Groups.
+func (r *GroupReconciler) nestingCycle(ctx context.Context, memberCR *usernautdevv1alpha1.Group,
+	groupKey types.NamespacedName, backend usernautdevv1alpha1.GroupBackend,
+	visitedOnPath map[string]struct{}) ([]string, error) {
+	memberKey := types.NamespacedName{Namespace: memberCR.Namespace, Name: memberCR.Name}
+	if memberKey == groupKey {
+		return []string{memberKey.String()}, nil
+	}
+	if _, ok := visitedOnPath[memberKey.String()]; ok {
+		return nil, nil
+	}
+	visitedOnPath[memberKey.String()] = struct{}{}
+	defer delete(visitedOnPath, memberKey.String())
+
+	for _, ref := range memberCR.Spec.Members.Groups {
+		subGroupKey := groupRefKey(ref, memberCR.Namespace)
+		subGroupCR := &usernautdevv1alpha1.Group{}
+		if err := r.Client.Get(ctx, subGroupKey, subGroupCR); err != nil {
+			return nil, err
+		}
+		if !hasBackend(subGroupCR, backend) {
+			continue
+		}
+		cycle, err := r.nestingCycle(ctx, subGroupCR, groupKey, backend, visitedOnPath)
+		if err != nil {
+			return nil, err
+		}
+		if cycle != nil {
+			return append([]string{memberKey.String()}, cycle...), nil
+		}
+	}
+	return nil, nil
+}
+
+// syncMemberTeams makes the teams of the member Groups the member teams of the team. The member
+// teams of the other Groups synced to the backend are removed, the teams not managed by a Group,
+// e.g. the system roles of Snowflake, are left untouched.
+func (r *GroupReconciler) syncMemberTeams(ctx context.Context, teamID string, memberGroups []string,
+	backend usernautdevv1alpha1.GroupBackend, backendClient clients.Client, nester clients.TeamNester) error {
+	desired := make(map[string]struct{}, len(memberGroups))
+	for _, memberGroup := range memberGroups {
+		memberTeamID, err := r.fetchOrCreateTeam(ctx, memberGroup, backend.Name, backend.Type, backendClient)
+		if err != nil {
+			return err
+		}
+		desired[memberTeamID] = struct{}{}
+	}
+
+	existing, err := nester.FetchMemberTeamsByTeamID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	teamsToAdd := make([]string, 0)
+	for memberTeamID := range desired {
+		if _, found := existing[memberTeamID]; !found {
+			teamsToAdd = append(teamsToAdd, memberTeamID)
+		}
+	}
+
+	teamsToRemove := make([]string, 0)
+	for memberTeamID := range existing {
+		if _, found := desired[memberTeamID]; !found {
+			teamsToRemove = append(teamsToRemove, memberTeamID)
+		}
+	}
+	if len(teamsToRemove) > 0 {
+		managed, err := r.managedTeamIDs(ctx, backend)
+		if err != nil {
+			return err
+		}
+		teamsToRemove = slices.DeleteFunc(teamsToRemove, func(memberTeamID string) bool {
+			_, isManaged := managed[memberTeamID]
+			return !isManaged
+		})
+	}
+
+	if len(teamsToAdd) > 0 {
+		r.backendLogger.WithField("team_count", len(teamsToAdd)).Info("adding member teams to the team")
+		if err := nester.AddTeamsToTeam(ctx, teamID, teamsToAdd); err != nil {
+			return err
+		}
+	}
+
+	if len(teamsToRemove) > 0 {
+		r.backendLogger.WithField("team_count", len(teamsToRemove)).Info("removing member teams from the team")
+		if err := nester.RemoveTeamsFromTeam(ctx, teamID, teamsToRemove); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// managedTeamIDs returns the IDs of the teams of the Groups synced to the backend, as found in the cache
+func (r *GroupReconciler) managedTeamIDs(ctx context.Context,
+	backend usernautdevv1alpha1.GroupBackend) (map[string]struct{}, error) {
+	groups := &usernautdevv1alpha1.GroupList{}
+	if err := r.List(ctx, groups); err != nil {
+		return nil, err
+	}
+
+	teamIDs := make(map[string]struct{})
+	for _, group := range groups.Items {
+		if !hasBackend(&group, backend) {
+			continue
+		}
+
+		transformedGroupName, err := utils.GetTransformedGroupName(r.AppConfig, backend.Type, group.Spec.GroupName)
+		if err != nil {
+			return nil, err
+		}
+		teamDetailsInCache, err := r.Cache.Get(ctx, transformedGroupName)
+		if err != nil || teamDetailsInCache == "" {
+			continue
+		}
+		teamDetailsMap := make(map[string]string)
+		if err := json.Unmarshal([]byte(teamDetailsInCache.(string)), &teamDetailsMap); err != nil {
+			return nil, err
+		}
+		if teamID := teamDetailsMap[backend.Name+"_"+backend.Type]; teamID != "" {
+			teamIDs[teamID] = struct{}{}
+		}
+	}
+
+	return teamIDs, nil
+}
+
+// hasBackend returns whether the Group is synced to the backend
+func hasBackend(groupCR *usernautdevv1alpha1.Group, backend usernautdevv1alpha1.GroupBackend) bool {
+	return slices.ContainsFunc(groupCR.Spec.Backends, func(b usernautdevv1alpha1.GroupBackend) bool {
+		return b.Name == backend.Name && b.Type == backend.Type
+	})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/inmemory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+// fakeNester records the member teams added to and removed from the team
+type fakeNester struct {
+	clients.Client
+	memberTeams map[string]structs.Team
+	added       []string
+	removed     []string
+}
+
+func (f *fakeNester) FetchMemberTeamsByTeamID(ctx context.Context, teamID string) (map[string]structs.Team, error) {
+	return f.memberTeams, nil
+}
+
+func (f *fakeNester) AddTeamsToTeam(ctx context.Context, teamID string, memberTeamIDs []string) error {
+	f.added = append(f.added, memberTeamIDs...)
+	return nil
+}
+
+func (f *fakeNester) RemoveTeamsFromTeam(ctx context.Context, teamID string, memberTeamIDs []string) error {
+	f.removed = append(f.removed, memberTeamIDs...)
+	return nil
+}
+
+func newNestingReconciler(t *testing.T, groups ...*usernautdevv1alpha1.Group) *GroupReconciler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, usernautdevv1alpha1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, group := range groups {
+		builder = builder.WithObjects(group)
+	}
+
+	store, err := cache.New(&cache.Config{
+		Driver:   "memory",
+		InMemory: &inmemory.Config{DefaultExpiration: -1, CleanupInterval: -1},
+	})
+	require.NoError(t, err)
+
+	log := logrus.NewEntry(logrus.New())
+	return &GroupReconciler{
+		Client: builder.Build(),
+		AppConfig: &config.AppConfig{
+			Pattern: map[string][]config.PatternEntry{
+				"default": {{Input: "^(.*)$", Output: "$1"}},
+			},
+		},
+		Cache:         store,
+		log:           log,
+		backendLogger: log,
+	}
+}
+
+func newGroup(name string, users, groups []string,
+	backends ...usernautdevv1alpha1.GroupBackend) *usernautdevv1alpha1.Group {
+	return &usernautdevv1alpha1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: usernautdevv1alpha1.GroupSpec{
+			GroupName: name,
+			Members:   usernautdevv1alpha1.Members{Users: users, Groups: groups},
+			Backends:  backends,
+		},
+	}
+}
+
+func TestNestedMembers(t *testing.T) {
+	snowflake := usernautdevv1alpha1.GroupBackend{Name: "snowflake", Type: "snowflake"}
+	other := usernautdevv1alpha1.GroupBackend{Name: "fivetran", Type: "fivetran"}
+
+	parent := newGroup("data-eng", []string{"alice"}, []string{"analysts", "interns"}, snowflake)
+	r := newNestingReconciler(t,
+		parent,
+		newGroup("analysts", []string{"carol"}, nil, snowflake),
+		newGroup("interns", []string{"bob", "alice"}, nil, other),
+	)
+
+	// the Groups synced to the backend are member teams, the others are flattened
+	users, memberGroups, err := r.nestedMembers(context.Background(), parent, snowflake)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, users)
+	assert.Equal(t, []string{"analysts"}, memberGroups)
+}
+
+func TestNestedMembersCycle(t *testing.T) {
+	ctx := context.Background()
+	snowflake := usernautdevv1alpha1.GroupBackend{Name: "snowflake", Type: "snowflake"}
+	other := usernautdevv1alpha1.GroupBackend{Name: "fivetran", Type: "fivetran"}
+
+	parent := newGroup("data-eng", []string{"alice"}, []string{"analysts"}, snowflake)
+	r := newNestingReconciler(t,
+		parent,
+		newGroup("analysts", []string{"carol"}, []string{"data-eng", "interns"}, snowflake),
+		newGroup("interns", []string{"bob"}, []string{"analysts"}, snowflake),
+	)
+
+	// the team nested back into the Group is reported, rather than granted to the backend
+	_, _, err := r.nestedMembers(ctx, parent, snowflake)
+	assert.ErrorIs(t, err, errNestingCycle)
+	assert.ErrorContains(t, err, "default/data-eng -> default/analysts -> default/data-eng")
+
+	// a cycle through a Group flattened on the backend doesn't nest the team back
+	parent = newGroup("data-eng", []string{"alice"}, []string{"analysts"}, snowflake)
+	r = newNestingReconciler(t,
+		parent,
+		newGroup("analysts", []string{"carol"}, []string{"interns"}, snowflake),
+		newGroup("interns", []string{"bob"}, []string{"data-eng", "analysts"}, other),
+	)
+	users, memberGroups, err := r.nestedMembers(ctx, parent, snowflake)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, users)
+	assert.Equal(t, []string{"analysts"}, memberGroups)
+}
+
+func TestSyncMemberTeams(t *testing.T) {
+	ctx := context.Background()
+	snowflake := usernautdevv1alpha1.GroupBackend{Name: "snowflake", Type: "snowflake"}
+
+	r := newNestingReconciler(t,
+		newGroup("analysts", nil, nil, snowflake),
+		newGroup("former", nil, nil, snowflake),
+	)
+	require.NoError(t, r.Cache.Set(ctx, "analysts", `{"snowflake_snowflake":"analysts"}`, cache.NoExpiration))
+	require.NoError(t, r.Cache.Set(ctx, "former", `{"snowflake_snowflake":"former"}`, cache.NoExpiration))
+
+	nester := &fakeNester{memberTeams: map[string]structs.Team{
+		"former":   {ID: "former", Name: "former"},
+		"sysadmin": {ID: "sysadmin", Name: "sysadmin"},
+	}}
+
+	// the member teams of former Groups are removed, the unmanaged ones are kept
+	err := r.syncMemberTeams(ctx, "data-eng", []string{"analysts"}, snowflake, nester, nester)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"analysts"}, nester.added)
+	assert.Equal(t, []string{"former"}, nester.removed)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// PreloadBackendCache loads all the users and teams of a backend into the cache,
+// so that the reconciler doesn't have to hit the backend to resolve their IDs.
+// Entries are merged with the IDs already cached for other backends.
+func PreloadBackendCache(ctx context.Context, backend config.Backend,
+	backendClient clients.Client, store cache.Cache) error {
+	log := logger.Logger(ctx).
+		WithFields(logrus.Fields{
+			"backend":   backend.Name,
+			"type":      backend.Type,
+			"component": "preloadCache",
+		})
+
+	// backends not listing their users or teams resolve them on reconciliation instead
+	capabilities := clients.GetCapabilities(backendClient)
+
+	// Fetch all the users and store them in the cache
+	users := map[string]*structs.User{}
+	if capabilities.UserListing {
+		var err error
+		users, _, err = backendClient.FetchAllUsers(ctx)
+		if err != nil {
+			log.WithError(err).Error("failed to fetch users from backend")
+			return err
+		}
+	} else {
+		log.Debug("backend doesn't list its users, skipping them")
+	}
+	for _, user := range users {
+		// users are cached by email, backends not exposing it are resolved on creation instead
+		if user.GetEmail() == "" {
+			continue
+		}
+		userMap := make(map[string]string)
+		userInCache, err := store.Get(ctx, user.GetEmail())
+		// if user is already in the cache, we will update the user details
+		// with the new user ID from the backend
+		if err == nil {
+			// process user details
+			err = json.Unmarshal([]byte(userInCache.(string)), &userMap)
+			if err != nil {
+				log.WithError(err).Error("failed to unmarshal user details from cache")
+				return err
+			}
+		}
+		userMap[backend.Name+"_"+backend.Type] = user.ID
+		newUserValue, err := json.Marshal(userMap)
+		if err != nil {
+			log.WithError(err).Error("failed to marshal user details to JSON")
+			return err
+		}
+		// Store the user in the cache with email as key
+		// and user ID as value
+		// This is done to avoid hitting the backend for every request
+		// and to improve the performance of the application
+		// The user ID is stored in the cache as a JSON string
+		// so that it can be easily retrieved later
+		// The user ID is stored in the cache with the backend name and type as key
+		err = store.Set(ctx, user.Email, string(newUserValue), cache.NoExpiration)
+		if err != nil {
+			log.WithError(err).Error("failed to store user in cache")
+			return err
+		}
+	}
+
+	// Fetch all the teams and store them in the cache
+	teams := map[string]structs.Team{}
+	if capabilities.TeamListing {
+		var err error
+		teams, err = backendClient.FetchAllTeams(ctx)
+		if err != nil {
+			log.WithError(err).Error("failed to fetch teams from backend")
+			return err
+		}
+	} else {
+		log.Debug("backend doesn't list its teams, skipping them")
+	}
+	for _, team := range teams {
+		teamMap := make(map[string]string)
+		teamInCache, err := store.Get(ctx, team.GetName())
+		// if team is already in the cache, we will update the team details
+		// with the new team ID from the backend
+		if err == nil {
+			// process user details
+			err = json.Unmarshal([]byte(teamInCache.(string)), &teamMap)
+			if err != nil {
+				log.WithError(err).Error("failed to unmarshal team details from cache")
+				return err
+			}
+		}
+		teamMap[backend.Name+"_"+backend.Type] = team.ID
+		newTeamValue, err := json.Marshal(teamMap)
+		if err != nil {
+			log.WithError(err).Error("failed to marshal team details to JSON")
+			return err
+		}
+
+		// Store the team in the cache with name as key
+		// and team ID as value
+		// This is done to avoid hitting the backend for every request
+		// and to improve the performance of the application
+		// The team ID is stored in the cache as a JSON string
+		// so that it can be easily retrieved later
+		// The team ID is stored in the cache with the backend name and type as key
+		err = store.Set(ctx, team.Name, string(newTeamValue), cache.NoExpiration)
+		if err != nil {
+			log.WithError(err).Error("failed to store team in cache")
+			return err
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"users": len(users),
+		"teams": len(teams),
+	}).Debug("fetched users and teams from backend")
+	return nil
+}
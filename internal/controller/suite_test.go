@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	usernautdevv1alpha1 "github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/builtin"
 	// +kubebuilder:scaffold:imports
 )
 
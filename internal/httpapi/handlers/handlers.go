@@ -22,29 +22,47 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/redhat-data-and-ai/usernaut/api/v1alpha1"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
 )
 
 type Handlers struct {
-	config *config.AppConfig
+	getConfig func() *config.AppConfig
 }
 
-func NewHandlers(cfg *config.AppConfig) *Handlers {
+func NewHandlers(getConfig func() *config.AppConfig) *Handlers {
 	return &Handlers{
-		config: cfg,
+		getConfig: getConfig,
 	}
 }
 
+// Backend is an enabled backend with the operations it supports, the capabilities
+// are left out until a reconciliation created the client of the backend
+type Backend struct {
+	v1alpha1.GroupBackend `json:",inline"`
+	Capabilities          *structs.Capabilities `json:"capabilities,omitempty"`
+}
+
 func (h *Handlers) GetBackends(c *gin.Context) {
-	response := make([]v1alpha1.Backend, 0, len(h.config.Backends))
-
-	for _, backend := range h.config.Backends {
-		if backend.Enabled {
-			response = append(response, v1alpha1.Backend{
-				Name: backend.Name,
-				Type: backend.Type,
-			})
+	appConfig := h.getConfig()
+	backends := appConfig.GetBackendList()
+	response := make([]Backend, 0, len(backends))
+
+	for _, backend := range backends {
+		if !backend.Enabled {
+			continue
+		}
+		item := Backend{GroupBackend: v1alpha1.GroupBackend{
+			Name: backend.Name,
+			Type: backend.Type,
+		}}
+		// the clients aren't created here, they may launch plugins or connect to the backend
+		if backendClient, ok := clients.Pooled(backend.Name, backend.Type); ok {
+			capabilities := clients.GetCapabilities(backendClient)
+			item.Capabilities = &capabilities
 		}
+		response = append(response, item)
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -20,8 +20,11 @@ import (
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
 )
 
-func BasicAuth(cfg *config.AppConfig) gin.HandlerFunc {
+// BasicAuth authenticates requests against the configured API users.
+// getConfig is evaluated on every request so that configuration reloads are honoured.
+func BasicAuth(getConfig func() *config.AppConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		cfg := getConfig()
 		if !cfg.APIServer.Auth.Enabled {
 			c.Next()
 			return
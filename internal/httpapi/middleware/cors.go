@@ -20,11 +20,13 @@ import (
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
 )
 
-func CORS(cfg *config.APIServerConfig) gin.HandlerFunc {
+// CORS allows cross origin requests from the configured origins.
+// getConfig is evaluated on every request so that configuration reloads are honoured.
+func CORS(getConfig func() *config.AppConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
 
-		if slices.Contains(cfg.CORS.AllowedOrigins, origin) {
+		if slices.Contains(getConfig().APIServer.CORS.AllowedOrigins, origin) {
 			c.Header("Access-Control-Allow-Origin", origin)
 		}
 		if c.Request.Method == "OPTIONS" {
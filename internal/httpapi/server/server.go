@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -18,7 +19,8 @@ import (
 )
 
 type APIServer struct {
-	config   *config.AppConfig
+	// config is swapped on configuration reload, see UpdateConfig
+	config   atomic.Pointer[config.AppConfig]
 	router   *gin.Engine
 	server   *http.Server
 	handlers *handlers.Handlers
@@ -44,13 +46,13 @@ func NewAPIServer(cfg *config.AppConfig) *APIServer {
 		return ""
 	}))
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORS(&cfg.APIServer))
 
 	s := &APIServer{
-		config:   cfg,
-		router:   router,
-		handlers: handlers.NewHandlers(cfg),
+		router: router,
 	}
+	s.config.Store(cfg)
+	s.handlers = handlers.NewHandlers(s.getConfig)
+	router.Use(middleware.CORS(s.getConfig))
 
 	s.setupRoutes()
 	return s
@@ -66,7 +68,7 @@ func (s *APIServer) setupRoutes() {
 	})
 
 	v1 := s.router.Group("/api/v1")
-	v1.Use(middleware.BasicAuth(s.config))
+	v1.Use(middleware.BasicAuth(s.getConfig))
 
 	// add authenticated endpoints accordingly
 
@@ -74,9 +76,23 @@ func (s *APIServer) setupRoutes() {
 
 }
 
+func (s *APIServer) getConfig() *config.AppConfig {
+	return s.config.Load()
+}
+
+// UpdateConfig makes the API server use a reloaded configuration.
+// Changes to the listen address only take effect after a restart.
+func (s *APIServer) UpdateConfig(cfg *config.AppConfig) {
+	old := s.config.Swap(cfg)
+	if old.APIServer.Address != cfg.APIServer.Address {
+		logrus.WithField("address", cfg.APIServer.Address).
+			Warn("http API server address changed, restart required to take effect")
+	}
+}
+
 func (s *APIServer) Start() error {
 	s.server = &http.Server{
-		Addr:    s.config.APIServer.Address,
+		Addr:    s.getConfig().APIServer.Address,
 		Handler: s.router,
 	}
 
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package builtin registers the backends built into the operator, it is imported for its side effects
+package builtin
+
+import (
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/entra"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/fivetran"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/github"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/gitlab"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/google"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/grafana"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/keycloak"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/kubernetes"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/postgres"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/redhat_rover"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/scim"
+	_ "github.com/redhat-data-and-ai/usernaut/pkg/clients/snowflake"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+func init() {
+	// the ldap package can't register itself, the config package it would import imports it
+	clients.Register("ldap", func(backend config.Backend, _ config.HttpClientConfig) (clients.Client, error) {
+		return ldap.NewGroupsClient(backend.Connection)
+	}, "server", "bind_dn", "bind_password", "base_dn", "user_dn")
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builtin
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinBackendTypes(t *testing.T) {
+	for _, backendType := range []string{
+		"entra", "fivetran", "github", "gitlab", "google", "grafana", "keycloak", "kubernetes",
+		"ldap", "plugin", "postgres", "rover", "scim", "snowflake",
+	} {
+		assert.True(t, config.IsSupportedBackendType(backendType), backendType)
+	}
+
+	errs := config.ValidateBackend(config.Backend{Name: "github", Type: "github", Enabled: true})
+	assert.Len(t, errs, 2)
+}
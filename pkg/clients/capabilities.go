@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import "github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+
+// CapabilityProvider is implemented by the clients declaring the operations their backend supports
+type CapabilityProvider interface {
+	Capabilities() structs.Capabilities
+}
+
+// DefaultCapabilities returns the capabilities of the clients not declaring any:
+// users are provisioned and both users and teams are listed
+func DefaultCapabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserProvisioning: true,
+		UserListing:      true,
+		TeamListing:      true,
+	}
+}
+
+// GetCapabilities returns the capabilities declared by the client, the default ones when it declares none
+func GetCapabilities(c Client) structs.Capabilities {
+	if provider, ok := c.(CapabilityProvider); ok {
+		return provider.Capabilities()
+	}
+	return DefaultCapabilities()
+}
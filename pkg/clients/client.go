@@ -18,21 +18,48 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/redhat-data-and-ai/usernaut/pkg/clients/fivetran"
-	redhatrover "github.com/redhat-data-and-ai/usernaut/pkg/clients/redhat_rover"
-	"github.com/redhat-data-and-ai/usernaut/pkg/clients/snowflake"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
 	// ErrInvalidBackend is returned when an invalid backend type is provided
 	ErrInvalidBackend = errors.New("invalid backend")
+
+	// kubeClient is the client of the manager, the kubernetes backend manages the cluster objects with it
+	kubeClient client.Client
+
+	// pool holds the clients by backend type and name, they are reused across reconciles
+	// until the configuration of their backend changes
+	pool   = map[string]*pooledClient{}
+	poolMu sync.Mutex
+
+	// retired holds the clients evicted from the pool until closeGracePeriod elapsed,
+	// leaving the reconciles still using them the time to finish, it is guarded by poolMu
+	retired          []retiredClient
+	closeGracePeriod = 10 * time.Minute
 )
 
+// SetKubernetesClient sets the client the kubernetes backend manages the cluster objects with
+func SetKubernetesClient(c client.Client) {
+	kubeClient = c
+}
+
+// KubernetesClient returns the client set with SetKubernetesClient
+func KubernetesClient() client.Client {
+	return kubeClient
+}
+
 type Client interface {
 	// Fetches all the users onboarded over the platform
 	// returns 2 maps where:
@@ -63,7 +90,26 @@ type Client interface {
 	RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error
 }
 
-func New(backendName, backendType string, backends map[string]map[string]config.Backend) (Client, error) {
+// pooledClient is a client of the pool with the settings it was built with,
+// mu serializes the builds so that concurrent reconciles share the client
+type pooledClient struct {
+	mu       sync.Mutex
+	client   Client
+	settings string
+}
+
+// retiredClient is a client evicted from the pool, closed on the first call to New once its grace period elapsed
+type retiredClient struct {
+	client    Client
+	retiredAt time.Time
+}
+
+// New returns the client of the backend, built by the factory registered for its type.
+// The client is reused across calls until the settings of the backend change, the replaced
+// clients and the ones of the removed backends are closed when they implement io.Closer,
+// after a grace period as the reconciles which got them before may still be using them.
+func New(backendName, backendType string, appConfig *config.AppConfig) (Client, error) {
+	backends := appConfig.GetBackendMap()
 	backend, ok := backends[backendType][backendName]
 	if !ok {
 		return nil, ErrInvalidBackend
@@ -71,34 +117,122 @@ func New(backendName, backendType string, backends map[string]map[string]config.
 	if !backend.Enabled {
 		return nil, errors.New("backend is not enabled")
 	}
-	switch strings.ToLower(backendType) {
-	case "fivetran":
-		apiKey := backend.GetStringConnection("apikey", "")
-		apiSecret := backend.GetStringConnection("apisecret", "")
-		if apiKey == "" || apiSecret == "" {
-			return nil, errors.New("missing required connection parameters for fivetran backend")
-		}
-		// Create and return a new Fivetran client
-		// using the API key and secret from the backend configuration
-		return fivetran.NewClient(apiKey, apiSecret), nil
-	case "rover":
-		appConfig, err := config.GetConfig()
-		if err != nil {
-			return nil, err
+	factory, ok := getFactory(backendType)
+	if !ok {
+		return nil, ErrInvalidBackend
+	}
+
+	httpClient := appConfig.HttpClient
+	if backend.HttpClient != nil {
+		httpClient = *backend.HttpClient
+	}
+	settings, err := json.Marshal(struct {
+		Backend    config.Backend
+		HttpClient config.HttpClientConfig
+	}{backend, httpClient})
+	if err != nil {
+		return nil, fmt.Errorf("invalid settings of backend %s: %w", backendName, err)
+	}
+
+	key := backendType + "/" + backendName
+	poolMu.Lock()
+	removed := prune(backends)
+	expired := expire()
+	pooled, ok := pool[key]
+	if !ok {
+		pooled = &pooledClient{}
+		pool[key] = pooled
+	}
+	poolMu.Unlock()
+	for _, c := range expired {
+		closeClient(c)
+	}
+	for _, p := range removed {
+		p.mu.Lock()
+		retire(p.client)
+		p.client = nil
+		p.mu.Unlock()
+	}
+
+	// the client is built outside of the pool lock, factories may connect to the backend
+	pooled.mu.Lock()
+	defer pooled.mu.Unlock()
+	if pooled.client != nil && pooled.settings == string(settings) {
+		return pooled.client, nil
+	}
+	retire(pooled.client)
+	pooled.client = nil
+	backendClient, err := factory(backend, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	pooled.client, pooled.settings = backendClient, string(settings)
+
+	// the entry may have been pruned while building, it is put back to be closed on the next prune
+	poolMu.Lock()
+	pool[key] = pooled
+	poolMu.Unlock()
+	return backendClient, nil
+}
+
+// Pooled returns the client of the backend already built by New, without building it. No client
+// is returned while one is being built, or once the backend was removed from the configuration.
+func Pooled(backendName, backendType string) (Client, bool) {
+	poolMu.Lock()
+	pooled, ok := pool[backendType+"/"+backendName]
+	poolMu.Unlock()
+	if !ok || !pooled.mu.TryLock() {
+		return nil, false
+	}
+	defer pooled.mu.Unlock()
+	return pooled.client, pooled.client != nil
+}
+
+// prune drops the clients of the backends removed from the configuration and returns them, poolMu must be held
+func prune(backends map[string]map[string]config.Backend) []*pooledClient {
+	var removed []*pooledClient
+	for key, pooled := range pool {
+		backendType, backendName, _ := strings.Cut(key, "/")
+		if _, ok := backends[backendType][backendName]; !ok {
+			removed = append(removed, pooled)
+			delete(pool, key)
 		}
+	}
+	return removed
+}
+
+// retire schedules the closing of a client evicted from the pool
+func retire(c Client) {
+	if c == nil {
+		return
+	}
+	poolMu.Lock()
+	retired = append(retired, retiredClient{client: c, retiredAt: time.Now()})
+	poolMu.Unlock()
+}
 
-		return redhatrover.NewClient(backend.Connection,
-			appConfig.HttpClient.ConnectionPoolConfig, appConfig.HttpClient.HystrixResiliencyConfig)
-	case "snowflake":
-		appConfig, err := config.GetConfig()
-		if err != nil {
-			return nil, err
+// expire drops the retired clients whose grace period elapsed and returns them, poolMu must be held
+func expire() []Client {
+	var expired []Client
+	kept := retired[:0]
+	for _, r := range retired {
+		if time.Since(r.retiredAt) >= closeGracePeriod {
+			expired = append(expired, r.client)
+		} else {
+			kept = append(kept, r)
 		}
+	}
+	retired = kept
+	return expired
+}
 
-		return snowflake.NewClient(backend.Connection,
-			appConfig.HttpClient.ConnectionPoolConfig, appConfig.HttpClient.HystrixResiliencyConfig)
-	default:
-		// If no valid backend type is matched, return an error
-		return nil, ErrInvalidBackend
+// closeClient releases the connections or processes held by a client evicted from the pool
+func closeClient(c Client) {
+	closer, ok := c.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		logger.Logger(context.Background()).WithError(err).Warn("failed to close backend client")
 	}
 }
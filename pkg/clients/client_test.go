@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient is a Client recording the settings it was built with
+type fakeClient struct {
+	Client
+	backend    config.Backend
+	httpClient config.HttpClientConfig
+	closed     bool
+}
+
+func (c *fakeClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *fakeClient) FetchAllTeams(_ context.Context) (map[string]structs.Team, error) {
+	return map[string]structs.Team{}, nil
+}
+
+func TestNew(t *testing.T) {
+	built := 0
+	Register("Fake", func(backend config.Backend, httpClient config.HttpClientConfig) (Client, error) {
+		if backend.GetStringConnection("token", "") == "" {
+			return nil, errors.New("token is required")
+		}
+		built++
+		return &fakeClient{backend: backend, httpClient: httpClient}, nil
+	}, "token")
+	assert.True(t, config.IsSupportedBackendType("fake"))
+	assert.Panics(t, func() {
+		Register("fake", func(config.Backend, config.HttpClientConfig) (Client, error) { return nil, nil })
+	})
+
+	appConfig := &config.AppConfig{
+		HttpClient: config.HttpClientConfig{ConnectionPoolConfig: httpclient.ConnectionPoolConfig{Timeout: 1000}},
+		BackendMap: map[string]map[string]config.Backend{
+			"fake": {
+				"first":    {Name: "first", Type: "fake", Enabled: true, Connection: map[string]interface{}{"token": "a"}},
+				"second":   {Name: "second", Type: "fake", Enabled: true, Connection: map[string]interface{}{"token": "b"}},
+				"disabled": {Name: "disabled", Type: "fake", Connection: map[string]interface{}{"token": "c"}},
+				"invalid":  {Name: "invalid", Type: "fake", Enabled: true},
+			},
+			"unknown": {"unknown": {Name: "unknown", Type: "unknown", Enabled: true}},
+		},
+	}
+
+	_, ok := Pooled("first", "fake")
+	assert.False(t, ok)
+	first, err := New("first", "fake", appConfig)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, first.(*fakeClient).httpClient.ConnectionPoolConfig.Timeout)
+	pooled, ok := Pooled("first", "fake")
+	assert.True(t, ok)
+	assert.Same(t, first, pooled)
+
+	// the clients are reused until the settings of their backend change
+	again, err := New("first", "fake", appConfig)
+	require.NoError(t, err)
+	assert.Same(t, first, again)
+	second, err := New("second", "fake", appConfig)
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, built)
+
+	appConfig.BackendMap["fake"]["first"] = config.Backend{Name: "first", Type: "fake", Enabled: true,
+		Connection: map[string]interface{}{"token": "a"},
+		HttpClient: &config.HttpClientConfig{ConnectionPoolConfig: httpclient.ConnectionPoolConfig{Timeout: 2000}}}
+	rebuilt, err := New("first", "fake", appConfig)
+	require.NoError(t, err)
+	assert.NotSame(t, first, rebuilt)
+	assert.Equal(t, 2000, rebuilt.(*fakeClient).httpClient.ConnectionPoolConfig.Timeout)
+	// the replaced client is left open for the reconciles still using it
+	assert.False(t, first.(*fakeClient).closed)
+
+	// the clients of the removed backends are dropped
+	delete(appConfig.BackendMap["fake"], "second")
+	_, err = New("first", "fake", appConfig)
+	require.NoError(t, err)
+	assert.NotContains(t, pool, "fake/second")
+	_, ok = Pooled("second", "fake")
+	assert.False(t, ok)
+	assert.False(t, second.(*fakeClient).closed)
+
+	// the evicted clients are closed once their grace period elapsed
+	gracePeriod := closeGracePeriod
+	closeGracePeriod = 0
+	t.Cleanup(func() { closeGracePeriod = gracePeriod })
+	_, err = New("first", "fake", appConfig)
+	require.NoError(t, err)
+	assert.True(t, first.(*fakeClient).closed)
+	assert.True(t, second.(*fakeClient).closed)
+	assert.False(t, rebuilt.(*fakeClient).closed)
+
+	_, err = New("disabled", "fake", appConfig)
+	assert.EqualError(t, err, "backend is not enabled")
+	_, err = New("invalid", "fake", appConfig)
+	assert.EqualError(t, err, "token is required")
+	_, err = New("missing", "fake", appConfig)
+	assert.ErrorIs(t, err, ErrInvalidBackend)
+	_, err = New("unknown", "unknown", appConfig)
+	assert.ErrorIs(t, err, ErrInvalidBackend)
+}
+
+func TestNewConcurrent(t *testing.T) {
+	var built atomic.Int32
+	Register("concurrent", func(backend config.Backend, _ config.HttpClientConfig) (Client, error) {
+		built.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &fakeClient{backend: backend}, nil
+	})
+	appConfig := &config.AppConfig{BackendMap: map[string]map[string]config.Backend{
+		"concurrent": {"shared": {Name: "shared", Type: "concurrent", Enabled: true}},
+	}}
+
+	// the reconciles asking for the same backend at once share one client
+	clients := make([]Client, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clients[i], _ = New("shared", "concurrent", appConfig)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), built.Load())
+	for _, c := range clients {
+		assert.Same(t, clients[0], c)
+	}
+}
+
+// lookupClient is a Client declaring it only resolves its users
+type lookupClient struct {
+	Client
+}
+
+func (lookupClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{UserListing: true, TeamListing: true}
+}
+
+// renamingClient is a Client renaming its teams, unless it declares otherwise
+type renamingClient struct {
+	Client
+	disabled bool
+}
+
+func (c renamingClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{TeamListing: true, Rename: !c.disabled}
+}
+
+func (renamingClient) RenameTeam(_ context.Context, teamID, name string) (*structs.Team, error) {
+	return &structs.Team{ID: teamID, Name: name}, nil
+}
+
+func TestGetCapabilities(t *testing.T) {
+	assert.Equal(t, DefaultCapabilities(), GetCapabilities(&fakeClient{}))
+	assert.True(t, GetCapabilities(&fakeClient{}).UserProvisioning)
+
+	capabilities := GetCapabilities(lookupClient{})
+	assert.False(t, capabilities.UserProvisioning)
+	assert.True(t, capabilities.TeamListing)
+
+	// the optional operations are only used when declared
+	_, ok := GetTeamRenamer(renamingClient{})
+	assert.True(t, ok)
+	_, ok = GetTeamRenamer(renamingClient{disabled: true})
+	assert.False(t, ok)
+	_, ok = GetTeamRenamer(&fakeClient{})
+	assert.False(t, ok)
+}
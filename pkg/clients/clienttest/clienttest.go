@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clienttest provides the fake HTTP APIs the clients of the backends are tested against,
+// and checks the errors the clients return for the error responses of their API.
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// PoolConfig and HystrixConfig are the settings of the HTTP clients under test, the circuits
+	// aren't opened by the error responses of the tests
+	PoolConfig    = httpclient.ConnectionPoolConfig{Timeout: 5000}
+	HystrixConfig = httpclient.HystrixResiliencyConfig{
+		MaxConcurrentRequests:  10,
+		RequestVolumeThreshold: 1000,
+		CircuitBreakerTimeout:  5000,
+	}
+)
+
+// NewServer starts a fake API routing the requests with mux, closed at the end of the test. guard, when set,
+// is called first and answers the requests it doesn't let through, e.g. the ones lacking the credentials.
+func NewServer(t testing.TB, mux *http.ServeMux,
+	guard func(w http.ResponseWriter, r *http.Request) bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if guard != nil && !guard(w, r) {
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Reply answers with the status and body encoded as JSON, the content type set beforehand is kept
+func Reply(w http.ResponseWriter, status int, body interface{}) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+// StatusCase is an error response of an API and the kind of error the client is expected to return for it
+type StatusCase struct {
+	Name   string
+	Status int
+	Header map[string]string
+	// Body is the body of the response, StatusTest.ErrorBody gives the one of the cases without any
+	Body       interface{}
+	Kind       error
+	RetryAfter time.Duration
+}
+
+// StatusCases returns the error responses the clients map alike, see clients.WrapStatus
+func StatusCases() []StatusCase {
+	return []StatusCase{
+		{Name: "not found", Status: http.StatusNotFound, Kind: clients.ErrNotFound},
+		{Name: "bad credentials", Status: http.StatusUnauthorized, Kind: clients.ErrUnauthorized},
+		{Name: "missing permission", Status: http.StatusForbidden, Kind: clients.ErrUnauthorized},
+		{
+			Name:       "too many requests",
+			Status:     http.StatusTooManyRequests,
+			Header:     map[string]string{"Retry-After": "30"},
+			Kind:       clients.ErrRateLimited,
+			RetryAfter: 30 * time.Second,
+		},
+		{Name: "server error", Status: http.StatusInternalServerError, Kind: clients.ErrTransient},
+		{Name: "service unavailable", Status: http.StatusServiceUnavailable, Kind: clients.ErrTransient},
+	}
+}
+
+// StatusTest checks the errors a client returns for the StatusCases and the cases specific to its API
+type StatusTest struct {
+	// Route is the pattern of the route answering with the error responses, e.g. "GET /teams/{team}"
+	Route string
+	// Call calls the route with the client under test
+	Call func(ctx context.Context) error
+	// ErrorBody returns the body of the error responses in the format of the API, if any
+	ErrorBody func(status int) interface{}
+	// Cases are checked along with the StatusCases
+	Cases []StatusCase
+
+	// DeleteRoute answers with a 404 to Delete, the resources already gone count as deleted
+	DeleteRoute string
+	Delete      func(ctx context.Context) error
+}
+
+// Run registers the routes of the test with mux, the mux of the fake API of the client, and runs the cases
+func (s StatusTest) Run(t *testing.T, mux *http.ServeMux) {
+	var current StatusCase
+	mux.HandleFunc(s.Route, func(w http.ResponseWriter, r *http.Request) {
+		for key, value := range current.Header {
+			w.Header().Set(key, value)
+		}
+		Reply(w, current.Status, current.Body)
+	})
+
+	for _, tc := range append(StatusCases(), s.Cases...) {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.Body == nil && s.ErrorBody != nil {
+				tc.Body = s.ErrorBody(tc.Status)
+			}
+			current = tc
+
+			err := s.Call(context.Background())
+			assert.ErrorIs(t, err, tc.Kind)
+			assert.InDelta(t, tc.RetryAfter, clients.RetryAfter(err), float64(2*time.Second))
+		})
+	}
+
+	if s.Delete == nil {
+		return
+	}
+	mux.HandleFunc(s.DeleteRoute, func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		if s.ErrorBody != nil {
+			body = s.ErrorBody(http.StatusNotFound)
+		}
+		Reply(w, http.StatusNotFound, body)
+	})
+	t.Run("delete gone", func(t *testing.T) {
+		require.NoError(t, s.Delete(context.Background()))
+	})
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParseBoolConnection returns the boolean connection parameter of a backend, set either as a boolean
+// by the config files or as a string by the Backend CRs and the resolved values, false when it isn't
+// set or empty. Unlike config.Backend.GetBoolConnection, the invalid values are reported.
+func ParseBoolConnection(connection map[string]interface{}, name string) (bool, error) {
+	switch val := connection[name].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return val, nil
+	case string:
+		if val == "" {
+			return false, nil
+		}
+		parsed, err := strconv.ParseBool(val)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s %q: expected a boolean", name, val)
+		}
+		return parsed, nil
+	default:
+		return false, fmt.Errorf("invalid %s %v: expected a boolean", name, val)
+	}
+}
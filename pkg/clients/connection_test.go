@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBoolConnection(t *testing.T) {
+	connection := map[string]interface{}{
+		"yaml": true, "cr": "true", "cr_false": "False", "unresolved": "", "typo": "yes please", "number": 1,
+	}
+
+	for name, expected := range map[string]bool{
+		"yaml": true, "cr": true, "cr_false": false, "unresolved": false, "missing": false,
+	} {
+		value, err := ParseBoolConnection(connection, name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, expected, value, name)
+	}
+
+	_, err := ParseBoolConnection(connection, "typo")
+	assert.EqualError(t, err, `invalid typo "yes please": expected a boolean`)
+	_, err = ParseBoolConnection(connection, "number")
+	assert.EqualError(t, err, "invalid number 1: expected a boolean")
+}
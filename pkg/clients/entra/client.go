@@ -0,0 +1,233 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+const (
+	// DefaultBaseURL is the Microsoft Graph v1.0 endpoint
+	DefaultBaseURL = "https://graph.microsoft.com/v1.0"
+	// DefaultLoginURL is the Microsoft identity platform endpoint
+	DefaultLoginURL = "https://login.microsoftonline.com"
+	// graphScope requests the application permissions granted to the client
+	graphScope = "https://graph.microsoft.com/.default"
+	// tokenExpiryLeeway is how long before its expiry the access token is refreshed
+	tokenExpiryLeeway = 5 * time.Minute
+)
+
+// NewClient creates a new Entra client with the given configuration
+func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionPoolConfig,
+	hystrixCfg httpclient.HystrixResiliencyConfig) (*EntraClient, error) {
+
+	// Extract connection parameters
+	config := EntraConfig{
+		BaseURL:    DefaultBaseURL,
+		LoginURL:   DefaultLoginURL,
+		UserLookup: LookupByMail,
+	}
+	config.TenantID, _ = connection["tenant_id"].(string)
+	config.ClientID, _ = connection["client_id"].(string)
+	config.ClientSecret, _ = connection["client_secret"].(string)
+	if config.TenantID == "" || config.ClientID == "" || config.ClientSecret == "" {
+		return nil, errors.New("missing required connection parameters for entra backend: " +
+			"tenant_id, client_id and client_secret are required")
+	}
+
+	if baseURL, _ := connection["base_url"].(string); baseURL != "" {
+		config.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	if loginURL, _ := connection["login_url"].(string); loginURL != "" {
+		config.LoginURL = strings.TrimSuffix(loginURL, "/")
+	}
+	if lookup, _ := connection["user_lookup"].(string); lookup != "" {
+		lookup = strings.ToLower(lookup)
+		if lookup != LookupByMail && lookup != LookupByUPN {
+			return nil, fmt.Errorf("invalid user_lookup %q for entra backend: expected mail or upn", lookup)
+		}
+		config.UserLookup = lookup
+	}
+	config.UPNAttribute, _ = connection["upn_attribute"].(string)
+
+	client, err := httpclient.InitializeClient(
+		"entra",
+		poolCfg,
+		hystrixCfg,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &EntraClient{
+		config: &config,
+		client: client,
+	}, nil
+}
+
+// accessToken returns the access token of the application,
+// fetched with the client credentials grant when missing or about to expire
+func (c *EntraClient) accessToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.config.ClientID},
+		"client_secret": {c.config.ClientSecret},
+		"scope":         {graphScope},
+	}
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", c.config.LoginURL, url.PathEscape(c.config.TenantID))
+	req, err := request.NewRequest(ctx, http.MethodPost, tokenURL, []byte(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetHeaders(map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+	})
+
+	resp, headers, status, err := req.MakeRequestWithHeader(c.client, http.MethodPost, "entra")
+	if err != nil {
+		return "", clients.Transient(fmt.Errorf("failed to fetch access token: %w", err))
+	}
+	if status != http.StatusOK {
+		return "", clients.StatusError(status, headers, "failed to fetch access token, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var token TokenResponse
+	if err := json.Unmarshal(resp, &token); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("token response has no access token")
+	}
+
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryLeeway)
+	return c.token, nil
+}
+
+// makeRequest sends a request to Microsoft Graph. endpoint is either a path relative
+// to the base URL or an absolute URL, as returned in the @odata.nextLink of the pages.
+func (c *EntraClient) makeRequest(ctx context.Context, endpoint,
+	method string, body interface{}) ([]byte, http.Header, int, error) {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	graphURL := endpoint
+	if !strings.HasPrefix(endpoint, "https://") && !strings.HasPrefix(endpoint, "http://") {
+		graphURL = c.config.BaseURL + endpoint
+	}
+	req, err := request.NewRequest(ctx, method, graphURL, requestBody)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	req.SetHeaders(map[string]string{
+		"Authorization": "Bearer " + token,
+		"Content-Type":  "application/json",
+		// required by the $filter and $count queries on directory objects
+		"ConsistencyLevel": "eventual",
+	})
+
+	resp, headers, status, err := req.MakeRequestWithHeader(c.client, method, "entra")
+	if err != nil {
+		return nil, nil, status, clients.Transient(err)
+	}
+	return resp, headers, status, nil
+}
+
+// statusError returns the error of a response with an unexpected status. Microsoft Graph answers
+// a bad request to the objects already existing, e.g. a group of the same mail nickname.
+func statusError(status int, header http.Header, resp []byte, format string, args ...interface{}) error {
+	err := fmt.Errorf(format+", status: %s, body: %s", append(args, http.StatusText(status), string(resp))...)
+
+	var body GraphErrorBody
+	if status == http.StatusBadRequest && json.Unmarshal(resp, &body) == nil &&
+		strings.Contains(body.Error.Message, "already exist") {
+		return &clients.Error{Kind: clients.ErrAlreadyExists, Err: err}
+	}
+	// the throttled calls are answered a 429, telling how long to wait with a Retry-After header
+	return clients.WrapStatus(status, header, err)
+}
+
+// fetchAllWithPagination decodes every page of a collection, following the @odata.nextLink
+func fetchAllWithPagination[T any](ctx context.Context, c *EntraClient, endpoint string) ([]T, error) {
+	var items []T
+	for next := endpoint; next != ""; {
+		resp, headers, status, err := c.makeRequest(ctx, next, http.MethodGet, nil)
+		if err != nil {
+			return nil, err
+		}
+		if status != http.StatusOK {
+			return nil, statusError(status, headers, resp, "failed to fetch data from %s", endpoint)
+		}
+
+		var page ListResponse[T]
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse list response: %w", err)
+		}
+		items = append(items, page.Value...)
+		next = page.NextLink
+	}
+	return items, nil
+}
+
+// GetConfig returns the client configuration
+func (c *EntraClient) GetConfig() *EntraConfig {
+	return c.config
+}
+
+// Capabilities returns the operations supported by the backend, the Entra accounts are provisioned by the tenant
+func (c *EntraClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserListing: true,
+		TeamListing: true,
+		Rename:      true,
+	}
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/clienttest"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGraphClient returns a client of the token endpoint and of Microsoft Graph, the Graph calls
+// being routed with mux. It returns the number of tokens issued so far.
+func newGraphClient(t *testing.T, mux *http.ServeMux, connection map[string]interface{}) (*EntraClient, func() int) {
+	issued := 0
+	server := clienttest.NewServer(t, mux, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == "/login/tenant/oauth2/v2.0/token" {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, graphScope, r.PostForm.Get("scope"))
+			if r.PostForm.Get("client_secret") != "secret" {
+				clienttest.Reply(w, http.StatusUnauthorized, map[string]string{
+					"error":             "invalid_client",
+					"error_description": "AADSTS7000215: Invalid client secret provided.",
+				})
+				return false
+			}
+			issued++
+			clienttest.Reply(w, http.StatusOK, TokenResponse{AccessToken: "token", ExpiresIn: 3599})
+			return false
+		}
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "eventual", r.Header.Get("ConsistencyLevel"))
+		return true
+	})
+
+	connection["tenant_id"] = "tenant"
+	connection["client_id"] = "usernaut"
+	if _, ok := connection["client_secret"]; !ok {
+		connection["client_secret"] = "secret"
+	}
+	connection["login_url"] = server.URL + "/login"
+	connection["base_url"] = server.URL + "/v1.0/"
+	client, err := NewClient(connection, clienttest.PoolConfig, clienttest.HystrixConfig)
+	require.NoError(t, err)
+	return client, func() int { return issued }
+}
+
+func graphError(code, message string) GraphErrorBody {
+	var body GraphErrorBody
+	body.Error.Code, body.Error.Message = code, message
+	return body
+}
+
+func TestNewClient(t *testing.T) {
+	poolCfg, hystrixCfg := clienttest.PoolConfig, clienttest.HystrixConfig
+
+	_, err := NewClient(map[string]interface{}{"tenant_id": "tenant", "client_id": "usernaut"}, poolCfg, hystrixCfg)
+	assert.Error(t, err)
+
+	connection := map[string]interface{}{"tenant_id": "tenant", "client_id": "usernaut", "client_secret": "secret"}
+	client, err := NewClient(connection, poolCfg, hystrixCfg)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBaseURL, client.GetConfig().BaseURL)
+	assert.Equal(t, DefaultLoginURL, client.GetConfig().LoginURL)
+	assert.Equal(t, LookupByMail, client.GetConfig().UserLookup)
+
+	connection["user_lookup"] = "samaccountname"
+	_, err = NewClient(connection, poolCfg, hystrixCfg)
+	assert.Error(t, err)
+}
+
+func TestFetchAllUsersFollowsNextLink(t *testing.T) {
+	mux := http.NewServeMux()
+	var skipTokens []string
+	mux.HandleFunc("GET /v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		skipTokens = append(skipTokens, r.URL.Query().Get("$skiptoken"))
+		switch r.URL.Query().Get("$skiptoken") {
+		case "":
+			assert.Equal(t, "999", r.URL.Query().Get("$top"))
+			assert.Equal(t, userFields, r.URL.Query().Get("$select"))
+			// the next link is absolute and opaque, it carries the query of the first page
+			clienttest.Reply(w, http.StatusOK, ListResponse[GraphUser]{
+				Value: []GraphUser{
+					{ID: "u1", UserPrincipalName: "jdoe@acquired.example.com", Mail: "JDoe@example.com"},
+					{ID: "u2", UserPrincipalName: "room-1@acquired.example.com"},
+				},
+				NextLink: "http://" + r.Host + "/v1.0/users?$top=999&$skiptoken=RFNwdAIAAQAAAD8",
+			})
+		default:
+			clienttest.Reply(w, http.StatusOK, ListResponse[GraphUser]{Value: []GraphUser{
+				{ID: "u3", UserPrincipalName: "asmith@acquired.example.com", Mail: "asmith@example.com"},
+			}})
+		}
+	})
+	client, issued := newGraphClient(t, mux, map[string]interface{}{})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "RFNwdAIAAQAAAD8"}, skipTokens)
+	assert.Len(t, byID, 3)
+	assert.Len(t, byEmail, 2)
+	assert.Equal(t, "u1", byEmail["jdoe@example.com"].ID)
+	assert.Equal(t, "room-1@acquired.example.com", byID["u2"].UserName)
+
+	// the access token is reused until it expires
+	assert.Equal(t, 1, issued())
+}
+
+func TestInvalidClientSecret(t *testing.T) {
+	client, _ := newGraphClient(t, http.NewServeMux(), map[string]interface{}{"client_secret": "expired"})
+
+	_, err := client.FetchAllTeams(context.Background())
+	assert.ErrorIs(t, err, clients.ErrUnauthorized)
+	assert.ErrorContains(t, err, "AADSTS7000215")
+}
+
+func TestCreateUserByMail(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("$filter") {
+		case "mail eq 'o''neil@example.com'":
+			clienttest.Reply(w, http.StatusOK,
+				ListResponse[GraphUser]{Value: []GraphUser{{ID: "u1", Mail: "O'Neil@example.com"}}})
+		case "mail eq 'shared@example.com'":
+			// a shared mailbox set as the mail of several accounts
+			clienttest.Reply(w, http.StatusOK, ListResponse[GraphUser]{Value: []GraphUser{{ID: "u2"}, {ID: "u3"}}})
+		default:
+			clienttest.Reply(w, http.StatusOK, ListResponse[GraphUser]{})
+		}
+	})
+	client, _ := newGraphClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	user, err := client.CreateUser(ctx, &structs.User{Email: "o'neil@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.User{ID: "u1", Email: "o'neil@example.com"}, user)
+
+	for _, email := range []string{"shared@example.com", "bwayne@example.com", ""} {
+		_, err = client.CreateUser(ctx, &structs.User{Email: email})
+		assert.ErrorIs(t, err, ErrUserNotFound, email)
+		assert.ErrorIs(t, err, clients.ErrNotFound, email)
+	}
+	assert.ErrorIs(t, client.DeleteUser(ctx, "u1"), ErrUserDeletionNotSupported)
+}
+
+func TestCreateUserByUPN(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1.0/users/{upn}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("upn") != "asmith@acquired.example.com" {
+			clienttest.Reply(w, http.StatusNotFound, graphError("Request_ResourceNotFound", "Resource does not exist."))
+			return
+		}
+		clienttest.Reply(w, http.StatusOK, GraphUser{ID: "u2", UserPrincipalName: "asmith@acquired.example.com"})
+	})
+	client, _ := newGraphClient(t, mux, map[string]interface{}{"user_lookup": "UPN", "upn_attribute": "entraUPN"})
+	ctx := context.Background()
+
+	user, err := client.CreateUser(ctx, &structs.User{
+		Email:      "asmith@example.com",
+		Attributes: map[string]string{"entraUPN": "asmith@acquired.example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "u2", user.ID)
+
+	// the email isn't used when the UPN attribute is configured
+	_, err = client.CreateUser(ctx, &structs.User{Email: "asmith@acquired.example.com"})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	_, err = client.CreateUser(ctx, &structs.User{Attributes: map[string]string{"entraUPN": "bwayne@example.com"}})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestCreateTeam(t *testing.T) {
+	mux := http.NewServeMux()
+	var created []GraphGroup
+	mux.HandleFunc("POST /v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		var group GraphGroup
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&group))
+		if group.DisplayName == "legacy" {
+			clienttest.Reply(w, http.StatusBadRequest, graphError("Request_BadRequest",
+				"Another object with the same value for property mailNickname already exists."))
+			return
+		}
+		created = append(created, group)
+		group.ID = "g2"
+		clienttest.Reply(w, http.StatusCreated, group)
+	})
+	client, _ := newGraphClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	team, err := client.CreateTeam(ctx, &structs.Team{Name: "data analysts (EMEA)", Description: "Analysts"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "g2", Name: "data analysts (EMEA)", Description: "Analysts"}, team)
+	assert.Equal(t, []GraphGroup{{
+		DisplayName:     "data analysts (EMEA)",
+		Description:     "Analysts",
+		MailNickname:    "data-analysts--EMEA-",
+		SecurityEnabled: true,
+	}}, created)
+
+	_, err = client.CreateTeam(ctx, &structs.Team{Name: "legacy"})
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+}
+
+func TestRenameTeam(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /v1.0/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var group map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&group))
+		if group["displayName"] == "legacy" {
+			clienttest.Reply(w, http.StatusBadRequest, graphError("Request_BadRequest",
+				"Another object with the same value for property mailNickname already exists."))
+			return
+		}
+		// the security and mail settings of the group are left untouched
+		assert.Equal(t, map[string]interface{}{
+			"displayName": "data analysts (EMEA)", "mailNickname": "data-analysts--EMEA-",
+		}, group)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client, _ := newGraphClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	renamer, ok := clients.GetTeamRenamer(client)
+	require.True(t, ok)
+	team, err := renamer.RenameTeam(ctx, "g2", "data analysts (EMEA)")
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "g2", Name: "data analysts (EMEA)"}, team)
+
+	_, err = renamer.RenameTeam(ctx, "g2", "legacy")
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+}
+
+func TestMembershipBatches(t *testing.T) {
+	mux := http.NewServeMux()
+	var batchSizes []int
+	mux.HandleFunc("POST /v1.0/$batch", func(w http.ResponseWriter, r *http.Request) {
+		var batch struct {
+			Requests []BatchRequest `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		batchSizes = append(batchSizes, len(batch.Requests))
+
+		responses := make([]BatchResponse, 0, len(batch.Requests))
+		for _, request := range batch.Requests {
+			response := BatchResponse{ID: request.ID, Status: http.StatusNoContent}
+			switch request.Method + " " + request.ID {
+			case "POST 0":
+				// adding an existing member
+				body := graphError("Request_BadRequest",
+					"One or more added object references already exist for the following modified properties: 'members'.")
+				response.Status, response.Body = http.StatusBadRequest, &body
+			case "POST 21":
+				response.Status, response.Headers = http.StatusTooManyRequests, map[string]string{"Retry-After": "7"}
+			case "DELETE 1":
+				response.Status = http.StatusNotFound
+			}
+			responses = append(responses, response)
+		}
+		clienttest.Reply(w, http.StatusOK, map[string][]BatchResponse{"responses": responses})
+	})
+	client, _ := newGraphClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	userIDs := make([]string, 0, 25)
+	for i := range 25 {
+		userIDs = append(userIDs, "u"+strconv.Itoa(i))
+	}
+
+	// the existing members count as added, the throttled requests are retried later
+	err := client.AddUserToTeam(ctx, "g1", userIDs)
+	assert.Equal(t, []int{20, 5}, batchSizes)
+	assert.ErrorIs(t, err, clients.ErrRateLimited)
+	assert.Equal(t, 7*time.Second, clients.RetryAfter(err))
+	assert.ErrorContains(t, err, "POST /groups/g1/members/$ref failed")
+
+	// the members already gone count as removed
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "g1", userIDs[:2]))
+}
+
+func TestFetchTeamMembers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1.0/groups/g1/members/microsoft.graph.user", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusOK, ListResponse[GraphUser]{Value: []GraphUser{{ID: "u1", Mail: "JDoe@example.com"}}})
+	})
+	client, _ := newGraphClient(t, mux, map[string]interface{}{})
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe@example.com", members["u1"].Email)
+}
+
+func TestStatusErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	client, _ := newGraphClient(t, mux, map[string]interface{}{})
+	clienttest.StatusTest{
+		Route: "GET /v1.0/groups/g1/members/microsoft.graph.user",
+		Call: func(ctx context.Context) error {
+			_, err := client.FetchTeamMembersByTeamID(ctx, "g1")
+			return err
+		},
+		ErrorBody: func(status int) interface{} {
+			return graphError(strings.ReplaceAll(http.StatusText(status), " ", ""), http.StatusText(status))
+		},
+		DeleteRoute: "DELETE /v1.0/groups/g2",
+		Delete: func(ctx context.Context) error {
+			return client.DeleteTeamByID(ctx, "g2")
+		},
+	}.Run(t, mux)
+}
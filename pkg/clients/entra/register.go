@@ -0,0 +1,29 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+func init() {
+	clients.Register("entra", func(backend config.Backend,
+		httpClient config.HttpClientConfig) (clients.Client, error) {
+		return NewClient(backend.Connection, httpClient.ConnectionPoolConfig, httpClient.HystrixResiliencyConfig)
+	}, "tenant_id", "client_id", "client_secret")
+}
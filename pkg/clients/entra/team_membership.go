@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// maxBatchSize is the maximum number of requests of a JSON batch
+const maxBatchSize = 20
+
+// FetchTeamMembersByTeamID fetches the user members of the group, keyed by object ID
+func (c *EntraClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "entra",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	// the cast to microsoft.graph.user leaves out the nested groups and devices
+	endpoint := "/groups/" + url.PathEscape(teamID) + "/members/microsoft.graph.user?$top=999&$select=" + userFields
+	users, err := fetchAllWithPagination[GraphUser](ctx, c, endpoint)
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, err
+	}
+
+	members := make(map[string]*structs.User, len(users))
+	for _, user := range users {
+		members[user.ID] = toUser(user)
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group with batched requests
+func (c *EntraClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	requests := make([]BatchRequest, 0, len(userIDs))
+	for _, userID := range userIDs {
+		requests = append(requests, BatchRequest{
+			Method:  http.MethodPost,
+			URL:     "/groups/" + url.PathEscape(teamID) + "/members/$ref",
+			Body:    map[string]string{"@odata.id": c.config.BaseURL + "/directoryObjects/" + url.PathEscape(userID)},
+			Headers: map[string]string{"Content-Type": "application/json"},
+		})
+	}
+	return c.batch(ctx, teamID, "adding users to team", requests, func(response BatchResponse) bool {
+		// adding an existing member fails with a bad request
+		return response.Status == http.StatusBadRequest && response.Body != nil &&
+			strings.Contains(response.Body.Error.Message, "already exist")
+	})
+}
+
+// RemoveUserFromTeam removes the users from the group with batched requests
+func (c *EntraClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	requests := make([]BatchRequest, 0, len(userIDs))
+	for _, userID := range userIDs {
+		requests = append(requests, BatchRequest{
+			Method: http.MethodDelete,
+			URL:    "/groups/" + url.PathEscape(teamID) + "/members/" + url.PathEscape(userID) + "/$ref",
+		})
+	}
+	return c.batch(ctx, teamID, "removing users from team", requests, func(response BatchResponse) bool {
+		return response.Status == http.StatusNotFound
+	})
+}
+
+// batch sends the requests in JSON batches of up to maxBatchSize requests, the responses
+// accepted by ignore, e.g. adding an existing member, count as successful
+func (c *EntraClient) batch(ctx context.Context, teamID, action string, requests []BatchRequest,
+	ignore func(BatchResponse) bool) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "entra",
+		"teamID":     teamID,
+		"user_count": len(requests),
+	})
+	log.Info(action)
+
+	var errs []error
+	for start := 0; start < len(requests); start += maxBatchSize {
+		chunk := requests[start:min(start+maxBatchSize, len(requests))]
+		for i := range chunk {
+			chunk[i].ID = strconv.Itoa(start + i)
+		}
+
+		resp, headers, status, err := c.makeRequest(ctx, "/$batch", http.MethodPost,
+			map[string][]BatchRequest{"requests": chunk})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send batch: %w", err))
+			continue
+		}
+		if status != http.StatusOK {
+			errs = append(errs, statusError(status, headers, resp, "failed to send batch"))
+			continue
+		}
+
+		var batch struct {
+			Responses []BatchResponse `json:"responses"`
+		}
+		if err := json.Unmarshal(resp, &batch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse batch response: %w", err))
+			continue
+		}
+		for _, response := range batch.Responses {
+			if response.Status/100 == 2 || ignore(response) {
+				continue
+			}
+			message := ""
+			if response.Body != nil {
+				message = response.Body.Error.Message
+			}
+			request := response.ID
+			if index, err := strconv.Atoi(response.ID); err == nil && index >= 0 && index < len(requests) {
+				request = requests[index].Method + " " + requests[index].URL
+			}
+			// the requests of a batch are throttled one by one, with their own Retry-After header
+			header := make(http.Header, len(response.Headers))
+			for name, value := range response.Headers {
+				header.Set(name, value)
+			}
+			errs = append(errs, clients.StatusError(response.Status, header, "%s failed, status: %s, message: %s",
+				request, http.StatusText(response.Status), message))
+		}
+	}
+
+	if len(errs) > 0 {
+		log.WithField("failed_count", len(errs)).Error("error updating team membership")
+		return errors.Join(errs...)
+	}
+	return nil
+}
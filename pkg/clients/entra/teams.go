@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// groupFields are the group properties selected from Microsoft Graph
+const groupFields = "id,displayName,description,mailNickname,mailEnabled,securityEnabled"
+
+// invalidNicknameChars matches the characters not allowed in the mail nickname of a group
+var invalidNicknameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// FetchAllTeams fetches the security groups of the tenant, keyed by display name
+func (c *EntraClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("service", "entra")
+
+	log.Info("fetching all teams")
+	endpoint := "/groups?$top=999&$select=" + groupFields + "&$filter=" + url.QueryEscape("securityEnabled eq true")
+	groups, err := fetchAllWithPagination[GraphGroup](ctx, c, endpoint)
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team, len(groups))
+	for _, group := range groups {
+		teams[group.DisplayName] = toTeam(group)
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates a security group
+func (c *EntraClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "entra",
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	payload := GraphGroup{
+		DisplayName:     team.Name,
+		Description:     team.Description,
+		MailNickname:    invalidNicknameChars.ReplaceAllString(team.Name, "-"),
+		MailEnabled:     false,
+		SecurityEnabled: true,
+	}
+
+	resp, headers, status, err := c.makeRequest(ctx, "/groups", http.MethodPost, payload)
+	if err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, err
+	}
+	if status != http.StatusCreated {
+		return nil, statusError(status, headers, resp, "failed to create group %s", team.Name)
+	}
+
+	var group GraphGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse create group response: %w", err)
+	}
+
+	log.Info("team created successfully")
+	createdTeam := toTeam(group)
+	return &createdTeam, nil
+}
+
+// FetchTeamDetails fetches the group by its object ID
+func (c *EntraClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "entra",
+		"teamID":  teamID,
+	})
+
+	log.Info("fetching team details")
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID)+"?$select="+groupFields,
+		http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, headers, resp, "failed to fetch group %s", teamID)
+	}
+
+	var group GraphGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+
+	log.Info("successfully fetched team details")
+	team := toTeam(group)
+	return &team, nil
+}
+
+// RenameTeam renames the group along with its mail nickname, keeping its object ID and members
+func (c *EntraClient) RenameTeam(ctx context.Context, teamID, name string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "entra",
+		"teamID":  teamID,
+		"team":    name,
+	})
+
+	log.Info("renaming team")
+	// only the properties sent are updated, GraphGroup would send its boolean ones as well
+	payload := map[string]string{
+		"displayName":  name,
+		"mailNickname": invalidNicknameChars.ReplaceAllString(name, "-"),
+	}
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodPatch, payload)
+	if err != nil {
+		log.WithError(err).Error("error renaming team")
+		return nil, err
+	}
+	if status != http.StatusNoContent {
+		return nil, statusError(status, headers, resp, "failed to rename group %s", teamID)
+	}
+
+	log.Info("team renamed successfully")
+	return &structs.Team{ID: teamID, Name: name}, nil
+}
+
+// DeleteTeamByID deletes the group, it stays restorable in the tenant for 30 days
+func (c *EntraClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "entra",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	if status != http.StatusNoContent && status != http.StatusNotFound {
+		return statusError(status, headers, resp, "failed to delete group")
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+func toTeam(group GraphGroup) structs.Team {
+	return structs.Team{
+		ID:          group.ID,
+		Name:        group.DisplayName,
+		Description: group.Description,
+	}
+}
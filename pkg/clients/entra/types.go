@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+)
+
+const (
+	// LookupByMail and LookupByUPN are the supported ways of matching the users to Entra accounts
+	LookupByMail = "mail"
+	LookupByUPN  = "upn"
+)
+
+// EntraConfig holds the configuration for Entra client
+type EntraConfig struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// BaseURL is the Microsoft Graph endpoint, including the API version
+	BaseURL string
+	// LoginURL is the Microsoft identity platform endpoint the tokens are fetched from
+	LoginURL string
+	// UserLookup is either LookupByMail or LookupByUPN
+	UserLookup string
+	// UPNAttribute is the LDAP attribute holding the user principal name, the email when empty
+	UPNAttribute string
+}
+
+// EntraClient is the client for managing Entra ID security groups through Microsoft Graph
+type EntraClient struct {
+	config *EntraConfig
+	client heimdall.Doer
+
+	// the access token of the application, refreshed when it expires
+	tokenMu     sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// TokenResponse is the response of the OAuth 2.0 token endpoint
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// ListResponse is a page of a Microsoft Graph collection
+type ListResponse[T any] struct {
+	Value    []T    `json:"value"`
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// GraphUser represents an Entra user
+type GraphUser struct {
+	ID                string `json:"id"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	Mail              string `json:"mail"`
+	DisplayName       string `json:"displayName"`
+	GivenName         string `json:"givenName"`
+	Surname           string `json:"surname"`
+}
+
+// GraphGroup represents an Entra group
+type GraphGroup struct {
+	ID              string `json:"id,omitempty"`
+	DisplayName     string `json:"displayName"`
+	Description     string `json:"description,omitempty"`
+	MailNickname    string `json:"mailNickname"`
+	MailEnabled     bool   `json:"mailEnabled"`
+	SecurityEnabled bool   `json:"securityEnabled"`
+}
+
+// BatchRequest is a request of a JSON batch
+type BatchRequest struct {
+	ID      string            `json:"id"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Body    interface{}       `json:"body,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// BatchResponse is the response of a request of a JSON batch
+type BatchResponse struct {
+	ID      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    *GraphErrorBody   `json:"body,omitempty"`
+}
+
+// GraphErrorBody is the body of the Microsoft Graph errors
+type GraphErrorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
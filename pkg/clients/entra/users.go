@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// userFields are the user properties selected from Microsoft Graph
+const userFields = "id,userPrincipalName,mail,displayName,givenName,surname"
+
+var (
+	// ErrUserNotFound is returned by CreateUser when no Entra account matches the user
+	ErrUserNotFound = clients.NewError(clients.ErrNotFound, "no entra user found")
+	// ErrUserDeletionNotSupported is returned by DeleteUser, the accounts are owned by the tenant
+	ErrUserDeletionNotSupported = errors.New("deleting users is not supported by the entra backend")
+)
+
+// FetchAllUsers fetches all the users of the tenant
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
+func (c *EntraClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "entra")
+
+	log.Info("fetching all users")
+	users, err := fetchAllWithPagination[GraphUser](ctx, c, "/users?$top=999&$select="+userFields)
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	resultByID := make(map[string]*structs.User, len(users))
+	resultByEmail := make(map[string]*structs.User, len(users))
+	for _, user := range users {
+		structUser := toUser(user)
+		resultByID[structUser.ID] = structUser
+		if structUser.Email != "" {
+			resultByEmail[structUser.Email] = structUser
+		}
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, resultByEmail, nil
+}
+
+// CreateUser looks up the Entra account of the user by mail or user principal name,
+// the accounts are provisioned by the tenant and never created
+func (c *EntraClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "entra",
+		"email":   user.GetEmail(),
+		"lookup":  c.config.UserLookup,
+	})
+
+	log.Info("looking up user")
+	var (
+		graphUser *GraphUser
+		err       error
+	)
+	if c.config.UserLookup == LookupByUPN {
+		upn := user.GetEmail()
+		if c.config.UPNAttribute != "" {
+			upn = user.GetAttribute(c.config.UPNAttribute)
+		}
+		graphUser, err = c.findUserByUPN(ctx, upn)
+	} else {
+		graphUser, err = c.findUserByMail(ctx, user.GetEmail())
+	}
+	if err != nil {
+		log.WithError(err).Error("error looking up user")
+		return nil, err
+	}
+
+	log.Info("found user")
+	return toUser(*graphUser), nil
+}
+
+// FetchUserDetails fetches the user by its object ID
+func (c *EntraClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "entra",
+		"userID":  userID,
+	})
+	log.Info("fetching user details by ID")
+
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		log.WithError(err).Error("error fetching user details")
+		return nil, err
+	}
+
+	log.Info("found user details")
+	return toUser(*user), nil
+}
+
+// DeleteUser isn't supported, the accounts are owned by the tenant
+func (c *EntraClient) DeleteUser(_ context.Context, _ string) error {
+	return ErrUserDeletionNotSupported
+}
+
+func (c *EntraClient) findUserByUPN(ctx context.Context, upn string) (*GraphUser, error) {
+	if upn == "" {
+		return nil, fmt.Errorf("%w: no user principal name", ErrUserNotFound)
+	}
+	return c.getUser(ctx, upn)
+}
+
+func (c *EntraClient) findUserByMail(ctx context.Context, mail string) (*GraphUser, error) {
+	if mail == "" {
+		return nil, fmt.Errorf("%w: no email", ErrUserNotFound)
+	}
+
+	// single quotes are escaped by doubling them in OData literals
+	filter := fmt.Sprintf("mail eq '%s'", strings.ReplaceAll(mail, "'", "''"))
+	endpoint := "/users?$select=" + userFields + "&$filter=" + url.QueryEscape(filter)
+	users, err := fetchAllWithPagination[GraphUser](ctx, c, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 {
+		return nil, fmt.Errorf("%w: %d users with mail %s", ErrUserNotFound, len(users), mail)
+	}
+	return &users[0], nil
+}
+
+// getUser fetches the user by object ID or user principal name
+func (c *EntraClient) getUser(ctx context.Context, idOrUPN string) (*GraphUser, error) {
+	resp, headers, status, err := c.makeRequest(ctx, "/users/"+url.PathEscape(idOrUPN)+"?$select="+userFields,
+		http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, idOrUPN)
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, headers, resp, "failed to fetch user")
+	}
+
+	var user GraphUser
+	if err := json.Unmarshal(resp, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+	return &user, nil
+}
+
+func toUser(user GraphUser) *structs.User {
+	return &structs.User{
+		ID:          user.ID,
+		UserName:    user.UserPrincipalName,
+		Email:       strings.ToLower(user.Mail),
+		FirstName:   user.GivenName,
+		LastName:    user.Surname,
+		DisplayName: user.DisplayName,
+	}
+}
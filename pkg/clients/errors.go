@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// The kinds of the errors returned by the clients, matched with errors.Is. The reconciler
+// tells with them the errors worth retrying from the ones needing an operator.
+var (
+	// ErrNotFound is returned when the user or team doesn't exist on the backend
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists is returned when creating a user or team the backend already has
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrRateLimited is returned when the backend throttles the calls, see RetryAfter
+	ErrRateLimited = errors.New("rate limited")
+	// ErrUnauthorized is returned when the backend rejects the credentials or their permissions
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrTransient is returned on network errors and server errors, the call may succeed on retry
+	ErrTransient = errors.New("transient error")
+)
+
+// Error is an error of a backend of the kind of one of the errors above
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// NewError returns an error with the message of the kind, one of the errors above
+func NewError(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// RateLimitError is the ErrRateLimited error, carrying the delay the backend asked to wait before retrying
+type RateLimitError struct {
+	// RetryAfter is zero when the backend didn't tell
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RateLimitError) Unwrap() []error {
+	return []error{ErrRateLimited, e.Err}
+}
+
+// RetryAfter returns the delay a rate limited backend asked to wait, zero for the other errors
+func RetryAfter(err error) time.Duration {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr.RetryAfter
+	}
+	return 0
+}
+
+// Transient wraps err, e.g. the error of a call that didn't get a response, as an ErrTransient error
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: ErrTransient, Err: err}
+}
+
+// StatusError returns the error of a response with an unexpected HTTP status, of the kind of
+// the status if any. The header of the response gives the delay of the rate limited calls.
+func StatusError(status int, header http.Header, format string, args ...interface{}) error {
+	return WrapStatus(status, header, fmt.Errorf(format, args...))
+}
+
+// WrapStatus wraps err as an error of the kind of the HTTP status, err is returned as is
+// for the statuses without any
+func WrapStatus(status int, header http.Header, err error) error {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return &RateLimitError{RetryAfter: parseRetryAfter(header), Err: err}
+	case status == http.StatusForbidden && parseRetryAfter(header) > 0:
+		// some backends, e.g. GitHub on its secondary rate limits, forbid the calls for a while
+		return &RateLimitError{RetryAfter: parseRetryAfter(header), Err: err}
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return &Error{Kind: ErrUnauthorized, Err: err}
+	case status == http.StatusNotFound:
+		return &Error{Kind: ErrNotFound, Err: err}
+	case status == http.StatusConflict:
+		return &Error{Kind: ErrAlreadyExists, Err: err}
+	case status == http.StatusRequestTimeout || status >= http.StatusInternalServerError:
+		return &Error{Kind: ErrTransient, Err: err}
+	}
+	return err
+}
+
+// parseRetryAfter parses the Retry-After header, either a number of seconds or a date
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		status int
+		kind   error
+	}{
+		{status: http.StatusUnauthorized, kind: ErrUnauthorized},
+		{status: http.StatusForbidden, kind: ErrUnauthorized},
+		{status: http.StatusNotFound, kind: ErrNotFound},
+		{status: http.StatusConflict, kind: ErrAlreadyExists},
+		{status: http.StatusTooManyRequests, kind: ErrRateLimited},
+		{status: http.StatusRequestTimeout, kind: ErrTransient},
+		{status: http.StatusServiceUnavailable, kind: ErrTransient},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			err := StatusError(tt.status, nil, "failed to create role, status: %s", http.StatusText(tt.status))
+			assert.ErrorIs(t, err, tt.kind)
+			assert.EqualError(t, err, "failed to create role, status: "+http.StatusText(tt.status))
+		})
+	}
+
+	err := StatusError(http.StatusBadRequest, nil, "invalid name")
+	for _, kind := range []error{ErrNotFound, ErrAlreadyExists, ErrRateLimited, ErrUnauthorized, ErrTransient} {
+		assert.NotErrorIs(t, err, kind)
+	}
+
+	// the kinds are kept through wrapping
+	wrapped := fmt.Errorf("sync failed: %w", StatusError(http.StatusNotFound, nil, "no role"))
+	assert.ErrorIs(t, wrapped, ErrNotFound)
+}
+
+func TestRetryAfter(t *testing.T) {
+	err := StatusError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"12"}}, "slow down")
+	assert.Equal(t, 12*time.Second, RetryAfter(fmt.Errorf("wrapped: %w", err)))
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	err = StatusError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{date}}, "slow down")
+	assert.InDelta(t, time.Minute, RetryAfter(err), float64(2*time.Second))
+
+	// a forbidden call with a delay is rate limited rather than unauthorized
+	err = StatusError(http.StatusForbidden, http.Header{"Retry-After": []string{"60"}}, "secondary rate limit")
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, time.Minute, RetryAfter(err))
+
+	assert.Zero(t, RetryAfter(StatusError(http.StatusTooManyRequests, nil, "slow down")))
+	assert.Zero(t, RetryAfter(StatusError(http.StatusNotFound, nil, "no role")))
+}
+
+func TestTransient(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := Transient(cause)
+	assert.ErrorIs(t, err, ErrTransient)
+	assert.ErrorIs(t, err, cause)
+	assert.EqualError(t, err, "connection reset")
+	assert.NoError(t, Transient(nil))
+}
@@ -17,9 +17,20 @@ limitations under the License.
 package fivetran
 
 import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/fivetran/go-fivetran"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 )
 
+// statusPattern matches the HTTP status in the errors of the fivetran SDK, e.g. 'status code: 404; expected: 200'
+var statusPattern = regexp.MustCompile(`status code: (\d+);`)
+
 type FivetranClient struct {
 	fivetranClient *fivetran.Client
 }
@@ -29,3 +40,33 @@ func NewClient(apiKey, apiSecret string) *FivetranClient {
 		fivetranClient: fivetran.New(apiKey, apiSecret),
 	}
 }
+
+// wrapError classifies the errors of the fivetran SDK, which reports the HTTP status only in their
+// message, the errors without any status are the calls which didn't get a response
+func wrapError(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	if match := statusPattern.FindStringSubmatch(err.Error()); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		return clients.WrapStatus(status, nil, err)
+	}
+	// the SDK waits as long as asked on 429 and gives up after a few attempts
+	if strings.Contains(err.Error(), "rate limit") {
+		return &clients.RateLimitError{Err: err}
+	}
+	return clients.Transient(err)
+}
+
+// Capabilities returns the operations supported by fivetran, the users and teams are
+// managed by the client, the members are given a role in their teams and the teams
+// a role on destinations and connectors
+func (fc *FivetranClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserProvisioning: true,
+		UserListing:      true,
+		TeamListing:      true,
+		MembershipRoles:  true,
+		Grants:           true,
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fivetran
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fivetran/go-fivetran"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// membershipPage is a page of the team memberships, next is the cursor of the following page
+type membershipPage struct {
+	items []map[string]string
+	next  string
+}
+
+// fakeMemberships serves the team membership endpoints, the lists are paginated by cursor
+type fakeMemberships struct {
+	t        *testing.T
+	mu       sync.Mutex
+	pages    map[string]map[string]membershipPage
+	requests []string
+}
+
+func newFakeMemberships(t *testing.T) (*fakeMemberships, *FivetranClient) {
+	fake := &fakeMemberships{t: t, pages: map[string]map[string]membershipPage{}}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	client := fivetran.New("key", "secret")
+	client.BaseURL(server.URL + "/v1")
+	// the rate limited calls are reported rather than waited for
+	client.SetHandleRateLimits(false)
+	return fake, &FivetranClient{fivetranClient: client}
+}
+
+func (f *fakeMemberships) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"NotFound_Connector","message":"connector not found"}`))
+		case strings.HasSuffix(r.URL.Path, "/throttled"):
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"code":"TooManyRequests","message":"rate limit exceeded"}`))
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"code":"Success"}`))
+		default:
+			_, _ = w.Write([]byte(`{"code":"Success"}`))
+		}
+		return
+	}
+
+	page, ok := f.pages[r.URL.Path][r.URL.Query().Get("cursor")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"NotFound","message":"unknown page"}`))
+		return
+	}
+	body := map[string]interface{}{
+		"code": "Success",
+		"data": map[string]interface{}{"items": page.items, "next_cursor": page.next},
+	}
+	require.NoError(f.t, json.NewEncoder(w).Encode(body))
+}
+
+func TestFetchTeamGrantsPagination(t *testing.T) {
+	fake, client := newFakeMemberships(t)
+	fake.pages["/v1/teams/team_1/groups"] = map[string]membershipPage{
+		"":      {items: []map[string]string{{"id": "warehouse", "role": "Destination Reviewer"}}, next: "page2"},
+		"page2": {items: []map[string]string{{"id": "lake", "role": "Destination Administrator"}}},
+	}
+	fake.pages["/v1/teams/team_1/connectors"] = map[string]membershipPage{
+		"":      {items: []map[string]string{{"id": "salesforce", "role": "Connector Reviewer"}}, next: "page2"},
+		"page2": {items: []map[string]string{{"id": "hubspot", "role": "Connector Administrator"}}},
+	}
+
+	// every page of both lists is fetched, the connectors from their first page
+	grants, err := client.FetchTeamGrants(t.Context(), "team_1")
+	require.NoError(t, err)
+	assert.Equal(t, []structs.Grant{
+		{ObjectType: structs.DestinationObjectType, ObjectName: "warehouse", Privilege: "Destination Reviewer"},
+		{ObjectType: structs.DestinationObjectType, ObjectName: "lake", Privilege: "Destination Administrator"},
+		{ObjectType: structs.ConnectorObjectType, ObjectName: "salesforce", Privilege: "Connector Reviewer"},
+		{ObjectType: structs.ConnectorObjectType, ObjectName: "hubspot", Privilege: "Connector Administrator"},
+	}, grants)
+	assert.Equal(t, []string{
+		"GET /v1/teams/team_1/groups?",
+		"GET /v1/teams/team_1/groups?cursor=page2",
+		"GET /v1/teams/team_1/connectors?",
+		"GET /v1/teams/team_1/connectors?cursor=page2",
+	}, fake.requests)
+
+	// a page missing midway fails the whole listing
+	delete(fake.pages["/v1/teams/team_1/connectors"], "page2")
+	_, err = client.FetchTeamGrants(t.Context(), "team_1")
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+}
+
+func TestTeamMembershipChanges(t *testing.T) {
+	fake, client := newFakeMemberships(t)
+	destination := structs.Grant{
+		ObjectType: structs.DestinationObjectType, ObjectName: "warehouse", Privilege: "Destination Reviewer",
+	}
+	connector := structs.Grant{
+		ObjectType: structs.ConnectorObjectType, ObjectName: "salesforce", Privilege: "Connector Administrator",
+	}
+
+	require.NoError(t, client.GrantToTeam(t.Context(), "team_1", []structs.Grant{destination, connector}))
+	require.NoError(t, client.ModifyTeamGrants(t.Context(), "team_1", []structs.Grant{connector}))
+	assert.Equal(t, []string{
+		"POST /v1/teams/team_1/groups?",
+		"POST /v1/teams/team_1/connectors?",
+		"PATCH /v1/teams/team_1/connectors/salesforce?",
+	}, fake.requests)
+
+	// the memberships already gone are ignored when revoking, not when changing their role
+	missing := structs.Grant{ObjectType: structs.ConnectorObjectType, ObjectName: "missing"}
+	assert.NoError(t, client.RevokeFromTeam(t.Context(), "team_1", []structs.Grant{missing, destination}))
+	assert.ErrorIs(t, client.ModifyTeamGrants(t.Context(), "team_1", []structs.Grant{missing}), clients.ErrNotFound)
+
+	throttled := structs.Grant{ObjectType: structs.DestinationObjectType, ObjectName: "throttled"}
+	assert.ErrorIs(t, client.ModifyTeamGrants(t.Context(), "team_1", []structs.Grant{throttled}),
+		clients.ErrRateLimited)
+
+	unsupported := structs.Grant{ObjectType: "warehouse", ObjectName: "compute_wh"}
+	assert.ErrorContains(t, client.GrantToTeam(t.Context(), "team_1", []structs.Grant{unsupported}),
+		"unsupported object type warehouse")
+}
@@ -0,0 +1,180 @@
+package fivetran
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamGrants returns the memberships of the team on destinations and connectors,
+// as grants of their role
+func (fc *FivetranClient) FetchTeamGrants(ctx context.Context, teamID string) ([]structs.Grant, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "fivetran",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team memberships on destinations and connectors")
+
+	grants := make([]structs.Grant, 0)
+	cursor := ""
+	for {
+		service := fc.fivetranClient.NewTeamGroupMembershipsList().TeamId(teamID)
+		if cursor != "" {
+			service = service.Cursor(cursor)
+		}
+		resp, err := service.Do(ctx)
+		if err != nil {
+			log.WithError(err).Error("error fetching team destination memberships")
+			return nil, wrapError(err)
+		}
+		for _, item := range resp.Data.Items {
+			grants = append(grants, structs.Grant{
+				ObjectType: structs.DestinationObjectType,
+				ObjectName: item.GroupId,
+				Privilege:  item.Role,
+			})
+		}
+		if cursor = resp.Data.NextCursor; cursor == "" {
+			break
+		}
+	}
+
+	for {
+		service := fc.fivetranClient.NewTeamConnectorMembershipsList().TeamId(teamID)
+		if cursor != "" {
+			service = service.Cursor(cursor)
+		}
+		resp, err := service.Do(ctx)
+		if err != nil {
+			log.WithError(err).Error("error fetching team connector memberships")
+			return nil, wrapError(err)
+		}
+		for _, item := range resp.Data.Items {
+			grants = append(grants, structs.Grant{
+				ObjectType: structs.ConnectorObjectType,
+				ObjectName: item.ConnectorId,
+				Privilege:  item.Role,
+			})
+		}
+		if cursor = resp.Data.NextCursor; cursor == "" {
+			break
+		}
+	}
+
+	return grants, nil
+}
+
+// GrantToTeam adds the memberships of the team
+func (fc *FivetranClient) GrantToTeam(ctx context.Context, teamID string, grants []structs.Grant) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":     "fivetran",
+		"teamID":      teamID,
+		"grant_count": len(grants),
+	})
+	log.Info("adding team memberships on destinations and connectors")
+
+	for _, grant := range grants {
+		var err error
+		switch grant.ObjectType {
+		case structs.DestinationObjectType:
+			_, err = fc.fivetranClient.NewTeamGroupMembershipCreate().
+				TeamId(teamID).
+				GroupId(grant.ObjectName).
+				Role(grant.Privilege).
+				Do(ctx)
+		case structs.ConnectorObjectType:
+			_, err = fc.fivetranClient.NewTeamConnectorMembershipCreate().
+				TeamId(teamID).
+				ConnectorId(grant.ObjectName).
+				Role(grant.Privilege).
+				Do(ctx)
+		default:
+			err = fmt.Errorf("unsupported object type %s for fivetran team memberships", grant.ObjectType)
+		}
+		if err = wrapError(err); err != nil {
+			log.WithField("grant", grant.String()).WithError(err).Error("error adding team membership")
+			return fmt.Errorf("failed to grant %s to team %s: %w", grant, teamID, err)
+		}
+	}
+
+	return nil
+}
+
+// ModifyTeamGrants changes the role of the existing memberships of the team
+func (fc *FivetranClient) ModifyTeamGrants(ctx context.Context, teamID string, grants []structs.Grant) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":     "fivetran",
+		"teamID":      teamID,
+		"grant_count": len(grants),
+	})
+	log.Info("changing team membership roles on destinations and connectors")
+
+	for _, grant := range grants {
+		var err error
+		switch grant.ObjectType {
+		case structs.DestinationObjectType:
+			_, err = fc.fivetranClient.NewTeamGroupMembershipModify().
+				TeamId(teamID).
+				GroupId(grant.ObjectName).
+				Role(grant.Privilege).
+				Do(ctx)
+		case structs.ConnectorObjectType:
+			_, err = fc.fivetranClient.NewTeamConnectorMembershipModify().
+				TeamId(teamID).
+				ConnectorId(grant.ObjectName).
+				Role(grant.Privilege).
+				Do(ctx)
+		default:
+			err = fmt.Errorf("unsupported object type %s for fivetran team memberships", grant.ObjectType)
+		}
+		if err = wrapError(err); err != nil {
+			log.WithField("grant", grant.String()).WithError(err).Error("error changing team membership role")
+			return fmt.Errorf("failed to change the role of team %s to %s: %w", teamID, grant, err)
+		}
+	}
+
+	return nil
+}
+
+// RevokeFromTeam removes the memberships of the team
+func (fc *FivetranClient) RevokeFromTeam(ctx context.Context, teamID string, grants []structs.Grant) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":     "fivetran",
+		"teamID":      teamID,
+		"grant_count": len(grants),
+	})
+	log.Info("removing team memberships on destinations and connectors")
+
+	for _, grant := range grants {
+		var err error
+		switch grant.ObjectType {
+		case structs.DestinationObjectType:
+			_, err = fc.fivetranClient.NewTeamGroupMembershipDelete().
+				TeamId(teamID).
+				GroupId(grant.ObjectName).
+				Do(ctx)
+		case structs.ConnectorObjectType:
+			_, err = fc.fivetranClient.NewTeamConnectorMembershipDelete().
+				TeamId(teamID).
+				ConnectorId(grant.ObjectName).
+				Do(ctx)
+		default:
+			err = fmt.Errorf("unsupported object type %s for fivetran team memberships", grant.ObjectType)
+		}
+		// the membership is already gone
+		if err = wrapError(err); errors.Is(err, clients.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			log.WithField("grant", grant.String()).WithError(err).Error("error removing team membership")
+			return fmt.Errorf("failed to revoke %s from team %s: %w", grant, teamID, err)
+		}
+	}
+
+	return nil
+}
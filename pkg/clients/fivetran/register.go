@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fivetran
+
+import (
+	"errors"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+func init() {
+	clients.Register("fivetran", func(backend config.Backend, _ config.HttpClientConfig) (clients.Client, error) {
+		apiKey := backend.GetStringConnection("apikey", "")
+		apiSecret := backend.GetStringConnection("apisecret", "")
+		if apiKey == "" || apiSecret == "" {
+			return nil, errors.New("missing required connection parameters for fivetran backend")
+		}
+		return NewClient(apiKey, apiSecret), nil
+	}, "apikey", "apisecret")
+}
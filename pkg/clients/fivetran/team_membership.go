@@ -2,6 +2,7 @@ package fivetran
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -29,7 +30,7 @@ func (fc *FivetranClient) FetchTeamMembersByTeamID(
 		Do(ctx)
 	if err != nil {
 		log.WithError(err).Error("error fetching team members by team ID")
-		return nil, err
+		return nil, wrapError(err)
 	}
 	for _, item := range resp.Data.Items {
 		teamMembers[item.UserId] = &structs.User{
@@ -46,7 +47,7 @@ func (fc *FivetranClient) FetchTeamMembersByTeamID(
 			Do(ctx)
 		if err != nil {
 			log.WithField("response", resp.Code).WithError(err).Error("error fetching list of team members")
-			return nil, err
+			return nil, wrapError(err)
 		}
 		for _, item := range resp.Data.Items {
 			teamMembers[item.UserId] = &structs.User{
@@ -94,7 +95,7 @@ func (fc *FivetranClient) AddUserToTeam(ctx context.Context, teamID string, user
 			if err != nil {
 				slog.WithField("response", resp.CommonResponse).WithError(err).
 					Error("Error adding user to team")
-				errch <- fmt.Errorf("%s: %w", uid, err)
+				errch <- fmt.Errorf("%s: %w", uid, wrapError(err))
 				return
 			}
 			slog.Info("added users to the team successfully")
@@ -109,7 +110,7 @@ func (fc *FivetranClient) AddUserToTeam(ctx context.Context, teamID string, user
 		allErrors = append(allErrors, err)
 	}
 	if len(allErrors) > 0 {
-		return fmt.Errorf("multiple errors occurred: %v", allErrors)
+		return fmt.Errorf("multiple errors occurred: %w", errors.Join(allErrors...))
 	}
 	return nil
 }
@@ -142,7 +143,7 @@ func (fc *FivetranClient) RemoveUserFromTeam(ctx context.Context, teamID string,
 				Do(ctx)
 			if err != nil {
 				slog.WithField("response", resp).WithError(err).Error("error removing user from the team")
-				errch <- fmt.Errorf("%s: %w", uid, err)
+				errch <- fmt.Errorf("%s: %w", uid, wrapError(err))
 				return
 
 			}
@@ -159,7 +160,7 @@ func (fc *FivetranClient) RemoveUserFromTeam(ctx context.Context, teamID string,
 		allErrors = append(allErrors, err)
 	}
 	if len(allErrors) > 0 {
-		return fmt.Errorf("multiple errors occurred: %v", allErrors)
+		return fmt.Errorf("multiple errors occurred: %w", errors.Join(allErrors...))
 	}
 	return nil
 }
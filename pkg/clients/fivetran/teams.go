@@ -16,7 +16,7 @@ func (fc *FivetranClient) FetchAllTeams(ctx context.Context) (map[string]structs
 	resp, err := fc.fivetranClient.NewTeamsList().Do(ctx)
 	if err != nil {
 		log.WithError(err).Error("error fetching list of teams")
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	log.WithField("total_teams_count", len(resp.Data.Items)).Info("found teams")
@@ -52,7 +52,7 @@ func (fc *FivetranClient) CreateTeam(ctx context.Context, team *structs.Team) (*
 
 	if err != nil {
 		log.WithError(err).WithField("response", resp).Error("error creating the team")
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &structs.Team{
@@ -83,7 +83,7 @@ func (fc *FivetranClient) UpdateTeam(ctx context.Context, g *UpdateTeam) (*struc
 
 	if err != nil {
 		log.WithError(err).WithField("response", resp).Error("error updating the team")
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &structs.Team{
@@ -107,7 +107,7 @@ func (fc *FivetranClient) FetchTeamDetails(ctx context.Context, teamID string) (
 		Do(ctx)
 	if err != nil {
 		log.WithField("responseCode", resp.Code).WithError(err).Error("error fetching team details")
-		return &structs.Team{}, err
+		return &structs.Team{}, wrapError(err)
 	}
 
 	log.Info("successfully fetched team details")
@@ -130,7 +130,7 @@ func (fc *FivetranClient) DeleteTeamByID(ctx context.Context, teamID string) err
 	resp, err := fc.fivetranClient.NewTeamsDelete().TeamId(teamID).Do(ctx)
 	if err != nil {
 		log.WithField("response", resp).WithError(err).Error("error deleting the team")
-		return err
+		return wrapError(err)
 	}
 
 	log.WithField("response", resp).Info("team deleted successfully")
@@ -25,7 +25,7 @@ func (fc *FivetranClient) FetchAllUsers(ctx context.Context) (
 	resp, err := fc.fivetranClient.NewUsersList().Do(ctx)
 	if err != nil {
 		log.WithField("response", resp.CommonResponse).WithError(err).Error("error fetching list of users")
-		return nil, nil, err
+		return nil, nil, wrapError(err)
 	}
 	for _, item := range resp.Data.Items {
 		usersEmailMap[item.Email] = userDetailsFromResponse(item)
@@ -38,7 +38,7 @@ func (fc *FivetranClient) FetchAllUsers(ctx context.Context) (
 		resp, err := fc.fivetranClient.NewUsersList().Cursor(cursor).Do(ctx)
 		if err != nil {
 			log.WithField("response", resp.CommonResponse).WithError(err).Error("error fetching list of users")
-			return nil, nil, err
+			return nil, nil, wrapError(err)
 		}
 		for _, item := range resp.Data.Items {
 			usersEmailMap[item.Email] = userDetailsFromResponse(item)
@@ -70,7 +70,7 @@ func (fc *FivetranClient) CreateUser(ctx context.Context, u *structs.User) (*str
 		Do(ctx)
 	if err != nil {
 		log.WithField("response", resp.CommonResponse).WithError(err).Error("error inviting the user")
-		return &structs.User{}, err
+		return &structs.User{}, wrapError(err)
 	}
 	log.WithField("response", resp).Info("invite sent to the user")
 
@@ -87,7 +87,7 @@ func (fc *FivetranClient) FetchUserDetails(ctx context.Context, userID string) (
 	resp, err := fc.fivetranClient.NewUserDetails().UserID(userID).Do(ctx)
 	if err != nil {
 		log.WithField("response", resp.CommonResponse).WithError(err).Error("error fetching user details")
-		return &structs.User{}, err
+		return &structs.User{}, wrapError(err)
 	}
 
 	log.Info("found user details")
@@ -110,7 +110,7 @@ func (fc *FivetranClient) UpdateUser(ctx context.Context, u *structs.User) (*str
 		Do(ctx)
 	if err != nil {
 		log.WithField("response", resp.CommonResponse).WithError(err).Error("error updating the user")
-		return &structs.User{}, err
+		return &structs.User{}, wrapError(err)
 	}
 
 	return userDetailsFromResponse(resp.Data), nil
@@ -132,7 +132,7 @@ func (fc *FivetranClient) DeleteUser(ctx context.Context, userID string) error {
 			"code":    resp.Code,
 			"message": resp.Message,
 		}).WithError(err).Error("error deleting the user")
-		return err
+		return wrapError(err)
 	}
 	log.Info("user deleted successfully")
 	return nil
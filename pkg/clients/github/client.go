@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+const (
+	// DefaultBaseURL is the GitHub REST API endpoint, GitHub Enterprise Server uses https://<host>/api/v3
+	DefaultBaseURL = "https://api.github.com"
+	// apiVersion is the GitHub REST API version the client is written against
+	apiVersion = "2022-11-28"
+	// pageSize is the maximum number of items GitHub returns per page
+	pageSize = 100
+
+	defaultTeamPrivacy = "closed"
+	// memberRole is the role of the users in the organization and the teams
+	memberRole = "member"
+	// secondaryRateLimitWait is waited on the secondary rate limits not telling for how long
+	secondaryRateLimitWait = time.Minute
+)
+
+// NewClient creates a new GitHub client with the given configuration
+func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionPoolConfig,
+	hystrixCfg httpclient.HystrixResiliencyConfig) (*GitHubClient, error) {
+
+	// Extract connection parameters
+	token, _ := connection["token"].(string)
+	org, _ := connection["org"].(string)
+	if token == "" || org == "" {
+		return nil, errors.New("missing required connection parameters for github backend: token and org are required")
+	}
+
+	config := GitHubConfig{
+		Token:       token,
+		Org:         org,
+		BaseURL:     DefaultBaseURL,
+		TeamPrivacy: defaultTeamPrivacy,
+	}
+	if baseURL, _ := connection["base_url"].(string); baseURL != "" {
+		config.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	if loginAttribute, _ := connection["login_attribute"].(string); loginAttribute != "" {
+		config.LoginAttribute = loginAttribute
+	}
+	if privacy, _ := connection["team_privacy"].(string); privacy != "" {
+		if privacy != "closed" && privacy != "secret" {
+			return nil, fmt.Errorf("invalid team_privacy %q for github backend: expected closed or secret", privacy)
+		}
+		config.TeamPrivacy = privacy
+	}
+
+	client, err := httpclient.InitializeClient(
+		"github",
+		poolCfg,
+		hystrixCfg,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &GitHubClient{
+		config: &config,
+		client: client,
+	}, nil
+}
+
+// makeRequest sends a request to the GitHub API. endpoint is either a path relative
+// to the base URL or an absolute URL, as returned in the pagination links.
+func (c *GitHubClient) makeRequest(ctx context.Context, endpoint,
+	method string, body interface{}) ([]byte, http.Header, int, error) {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	url := endpoint
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		url = c.config.BaseURL + endpoint
+	}
+	req, err := request.NewRequest(ctx, method, url, requestBody)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	req.SetHeaders(map[string]string{
+		"Authorization":        "Bearer " + c.config.Token,
+		"Accept":               "application/vnd.github+json",
+		"Content-Type":         "application/json",
+		"X-GitHub-Api-Version": apiVersion,
+	})
+
+	resp, header, status, err := req.MakeRequestWithHeader(c.client, method, "github")
+	if err != nil {
+		return nil, nil, status, clients.Transient(err)
+	}
+	return resp, header, status, nil
+}
+
+// statusError returns the error of a response with an unexpected status, of the kind of the status.
+// GitHub answers the calls over its primary rate limit with 403 or 429 and X-RateLimit-Remaining
+// down to 0 until X-RateLimit-Reset, and the ones over a secondary rate limit with 403 or 429 and
+// either a Retry-After header or only a message, in which case at least a minute should be waited.
+func statusError(status int, header http.Header, resp []byte, format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	if (status != http.StatusForbidden && status != http.StatusTooManyRequests) || header.Get("Retry-After") != "" {
+		return clients.WrapStatus(status, header, err)
+	}
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+		return &clients.RateLimitError{RetryAfter: max(time.Until(time.Unix(reset, 0)), 0), Err: err}
+	}
+	if strings.Contains(strings.ToLower(string(resp)), "secondary rate limit") {
+		return &clients.RateLimitError{RetryAfter: secondaryRateLimitWait, Err: err}
+	}
+	return clients.WrapStatus(status, header, err)
+}
+
+// fetchAllWithPagination calls processPage with every page of a list endpoint,
+// following the "next" links of the Link header
+func (c *GitHubClient) fetchAllWithPagination(ctx context.Context,
+	endpoint string, processPage func([]byte) error) error {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	nextURL := fmt.Sprintf("%s%sper_page=%d", endpoint, separator, pageSize)
+
+	for nextURL != "" {
+		resp, headers, status, err := c.makeRequest(ctx, nextURL, http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return statusError(status, headers, resp, "failed to fetch data from %s, status: %s, body: %s",
+				endpoint, http.StatusText(status), string(resp))
+		}
+
+		if err := processPage(resp); err != nil {
+			return err
+		}
+		nextURL = nextPageURL(headers.Get("Link"))
+	}
+
+	return nil
+}
+
+// nextPageURL returns the URL of the "next" relation of a Link header, e.g.
+// <https://api.github.com/organizations/1/members?page=2>; rel="next", <...>; rel="last"
+func nextPageURL(linkHeader string) string {
+	for _, link := range strings.Split(linkHeader, ",") {
+		url, params, found := strings.Cut(link, ";")
+		if !found {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(url), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// GetConfig returns the client configuration
+func (c *GitHubClient) GetConfig() *GitHubConfig {
+	return c.config
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/clienttest"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of the acme organization calling a server routing the API calls with mux
+func newTestClient(t *testing.T, mux *http.ServeMux) *GitHubClient {
+	server := clienttest.NewServer(t, mux, func(w http.ResponseWriter, r *http.Request) bool {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, apiVersion, r.Header.Get("X-GitHub-Api-Version"))
+		return true
+	})
+
+	client, err := NewClient(map[string]interface{}{
+		"token":           "token",
+		"org":             "acme",
+		"base_url":        server.URL + "/",
+		"login_attribute": "githubLogin",
+	}, clienttest.PoolConfig, clienttest.HystrixConfig)
+	require.NoError(t, err)
+	return client
+}
+
+// respond returns a handler answering with the status and body
+func respond(status int, body interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, status, body)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	pool, hystrix := clienttest.PoolConfig, clienttest.HystrixConfig
+
+	_, err := NewClient(map[string]interface{}{"token": "token"}, pool, hystrix)
+	assert.ErrorContains(t, err, "token and org are required")
+
+	_, err = NewClient(map[string]interface{}{"token": "token", "org": "acme", "team_privacy": "public"}, pool, hystrix)
+	assert.ErrorContains(t, err, "invalid team_privacy")
+
+	client, err := NewClient(map[string]interface{}{"token": "token", "org": "acme"}, pool, hystrix)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBaseURL, client.GetConfig().BaseURL)
+	assert.Equal(t, "closed", client.GetConfig().TeamPrivacy)
+}
+
+func TestFetchAllUsersFollowsLinks(t *testing.T) {
+	var pages []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/acme/members", func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.RawQuery)
+		logins := map[string]string{"per_page=100": "Octocat", "page=2&per_page=100": "hubot"}
+		if r.URL.Query().Get("page") == "" {
+			// the links are absolute and carry the page size along
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2&per_page=100>; rel="next", `+
+				`<http://%s%s?page=2&per_page=100>; rel="last"`, r.Host, r.URL.Path, r.Host, r.URL.Path))
+		}
+		clienttest.Reply(w, http.StatusOK, []GitHubUser{{Login: logins[r.URL.RawQuery], Email: "Ignored@example.com"}})
+	})
+	client := newTestClient(t, mux)
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"per_page=100", "page=2&per_page=100"}, pages)
+	assert.Equal(t, "Octocat", byID["octocat"].UserName)
+	assert.Contains(t, byID, "hubot")
+	// the members are never keyed by email, GitHub only returns the public ones
+	assert.Empty(t, byEmail)
+}
+
+func TestFetchAllUsersFailingPage(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/acme/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			clienttest.Reply(w, http.StatusBadGateway, map[string]string{"message": "Server Error"})
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+		clienttest.Reply(w, http.StatusOK, []GitHubUser{{Login: "octocat"}})
+	})
+	client := newTestClient(t, mux)
+
+	_, _, err := client.FetchAllUsers(context.Background())
+	assert.ErrorIs(t, err, clients.ErrTransient)
+}
+
+func TestStatusErrors(t *testing.T) {
+	reset := strconv.FormatInt(time.Now().Add(90*time.Second).Unix(), 10)
+	mux := http.NewServeMux()
+	client := newTestClient(t, mux)
+	clienttest.StatusTest{
+		Route: "GET /orgs/acme/teams/data-team",
+		Call: func(ctx context.Context) error {
+			_, err := client.FetchTeamDetails(ctx, "data-team")
+			return err
+		},
+		Cases: []clienttest.StatusCase{
+			{
+				Name:       "primary rate limit",
+				Status:     http.StatusForbidden,
+				Header:     map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset},
+				Kind:       clients.ErrRateLimited,
+				RetryAfter: 90 * time.Second,
+			},
+			{
+				Name:       "secondary rate limit with retry-after",
+				Status:     http.StatusForbidden,
+				Header:     map[string]string{"Retry-After": "60"},
+				Kind:       clients.ErrRateLimited,
+				RetryAfter: time.Minute,
+			},
+			{
+				Name:       "secondary rate limit without retry-after",
+				Status:     http.StatusForbidden,
+				Body:       map[string]string{"message": "You have exceeded a secondary rate limit."},
+				Kind:       clients.ErrRateLimited,
+				RetryAfter: secondaryRateLimitWait,
+			},
+		},
+	}.Run(t, mux)
+}
+
+func TestCreateUserInvitesNonMembers(t *testing.T) {
+	var invited []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/octocat", respond(http.StatusOK, GitHubUser{Login: "Octocat", Name: "The Octocat"}))
+	mux.HandleFunc("GET /users/monalisa", respond(http.StatusOK, GitHubUser{Login: "monalisa"}))
+	// octocat is an admin of the organization, monalisa isn't a member
+	mux.HandleFunc("GET /orgs/acme/memberships/Octocat", respond(http.StatusOK, map[string]string{"role": "admin"}))
+	mux.HandleFunc("GET /orgs/acme/memberships/monalisa", respond(http.StatusNotFound, nil))
+	mux.HandleFunc("PUT /orgs/acme/memberships/monalisa", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		invited = append(invited, "monalisa:"+body["role"])
+		clienttest.Reply(w, http.StatusOK, map[string]string{"state": "pending"})
+	})
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	// the login is read from the LDAP attribute, the email is kept from LDAP
+	user, err := client.CreateUser(ctx, &structs.User{
+		UserName:   "ocat",
+		Email:      "Octocat@example.com",
+		Attributes: map[string]string{"githubLogin": " octocat "},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.User{
+		ID: "octocat", UserName: "Octocat", Email: "octocat@example.com", DisplayName: "The Octocat",
+	}, user)
+
+	user, err = client.CreateUser(ctx, &structs.User{Attributes: map[string]string{"githubLogin": "monalisa"}})
+	require.NoError(t, err)
+	assert.Equal(t, "monalisa", user.ID)
+	assert.Equal(t, []string{"monalisa:" + memberRole}, invited)
+
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "nologin"})
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+	_, err = client.CreateUser(ctx, &structs.User{Attributes: map[string]string{"githubLogin": "ghost"}})
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+}
+
+func TestCreateTeamNameTaken(t *testing.T) {
+	var payload map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /orgs/acme/teams", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		switch payload["name"] {
+		case "data_team":
+			clienttest.Reply(w, http.StatusCreated, GitHubTeam{ID: 1, Slug: "data-team", Name: "data_team"})
+		case "taken":
+			clienttest.Reply(w, http.StatusUnprocessableEntity, map[string]interface{}{
+				"message": "Validation Failed",
+				"errors":  []map[string]string{{"code": "custom", "message": "Name must be unique for this org"}},
+			})
+		default:
+			clienttest.Reply(w, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"})
+		}
+	})
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	team, err := client.CreateTeam(ctx, &structs.Team{Name: "data_team", Description: "team for data"})
+	require.NoError(t, err)
+	assert.Equal(t, structs.Team{ID: "data-team", Name: "data_team"}, *team)
+	assert.Equal(t, "closed", payload["privacy"])
+
+	// the teams named as an existing one are adopted by the reconciler
+	_, err = client.CreateTeam(ctx, &structs.Team{Name: "taken"})
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+
+	_, err = client.CreateTeam(ctx, &structs.Team{Name: "in/valid"})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, clients.ErrAlreadyExists)
+}
+
+func TestTeamMembershipRemovalIsIdempotent(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /orgs/acme/teams/data-team/memberships/octocat", respond(http.StatusOK, nil))
+	mux.HandleFunc("DELETE /orgs/acme/teams/data-team/memberships/octocat", respond(http.StatusNoContent, nil))
+	mux.HandleFunc("DELETE /orgs/acme/teams/data-team/memberships/hubot", respond(http.StatusNotFound, nil))
+	mux.HandleFunc("PUT /orgs/acme/teams/data-team/memberships/ghost", respond(http.StatusUnprocessableEntity,
+		map[string]string{"message": "Validation Failed"}))
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	require.NoError(t, client.AddUserToTeam(ctx, "data-team", []string{"octocat"}))
+	assert.ErrorContains(t, client.AddUserToTeam(ctx, "data-team", []string{"octocat", "ghost"}),
+		"failed to add user ghost to team data-team")
+
+	// the members already gone from the team are ignored
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "data-team", []string{"octocat", "hubot"}))
+}
+
+func TestNextPageURL(t *testing.T) {
+	link := `<https://api.github.com/organizations/1/members?page=2>; rel="next", ` +
+		`<https://api.github.com/organizations/1/members?page=5>; rel="last"`
+	assert.Equal(t, "https://api.github.com/organizations/1/members?page=2", nextPageURL(link))
+	assert.Equal(t, "", nextPageURL(`<https://api.github.com/organizations/1/members?page=1>; rel="prev"`))
+	assert.Equal(t, "", nextPageURL(""))
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamMembersByTeamID fetches the members of the team, keyed by lower cased login
+func (c *GitHubClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	members := make(map[string]*structs.User)
+	endpoint := fmt.Sprintf("/orgs/%s/teams/%s/members", c.config.Org, teamID)
+	err := c.fetchAllWithPagination(ctx, endpoint, func(resp []byte) error {
+		var githubUsers []GitHubUser
+		if err := json.Unmarshal(resp, &githubUsers); err != nil {
+			return fmt.Errorf("error unmarshaling response: %w", err)
+		}
+		for _, githubUser := range githubUsers {
+			members[strings.ToLower(githubUser.Login)] = toUser(githubUser)
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the team, users who aren't members of the organization yet get invited
+func (c *GitHubClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "github",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("adding users to team")
+
+	for _, userID := range userIDs {
+		endpoint := fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", c.config.Org, teamID, userID)
+		resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodPut, map[string]string{"role": memberRole})
+		if err != nil {
+			return fmt.Errorf("failed to add user %s to team %s: %w", userID, teamID, err)
+		}
+
+		if status != http.StatusOK {
+			return statusError(status, headers, resp, "failed to add user %s to team %s, status: %s, body: %s",
+				userID, teamID, http.StatusText(status), string(resp))
+		}
+	}
+
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the team, they remain members of the organization
+func (c *GitHubClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "github",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("removing users from team")
+
+	for _, userID := range userIDs {
+		endpoint := fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", c.config.Org, teamID, userID)
+		resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodDelete, nil)
+		if err != nil {
+			return fmt.Errorf("failed to remove user %s from team %s: %w", userID, teamID, err)
+		}
+
+		if status != http.StatusNoContent && status != http.StatusNotFound {
+			return statusError(status, headers, resp, "failed to remove user %s from team %s, status: %s, body: %s",
+				userID, teamID, http.StatusText(status), string(resp))
+		}
+	}
+
+	return nil
+}
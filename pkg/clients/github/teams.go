@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchAllTeams fetches all the teams of the organization, keyed by name with the slug as ID
+func (c *GitHubClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"org":     c.config.Org,
+	})
+
+	log.Info("fetching all teams")
+	teams := make(map[string]structs.Team)
+
+	endpoint := fmt.Sprintf("/orgs/%s/teams", c.config.Org)
+	err := c.fetchAllWithPagination(ctx, endpoint, func(resp []byte) error {
+		var githubTeams []GitHubTeam
+		if err := json.Unmarshal(resp, &githubTeams); err != nil {
+			return fmt.Errorf("failed to parse teams response: %w", err)
+		}
+		for _, githubTeam := range githubTeams {
+			teams[githubTeam.Name] = toTeam(githubTeam)
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, err
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates a new team in the organization
+func (c *GitHubClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"org":     c.config.Org,
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	endpoint := fmt.Sprintf("/orgs/%s/teams", c.config.Org)
+	payload := map[string]interface{}{
+		"name":        team.Name,
+		"description": team.Description,
+		"privacy":     c.config.TeamPrivacy,
+	}
+
+	resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodPost, payload)
+	if err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, err
+	}
+	// GitHub rejects a team named as an existing one as invalid rather than conflicting
+	if status == http.StatusUnprocessableEntity && strings.Contains(string(resp), "must be unique") {
+		return nil, clients.NewError(clients.ErrAlreadyExists, "team %s already exists, body: %s", team.Name, string(resp))
+	}
+	if status != http.StatusCreated {
+		return nil, statusError(status, headers, resp, "failed to create team, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var createdTeam GitHubTeam
+	if err := json.Unmarshal(resp, &createdTeam); err != nil {
+		return nil, fmt.Errorf("failed to parse create team response: %w", err)
+	}
+
+	result := toTeam(createdTeam)
+	return &result, nil
+}
+
+// FetchTeamDetails fetches the team by its slug
+func (c *GitHubClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"teamID":  teamID,
+	})
+
+	log.Info("fetching team details")
+	endpoint := fmt.Sprintf("/orgs/%s/teams/%s", c.config.Org, teamID)
+	resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, headers, resp, "failed to fetch team details, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var githubTeam GitHubTeam
+	if err := json.Unmarshal(resp, &githubTeam); err != nil {
+		return nil, fmt.Errorf("failed to parse team response: %w", err)
+	}
+
+	log.Info("successfully fetched team details")
+	team := toTeam(githubTeam)
+	return &team, nil
+}
+
+// DeleteTeamByID deletes the team by its slug
+func (c *GitHubClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	endpoint := fmt.Sprintf("/orgs/%s/teams/%s", c.config.Org, teamID)
+	resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	if status != http.StatusNoContent && status != http.StatusNotFound {
+		return statusError(status, headers, resp, "failed to delete team, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+func toTeam(githubTeam GitHubTeam) structs.Team {
+	return structs.Team{
+		ID:          githubTeam.Slug,
+		Name:        githubTeam.Name,
+		Description: githubTeam.Description,
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import "github.com/gojek/heimdall/v7"
+
+// GitHubConfig holds the configuration for GitHub client
+type GitHubConfig struct {
+	Token   string
+	Org     string
+	BaseURL string
+	// LoginAttribute is the LDAP attribute holding the GitHub login of the users,
+	// the LDAP uid is used as the login when it's not set
+	LoginAttribute string
+	// TeamPrivacy is the privacy of the created teams, either closed or secret
+	TeamPrivacy string
+}
+
+// GitHubClient is the client for interacting with GitHub REST API
+type GitHubClient struct {
+	config *GitHubConfig
+	client heimdall.Doer
+}
+
+// GitHubUser represents a user object from GitHub API response
+type GitHubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// GitHubTeam represents a team object from GitHub API response
+type GitHubTeam struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+	Privacy     string `json:"privacy,omitempty"`
+}
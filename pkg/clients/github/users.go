@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchAllUsers fetches all the members of the organization.
+// GitHub doesn't expose the email of the members, so the map keyed by email is always empty
+// and users get mapped to their login when they're created.
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
+func (c *GitHubClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"org":     c.config.Org,
+	})
+
+	log.Info("fetching all users")
+	resultByID := make(map[string]*structs.User)
+	resultByEmail := make(map[string]*structs.User)
+
+	endpoint := fmt.Sprintf("/orgs/%s/members", c.config.Org)
+	err := c.fetchAllWithPagination(ctx, endpoint, func(resp []byte) error {
+		var members []GitHubUser
+		if err := json.Unmarshal(resp, &members); err != nil {
+			return fmt.Errorf("failed to parse members response: %w", err)
+		}
+		for _, member := range members {
+			resultByID[strings.ToLower(member.Login)] = toUser(member)
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, resultByEmail, nil
+}
+
+// CreateUser adds the user to the organization. GitHub accounts can't be created through the API,
+// so the login is looked up from the configured LDAP attribute and an invitation to the
+// organization is sent when the user isn't a member yet.
+func (c *GitHubClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	login := c.loginOf(user)
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"org":     c.config.Org,
+		"login":   login,
+	})
+
+	log.Info("creating user")
+	if login == "" {
+		return nil, clients.NewError(clients.ErrNotFound, "github login not found for user %s", user.GetUserName())
+	}
+
+	githubUser, err := c.fetchUser(ctx, login)
+	if err != nil {
+		log.WithError(err).Error("error fetching github user")
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/orgs/%s/memberships/%s", c.config.Org, githubUser.Login)
+	resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching organization membership")
+		return nil, err
+	}
+
+	switch status {
+	case http.StatusOK:
+		// already a member, or invited, keep the existing role
+		log.Info("user is already a member of the organization")
+	case http.StatusNotFound:
+		resp, headers, status, err = c.makeRequest(ctx, endpoint, http.MethodPut, map[string]string{"role": memberRole})
+		if err != nil {
+			log.WithError(err).Error("error inviting user to the organization")
+			return nil, err
+		}
+		if status != http.StatusOK {
+			return nil, statusError(status, headers, resp, "failed to invite user to the organization, status: %s, body: %s",
+				http.StatusText(status), string(resp))
+		}
+		log.Info("invited user to the organization")
+	default:
+		return nil, statusError(status, headers, resp, "failed to fetch organization membership, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	createdUser := toUser(*githubUser)
+	createdUser.Email = strings.ToLower(user.GetEmail())
+	return createdUser, nil
+}
+
+// FetchUserDetails fetches the GitHub account of the given login
+func (c *GitHubClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"userID":  userID,
+	})
+	log.Info("fetching user details by ID")
+
+	githubUser, err := c.fetchUser(ctx, userID)
+	if err != nil {
+		log.WithError(err).Error("error fetching user details")
+		return nil, err
+	}
+
+	log.Info("found user details")
+	return toUser(*githubUser), nil
+}
+
+// DeleteUser removes the user from the organization, the GitHub account itself is left untouched
+func (c *GitHubClient) DeleteUser(ctx context.Context, userID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "github",
+		"org":     c.config.Org,
+		"userID":  userID,
+	})
+
+	log.Info("deleting user")
+	endpoint := fmt.Sprintf("/orgs/%s/memberships/%s", c.config.Org, userID)
+	resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting user")
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if status != http.StatusNoContent && status != http.StatusNotFound {
+		return statusError(status, headers, resp, "failed to delete user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	log.Info("user deleted successfully")
+	return nil
+}
+
+// fetchUser returns the GitHub account of the login
+func (c *GitHubClient) fetchUser(ctx context.Context, login string) (*GitHubUser, error) {
+	resp, headers, status, err := c.makeRequest(ctx, "/users/"+login, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, clients.NewError(clients.ErrNotFound, "github user %s not found", login)
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, headers, resp, "failed to fetch user details, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var githubUser GitHubUser
+	if err := json.Unmarshal(resp, &githubUser); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+	return &githubUser, nil
+}
+
+// loginOf returns the GitHub login of the user, read from the configured LDAP attribute
+func (c *GitHubClient) loginOf(user *structs.User) string {
+	if c.config.LoginAttribute != "" {
+		return strings.TrimSpace(user.GetAttribute(c.config.LoginAttribute))
+	}
+	return user.GetUserName()
+}
+
+func toUser(githubUser GitHubUser) *structs.User {
+	return &structs.User{
+		ID:          strings.ToLower(githubUser.Login),
+		UserName:    githubUser.Login,
+		Email:       strings.ToLower(githubUser.Email),
+		DisplayName: githubUser.Name,
+	}
+}
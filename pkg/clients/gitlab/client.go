@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+const (
+	// DefaultBaseURL is the GitLab SaaS endpoint, self-managed instances use their own URL
+	DefaultBaseURL = "https://gitlab.com"
+	// apiPath is the path of the REST API under the base URL
+	apiPath = "/api/v4"
+	// pageSize is the maximum number of items GitLab returns per page
+	pageSize = 100
+)
+
+// NewClient creates a new GitLab client with the given configuration
+func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionPoolConfig,
+	hystrixCfg httpclient.HystrixResiliencyConfig) (*GitLabClient, error) {
+
+	// Extract connection parameters
+	token, _ := connection["token"].(string)
+	if token == "" {
+		return nil, errors.New("missing required connection parameters for gitlab backend: token is required")
+	}
+
+	config := GitLabConfig{
+		Token:       token,
+		BaseURL:     DefaultBaseURL,
+		AccessLevel: DeveloperAccess,
+		UserLookup:  LookupByUsername,
+	}
+	if baseURL, _ := connection["base_url"].(string); baseURL != "" {
+		config.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	// the parent group may be set by ID or full path, e.g. 'my-org/data-teams'
+	if parent, ok := connection["parent_group"]; ok && parent != nil && fmt.Sprint(parent) != "" {
+		config.ParentGroupID = url.PathEscape(fmt.Sprint(parent))
+	}
+	if accessLevel, _ := connection["access_level"].(string); accessLevel != "" {
+		level, ok := accessLevels[strings.ToLower(accessLevel)]
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid access_level %q for gitlab backend: expected guest, reporter, developer or maintainer", accessLevel)
+		}
+		config.AccessLevel = level
+	}
+	if lookup, _ := connection["user_lookup"].(string); lookup != "" {
+		lookup = strings.ToLower(lookup)
+		if lookup != LookupByUsername && lookup != LookupByEmail {
+			return nil, fmt.Errorf("invalid user_lookup %q for gitlab backend: expected username or email", lookup)
+		}
+		config.UserLookup = lookup
+	}
+
+	client, err := httpclient.InitializeClient(
+		"gitlab",
+		poolCfg,
+		hystrixCfg,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &GitLabClient{
+		config: &config,
+		client: client,
+	}, nil
+}
+
+// makeRequest sends a request to the GitLab API. endpoint is either a path relative
+// to the API root or an absolute URL, as returned in the pagination links.
+func (c *GitLabClient) makeRequest(ctx context.Context, endpoint,
+	method string, body interface{}) ([]byte, http.Header, int, error) {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	url := endpoint
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		url = c.config.BaseURL + apiPath + endpoint
+	}
+	req, err := request.NewRequest(ctx, method, url, requestBody)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	req.SetHeaders(map[string]string{
+		"PRIVATE-TOKEN": c.config.Token,
+		"Content-Type":  "application/json",
+		"Accept":        "application/json",
+	})
+
+	resp, header, status, err := req.MakeRequestWithHeader(c.client, method, "gitlab")
+	if err != nil {
+		return nil, nil, status, clients.Transient(err)
+	}
+	return resp, header, status, nil
+}
+
+// fetchAllWithPagination calls processPage with every page of a list endpoint,
+// following the "next" links of the Link header
+func (c *GitLabClient) fetchAllWithPagination(ctx context.Context,
+	endpoint string, processPage func([]byte) error) error {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	nextURL := fmt.Sprintf("%s%sper_page=%d", endpoint, separator, pageSize)
+
+	for nextURL != "" {
+		resp, headers, status, err := c.makeRequest(ctx, nextURL, http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return clients.StatusError(status, headers, "failed to fetch data from %s, status: %s, body: %s",
+				endpoint, http.StatusText(status), string(resp))
+		}
+
+		if err := processPage(resp); err != nil {
+			return err
+		}
+		nextURL = nextPageURL(headers.Get("Link"))
+	}
+
+	return nil
+}
+
+// nextPageURL returns the URL of the "next" relation of a Link header
+func nextPageURL(linkHeader string) string {
+	for _, link := range strings.Split(linkHeader, ",") {
+		url, params, found := strings.Cut(link, ";")
+		if !found {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(url), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// GetConfig returns the client configuration
+func (c *GitLabClient) GetConfig() *GitLabConfig {
+	return c.config
+}
+
+// Capabilities returns the operations supported by the backend, the GitLab accounts are provisioned
+// by the identity provider, the members are given the configured access level unless the Group gives
+// them another role and the teams may be subgroups of a parent group
+func (c *GitLabClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserListing:     true,
+		TeamListing:     true,
+		MembershipRoles: true,
+		NestedTeams:     true,
+		Rename:          true,
+	}
+}
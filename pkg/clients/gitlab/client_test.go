@@ -0,0 +1,348 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/clienttest"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMuxClient returns a client of a server routing the API calls with mux, the token is checked first
+func newMuxClient(t *testing.T, mux *http.ServeMux, connection map[string]interface{}) *GitLabClient {
+	server := clienttest.NewServer(t, mux, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("PRIVATE-TOKEN") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	})
+
+	connection["token"] = "token"
+	connection["base_url"] = server.URL
+	client, err := NewClient(connection, clienttest.PoolConfig, clienttest.HystrixConfig)
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	pool, hystrix := clienttest.PoolConfig, clienttest.HystrixConfig
+
+	_, err := NewClient(map[string]interface{}{}, pool, hystrix)
+	assert.Error(t, err)
+
+	// owners manage the groups, they can't be given to the synced members
+	_, err = NewClient(map[string]interface{}{"token": "token", "access_level": "owner"}, pool, hystrix)
+	assert.ErrorContains(t, err, "invalid access_level")
+
+	_, err = NewClient(map[string]interface{}{"token": "token", "user_lookup": "uid"}, pool, hystrix)
+	assert.ErrorContains(t, err, "invalid user_lookup")
+
+	client, err := NewClient(map[string]interface{}{
+		"token":        "token",
+		"access_level": "Maintainer",
+		"parent_group": 42,
+	}, pool, hystrix)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBaseURL, client.GetConfig().BaseURL)
+	assert.Equal(t, MaintainerAccess, client.GetConfig().AccessLevel)
+	assert.Equal(t, "42", client.GetConfig().ParentGroupID)
+	assert.Equal(t, LookupByUsername, client.GetConfig().UserLookup)
+}
+
+func TestFetchAllUsersFollowsLinks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		// only the human and active accounts are synced, 100 per page
+		assert.Equal(t, "true", r.URL.Query().Get("active"))
+		assert.Equal(t, "true", r.URL.Query().Get("humans"))
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		if r.URL.Query().Get("page") == "2" {
+			clienttest.Reply(w, http.StatusOK, []GitLabUser{{ID: 2, Username: "asmith", PublicEmail: "ASmith@example.com"}})
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v4/users?active=true&humans=true&page=2&per_page=100>; `+
+			`rel="next", <http://%s/api/v4/users?page=1>; rel="first"`, r.Host, r.Host))
+		clienttest.Reply(w, http.StatusOK, []GitLabUser{
+			{ID: 1, Username: "jdoe", Email: "JDoe@example.com", PublicEmail: "john@example.com"},
+			{ID: 3, Username: "bot"},
+		})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, byID, 3)
+	// the email seen by administrators wins over the public one
+	assert.Equal(t, "1", byEmail["jdoe@example.com"].ID)
+	assert.Equal(t, "2", byEmail["asmith@example.com"].ID)
+	assert.Len(t, byEmail, 2)
+}
+
+func TestCreateUserLookup(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Get("username") == "jdoe":
+			clienttest.Reply(w, http.StatusOK, []GitLabUser{{ID: 1, Username: "jdoe"}})
+		case r.URL.Query().Get("search") == "smith@example.com":
+			// the search matches partially, e.g. on other emails ending the same
+			clienttest.Reply(w, http.StatusOK, []GitLabUser{
+				{ID: 4, Username: "asmith2", PublicEmail: "asmith@example.com"},
+				{ID: 2, Username: "smith", PublicEmail: "Smith@example.com"},
+			})
+		default:
+			clienttest.Reply(w, http.StatusOK, []GitLabUser{})
+		}
+	})
+	ctx := context.Background()
+
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	user, err := client.CreateUser(ctx, &structs.User{UserName: "jdoe", Email: "JDoe@example.com"})
+	require.NoError(t, err)
+	// the accounts without a visible email keep the one of LDAP
+	assert.Equal(t, &structs.User{ID: "1", UserName: "jdoe", Email: "jdoe@example.com"}, user)
+
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "unknown"})
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+
+	client = newMuxClient(t, mux, map[string]interface{}{"user_lookup": "email"})
+	user, err = client.CreateUser(ctx, &structs.User{UserName: "bob", Email: "smith@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "2", user.ID)
+
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "bob"})
+	assert.ErrorContains(t, err, "email is required")
+	assert.ErrorIs(t, client.DeleteUser(ctx, "2"), ErrUserDeletionNotSupported)
+}
+
+func TestFetchAllTeamsOwned(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		// without the filters every public group of gitlab.com would be listed
+		assert.Equal(t, "true", r.URL.Query().Get("top_level_only"))
+		assert.Equal(t, strconv.Itoa(OwnerAccess), r.URL.Query().Get("min_access_level"))
+		clienttest.Reply(w, http.StatusOK, []GitLabGroup{{ID: 7, Name: "analytics", Description: "team for analytics"}})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{
+		"analytics": {ID: "7", Name: "analytics", Description: "team for analytics"},
+	}, teams)
+}
+
+func TestParentGroupByPath(t *testing.T) {
+	var created map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/groups/{group}/subgroups", func(w http.ResponseWriter, r *http.Request) {
+		// the full path of the parent is escaped as a single segment
+		assert.Contains(t, r.URL.EscapedPath(), "/groups/acme%2Fdata-teams/")
+		clienttest.Reply(w, http.StatusOK, []GitLabGroup{{ID: 8, Name: "Data_Team"}})
+	})
+	mux.HandleFunc("GET /api/v4/groups/{group}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "acme/data-teams", r.PathValue("group"))
+		clienttest.Reply(w, http.StatusOK, GitLabGroup{ID: 100, FullPath: "acme/data-teams"})
+	})
+	mux.HandleFunc("POST /api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+		clienttest.Reply(w, http.StatusCreated, GitLabGroup{ID: 9, Name: "Data Team/1"})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{"parent_group": "acme/data-teams"})
+	ctx := context.Background()
+
+	teams, err := client.FetchAllTeams(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "8", teams["Data_Team"].ID)
+
+	team, err := client.CreateTeam(ctx, &structs.Team{Name: "Data Team/1"})
+	require.NoError(t, err)
+	assert.Equal(t, "9", team.ID)
+	assert.Equal(t, float64(100), created["parent_id"])
+	assert.Equal(t, "data-team-1", created["path"])
+	assert.Equal(t, "private", created["visibility"])
+}
+
+func TestTeamMembers(t *testing.T) {
+	levels := map[string]int{"1": GuestAccess, "3": OwnerAccess}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/groups/7/members", func(w http.ResponseWriter, r *http.Request) {
+		members := make([]GitLabMember, 0)
+		for id, level := range levels {
+			userID, _ := strconv.ParseInt(id, 10, 64)
+			members = append(members, GitLabMember{GitLabUser: GitLabUser{ID: userID}, AccessLevel: level})
+		}
+		clienttest.Reply(w, http.StatusOK, members)
+	})
+	mux.HandleFunc("POST /api/v4/groups/7/members", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			UserID      string `json:"user_id"`
+			AccessLevel int    `json:"access_level"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if _, exists := levels[body.UserID]; exists {
+			clienttest.Reply(w, http.StatusConflict, map[string]string{"message": "Member already exists"})
+			return
+		}
+		levels[body.UserID] = body.AccessLevel
+		clienttest.Reply(w, http.StatusCreated, nil)
+	})
+	mux.HandleFunc("PUT /api/v4/groups/7/members/{user}", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			AccessLevel int `json:"access_level"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		levels[r.PathValue("user")] = body.AccessLevel
+		clienttest.Reply(w, http.StatusOK, nil)
+	})
+	mux.HandleFunc("DELETE /api/v4/groups/7/members/{user}", func(w http.ResponseWriter, r *http.Request) {
+		if _, exists := levels[r.PathValue("user")]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(levels, r.PathValue("user"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{"access_level": "reporter"})
+	ctx := context.Background()
+
+	// the owners, e.g. the account of the token, aren't synced
+	members, err := client.FetchTeamMembersByTeamID(ctx, "7")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{"1": {ID: "1", Role: "guest"}}, members)
+
+	// the existing members are aligned to the configured access level
+	require.NoError(t, client.AddUserToTeam(ctx, "7", []string{"1", "2"}))
+	assert.Equal(t, ReporterAccess, levels["1"])
+	assert.Equal(t, ReporterAccess, levels["2"])
+
+	require.NoError(t, client.SetTeamMemberRole(ctx, "7", "2", "Maintainer"))
+	assert.Equal(t, MaintainerAccess, levels["2"])
+	assert.ErrorContains(t, client.SetTeamMemberRole(ctx, "7", "2", "owner"), "invalid gitlab role")
+
+	// the members already gone are ignored
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "7", []string{"1", "5"}))
+	assert.NotContains(t, levels, "1")
+}
+
+func TestDeleteTeamByID(t *testing.T) {
+	mux := http.NewServeMux()
+	// groups are deleted asynchronously
+	mux.HandleFunc("DELETE /api/v4/groups/7", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusAccepted, map[string]string{"message": "202 Accepted"})
+	})
+	mux.HandleFunc("DELETE /api/v4/groups/8", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusForbidden, map[string]string{"message": "403 Forbidden"})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	require.NoError(t, client.DeleteTeamByID(ctx, "7"))
+	require.NoError(t, client.DeleteTeamByID(ctx, "9"))
+	assert.ErrorIs(t, client.DeleteTeamByID(ctx, "8"), clients.ErrUnauthorized)
+}
+
+func TestRenameTeam(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/v4/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		if r.PathValue("id") == "8" {
+			clienttest.Reply(w, http.StatusBadRequest, map[string]interface{}{
+				"message": map[string][]string{"path": {"has already been taken"}},
+			})
+			return
+		}
+		// the path follows the name, as for the created groups
+		assert.Equal(t, map[string]string{"name": "Data Eng", "path": "data-eng"}, payload)
+		clienttest.Reply(w, http.StatusOK, GitLabGroup{ID: 7, Name: payload["name"], Path: payload["path"]})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	renamer, ok := clients.GetTeamRenamer(client)
+	require.True(t, ok)
+	team, err := renamer.RenameTeam(ctx, "7", "Data Eng")
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "7", Name: "Data Eng"}, team)
+
+	_, err = renamer.RenameTeam(ctx, "8", "Data Eng")
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+}
+
+func TestStatusErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	clienttest.StatusTest{
+		Route: "GET /api/v4/groups/7/members",
+		Call: func(ctx context.Context) error {
+			_, err := client.FetchTeamMembersByTeamID(ctx, "7")
+			return err
+		},
+		ErrorBody: func(status int) interface{} {
+			return map[string]string{"message": http.StatusText(status)}
+		},
+	}.Run(t, mux)
+}
+
+func TestCreateTeamPathTaken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusBadRequest, map[string]interface{}{
+			"message": map[string][]string{"path": {"has already been taken"}},
+		})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+
+	// the groups whose path is taken are adopted by the reconciler
+	_, err := client.CreateTeam(context.Background(), &structs.Team{Name: "analytics"})
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+}
+
+func TestMemberRole(t *testing.T) {
+	client, err := NewClient(map[string]interface{}{"token": "token", "access_level": "reporter"},
+		clienttest.PoolConfig, clienttest.HystrixConfig)
+	require.NoError(t, err)
+
+	role, err := client.MemberRole("")
+	require.NoError(t, err)
+	assert.Equal(t, "reporter", role)
+
+	role, err = client.MemberRole("Maintainer")
+	require.NoError(t, err)
+	assert.Equal(t, "maintainer", role)
+
+	_, err = client.MemberRole("owner")
+	assert.Error(t, err)
+	assert.Equal(t, "50", accessLevelName(OwnerAccess))
+}
+
+func TestGroupPath(t *testing.T) {
+	assert.Equal(t, "data_team", groupPath("Data_Team"))
+	assert.Equal(t, "data-team-1", groupPath("Data Team/1"))
+	assert.Equal(t, "team", groupPath("-Team."))
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamMembersByTeamID fetches the direct members of the group, keyed by user ID.
+// Owners are left out as they manage the group, e.g. the account of the token, and aren't synced.
+func (c *GitLabClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	members := make(map[string]*structs.User)
+	err := c.fetchAllWithPagination(ctx, fmt.Sprintf("/groups/%s/members", teamID), func(resp []byte) error {
+		var groupMembers []GitLabMember
+		if err := json.Unmarshal(resp, &groupMembers); err != nil {
+			return fmt.Errorf("error unmarshaling response: %w", err)
+		}
+		for _, member := range groupMembers {
+			if member.AccessLevel >= OwnerAccess {
+				continue
+			}
+			user := toUser(member.GitLabUser)
+			user.Role = accessLevelName(member.AccessLevel)
+			members[user.ID] = user
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group with the configured access level.
+// Existing members get their access level updated.
+func (c *GitLabClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "gitlab",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("adding users to team")
+
+	for _, userID := range userIDs {
+		endpoint := fmt.Sprintf("/groups/%s/members", teamID)
+		payload := map[string]interface{}{
+			"user_id":      userID,
+			"access_level": c.config.AccessLevel,
+		}
+		resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodPost, payload)
+		if err != nil {
+			return fmt.Errorf("failed to add user %s to team %s: %w", userID, teamID, err)
+		}
+
+		if status == http.StatusConflict {
+			// already a member, align the access level
+			endpoint = fmt.Sprintf("/groups/%s/members/%s", teamID, userID)
+			resp, headers, status, err = c.makeRequest(ctx, endpoint, http.MethodPut,
+				map[string]interface{}{"access_level": c.config.AccessLevel})
+			if err != nil {
+				return fmt.Errorf("failed to update user %s in team %s: %w", userID, teamID, err)
+			}
+		}
+
+		if status != http.StatusOK && status != http.StatusCreated {
+			return clients.StatusError(status, headers,
+				"failed to add user %s to team %s, status: %s, body: %s",
+				userID, teamID, http.StatusText(status), string(resp))
+		}
+	}
+
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the group
+func (c *GitLabClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "gitlab",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("removing users from team")
+
+	for _, userID := range userIDs {
+		endpoint := fmt.Sprintf("/groups/%s/members/%s", teamID, userID)
+		resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodDelete, nil)
+		if err != nil {
+			return fmt.Errorf("failed to remove user %s from team %s: %w", userID, teamID, err)
+		}
+
+		if status != http.StatusNoContent && status != http.StatusNotFound {
+			return clients.StatusError(status, headers,
+				"failed to remove user %s from team %s, status: %s, body: %s",
+				userID, teamID, http.StatusText(status), string(resp))
+		}
+	}
+
+	return nil
+}
+
+// MemberRole returns the name of the access level given for the role, the configured
+// access level when the role is empty
+func (c *GitLabClient) MemberRole(role string) (string, error) {
+	if role == "" {
+		return accessLevelName(c.config.AccessLevel), nil
+	}
+	if _, ok := accessLevels[strings.ToLower(role)]; !ok {
+		return "", fmt.Errorf("invalid gitlab role %q: expected guest, reporter, developer or maintainer", role)
+	}
+	return strings.ToLower(role), nil
+}
+
+// SetTeamMemberRole changes the access level of a member of the group
+func (c *GitLabClient) SetTeamMemberRole(ctx context.Context, teamID, userID, role string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+		"userID":  userID,
+		"role":    role,
+	})
+	log.Info("changing the access level of a team member")
+
+	role, err := c.MemberRole(role)
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("/groups/%s/members/%s", teamID, userID)
+	resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodPut,
+		map[string]interface{}{"access_level": accessLevels[role]})
+	if err != nil {
+		return fmt.Errorf("failed to update user %s in team %s: %w", userID, teamID, err)
+	}
+	if status != http.StatusOK {
+		return clients.StatusError(status, headers, "failed to update user %s in team %s, status: %s, body: %s",
+			userID, teamID, http.StatusText(status), string(resp))
+	}
+	return nil
+}
+
+// accessLevelName returns the name of an access level, its value for the levels without one
+func accessLevelName(level int) string {
+	for name, value := range accessLevels {
+		if value == level {
+			return name
+		}
+	}
+	return strconv.Itoa(level)
+}
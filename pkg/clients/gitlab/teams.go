@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// invalidPathChars matches the characters not allowed in a GitLab group path
+var invalidPathChars = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// FetchAllTeams fetches the subgroups of the parent group, or the groups the token has access to
+// when no parent is configured, keyed by name
+func (c *GitLabClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("service", "gitlab")
+
+	log.Info("fetching all teams")
+	teams := make(map[string]structs.Team)
+
+	// without a parent group the teams are the top level groups owned by the account of the
+	// token, not every group visible to it, e.g. all the public groups of gitlab.com
+	endpoint := fmt.Sprintf("/groups?top_level_only=true&min_access_level=%d", OwnerAccess)
+	if c.config.ParentGroupID != "" {
+		endpoint = fmt.Sprintf("/groups/%s/subgroups", c.config.ParentGroupID)
+	}
+	err := c.fetchAllWithPagination(ctx, endpoint, func(resp []byte) error {
+		var groups []GitLabGroup
+		if err := json.Unmarshal(resp, &groups); err != nil {
+			return fmt.Errorf("failed to parse groups response: %w", err)
+		}
+		for _, group := range groups {
+			teams[group.Name] = toTeam(group)
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, err
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates a private group, as a subgroup of the parent group when one is configured
+func (c *GitLabClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	payload := map[string]interface{}{
+		"name":        team.Name,
+		"path":        groupPath(team.Name),
+		"description": team.Description,
+		"visibility":  "private",
+	}
+	if c.config.ParentGroupID != "" {
+		parentID, err := c.parentGroupID(ctx)
+		if err != nil {
+			log.WithError(err).Error("error resolving the parent group")
+			return nil, err
+		}
+		payload["parent_id"] = parentID
+	}
+
+	resp, headers, status, err := c.makeRequest(ctx, "/groups", http.MethodPost, payload)
+	if err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, err
+	}
+	// GitLab rejects a group whose path is taken as a bad request rather than a conflict
+	if status == http.StatusBadRequest && strings.Contains(string(resp), "has already been taken") {
+		return nil, clients.NewError(clients.ErrAlreadyExists, "group %s already exists, body: %s", team.Name, string(resp))
+	}
+	if status != http.StatusCreated {
+		return nil, clients.StatusError(status, headers, "failed to create group, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var group GitLabGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse create group response: %w", err)
+	}
+
+	createdTeam := toTeam(group)
+	return &createdTeam, nil
+}
+
+// FetchTeamDetails fetches the group by its ID
+func (c *GitLabClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+	})
+
+	log.Info("fetching team details")
+	group, err := c.fetchGroup(ctx, teamID)
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+
+	log.Info("successfully fetched team details")
+	team := toTeam(*group)
+	return &team, nil
+}
+
+// RenameTeam renames the group along with its path, keeping its members and subgroups
+func (c *GitLabClient) RenameTeam(ctx context.Context, teamID, name string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+		"team":    name,
+	})
+
+	log.Info("renaming team")
+	payload := map[string]interface{}{
+		"name": name,
+		"path": groupPath(name),
+	}
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+teamID, http.MethodPut, payload)
+	if err != nil {
+		log.WithError(err).Error("error renaming team")
+		return nil, err
+	}
+	if status == http.StatusBadRequest && strings.Contains(string(resp), "has already been taken") {
+		return nil, clients.NewError(clients.ErrAlreadyExists, "group %s already exists, body: %s", name, string(resp))
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, headers, "failed to rename group %s, status: %s, body: %s",
+			teamID, http.StatusText(status), string(resp))
+	}
+
+	var group GitLabGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+
+	log.Info("team renamed successfully")
+	team := toTeam(group)
+	return &team, nil
+}
+
+// DeleteTeamByID deletes the group by its ID
+func (c *GitLabClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+teamID, http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	// groups are deleted asynchronously, GitLab answers with 202 Accepted
+	if status != http.StatusAccepted && status != http.StatusNoContent && status != http.StatusNotFound {
+		return clients.StatusError(status, headers, "failed to delete group, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+// parentGroupID returns the numeric ID of the configured parent group, which may be set by path
+func (c *GitLabClient) parentGroupID(ctx context.Context) (int64, error) {
+	if id, err := strconv.ParseInt(c.config.ParentGroupID, 10, 64); err == nil {
+		return id, nil
+	}
+	group, err := c.fetchGroup(ctx, c.config.ParentGroupID)
+	if err != nil {
+		return 0, err
+	}
+	return group.ID, nil
+}
+
+func (c *GitLabClient) fetchGroup(ctx context.Context, groupID string) (*GitLabGroup, error) {
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+groupID, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, headers, "failed to fetch group %s, status: %s, body: %s",
+			groupID, http.StatusText(status), string(resp))
+	}
+
+	var group GitLabGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+	return &group, nil
+}
+
+// groupPath derives the URL path of a group from its name
+func groupPath(name string) string {
+	return strings.Trim(invalidPathChars.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+}
+
+func toTeam(group GitLabGroup) structs.Team {
+	return structs.Team{
+		ID:          strconv.FormatInt(group.ID, 10),
+		Name:        group.Name,
+		Description: group.Description,
+	}
+}
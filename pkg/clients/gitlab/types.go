@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import "github.com/gojek/heimdall/v7"
+
+// Access levels of the GitLab group members
+const (
+	GuestAccess      = 10
+	ReporterAccess   = 20
+	DeveloperAccess  = 30
+	MaintainerAccess = 40
+	OwnerAccess      = 50
+)
+
+// accessLevels maps the access level names accepted in the configuration to their values
+var accessLevels = map[string]int{
+	"guest":      GuestAccess,
+	"reporter":   ReporterAccess,
+	"developer":  DeveloperAccess,
+	"maintainer": MaintainerAccess,
+}
+
+// Ways of looking up the GitLab account of a user
+const (
+	LookupByUsername = "username"
+	LookupByEmail    = "email"
+)
+
+// GitLabConfig holds the configuration for GitLab client
+type GitLabConfig struct {
+	Token   string
+	BaseURL string
+	// ParentGroupID, when set, makes the teams subgroups of this group
+	ParentGroupID string
+	// AccessLevel is granted to the members added to the teams, unless the Group gives them another role
+	AccessLevel int
+	// UserLookup is how users are matched to GitLab accounts, by username or by email
+	UserLookup string
+}
+
+// GitLabClient is the client for interacting with GitLab REST API
+type GitLabClient struct {
+	config *GitLabConfig
+	client heimdall.Doer
+}
+
+// GitLabUser represents a user object from GitLab API response
+type GitLabUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	// PublicEmail is returned instead of Email for tokens without admin access
+	PublicEmail string `json:"public_email,omitempty"`
+	State       string `json:"state,omitempty"`
+}
+
+// GitLabMember represents a group member from GitLab API response
+type GitLabMember struct {
+	GitLabUser
+	AccessLevel int `json:"access_level"`
+}
+
+// GitLabGroup represents a group object from GitLab API response
+type GitLabGroup struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	FullPath    string `json:"full_path,omitempty"`
+	Description string `json:"description,omitempty"`
+	ParentID    *int64 `json:"parent_id,omitempty"`
+}
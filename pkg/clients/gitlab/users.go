@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrUserDeletionNotSupported is returned by DeleteUser, GitLab accounts are owned by the identity provider
+var ErrUserDeletionNotSupported = errors.New("deleting users is not supported by the gitlab backend")
+
+// FetchAllUsers fetches all the active users of the GitLab instance. Emails are only
+// returned to administrators, otherwise the public email of the users is used.
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
+func (c *GitLabClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "gitlab")
+
+	log.Info("fetching all users")
+	resultByID := make(map[string]*structs.User)
+	resultByEmail := make(map[string]*structs.User)
+
+	err := c.fetchAllWithPagination(ctx, "/users?active=true&humans=true", func(resp []byte) error {
+		var users []GitLabUser
+		if err := json.Unmarshal(resp, &users); err != nil {
+			return fmt.Errorf("failed to parse users response: %w", err)
+		}
+		for _, user := range users {
+			structUser := toUser(user)
+			resultByID[structUser.ID] = structUser
+			if structUser.Email != "" {
+				resultByEmail[structUser.Email] = structUser
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, resultByEmail, nil
+}
+
+// CreateUser maps the user to an existing GitLab account, looked up by username or email.
+// GitLab accounts are provisioned by the identity provider, so none is created here.
+func (c *GitLabClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"user":    user.GetUserName(),
+		"lookup":  c.config.UserLookup,
+	})
+
+	log.Info("looking up user")
+	query := "username=" + url.QueryEscape(user.GetUserName())
+	if c.config.UserLookup == LookupByEmail {
+		if user.GetEmail() == "" {
+			return nil, fmt.Errorf("email is required to look up the gitlab user %s", user.GetUserName())
+		}
+		query = "search=" + url.QueryEscape(user.GetEmail())
+	}
+
+	resp, headers, status, err := c.makeRequest(ctx, "/users?"+query, http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error looking up user")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, headers, "failed to look up user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var users []GitLabUser
+	if err := json.Unmarshal(resp, &users); err != nil {
+		return nil, fmt.Errorf("failed to parse users response: %w", err)
+	}
+	for _, found := range users {
+		// search matches partially, only accept the exact account
+		if strings.EqualFold(found.Username, user.GetUserName()) ||
+			(c.config.UserLookup == LookupByEmail && strings.EqualFold(emailOf(found), user.GetEmail())) {
+			mapped := toUser(found)
+			if mapped.Email == "" {
+				mapped.Email = strings.ToLower(user.GetEmail())
+			}
+			log.WithField("userID", mapped.ID).Info("found gitlab user")
+			return mapped, nil
+		}
+	}
+
+	return nil, clients.NewError(clients.ErrNotFound, "gitlab user not found for %s", user.GetUserName())
+}
+
+// FetchUserDetails fetches the GitLab account by its ID
+func (c *GitLabClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "gitlab",
+		"userID":  userID,
+	})
+	log.Info("fetching user details by ID")
+
+	resp, headers, status, err := c.makeRequest(ctx, "/users/"+userID, http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching user details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, headers, "failed to fetch user details, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var user GitLabUser
+	if err := json.Unmarshal(resp, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	log.Info("found user details")
+	return toUser(user), nil
+}
+
+// DeleteUser isn't supported, the accounts are managed by the identity provider of GitLab
+func (c *GitLabClient) DeleteUser(_ context.Context, _ string) error {
+	return ErrUserDeletionNotSupported
+}
+
+func emailOf(user GitLabUser) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	return user.PublicEmail
+}
+
+func toUser(user GitLabUser) *structs.User {
+	return &structs.User{
+		ID:          strconv.FormatInt(user.ID, 10),
+		UserName:    user.Username,
+		Email:       strings.ToLower(emailOf(user)),
+		DisplayName: user.Name,
+	}
+}
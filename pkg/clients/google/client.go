@@ -0,0 +1,344 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+const (
+	// DefaultBaseURL is the Admin SDK endpoint
+	DefaultBaseURL = "https://admin.googleapis.com"
+	// DefaultTokenURI is the Google OAuth 2.0 token endpoint
+	DefaultTokenURI = "https://oauth2.googleapis.com/token"
+	// directoryPath is the path of the Directory API under the base URL
+	directoryPath = "/admin/directory/v1"
+	// scopes are the scopes delegated to the service account in the Admin console
+	scopes = "https://www.googleapis.com/auth/admin.directory.group " +
+		"https://www.googleapis.com/auth/admin.directory.user.readonly"
+	// assertionLifetime is the lifetime of the signed assertion, the longest Google accepts
+	assertionLifetime = time.Hour
+	// tokenExpiryLeeway is how long before its expiry the access token is refreshed
+	tokenExpiryLeeway = 5 * time.Minute
+)
+
+// memberRoles are the supported member roles
+var memberRoles = map[string]bool{RoleMember: true, RoleManager: true, RoleOwner: true}
+
+// quotaReasons are the reasons of the errors of the calls over the quotas of the Directory API
+var quotaReasons = map[string]bool{"rateLimitExceeded": true, "userRateLimitExceeded": true, "quotaExceeded": true}
+
+// NewClient creates a new Google client with the given configuration
+func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionPoolConfig,
+	hystrixCfg httpclient.HystrixResiliencyConfig) (*GoogleClient, error) {
+
+	// Extract connection parameters
+	credentials, _ := connection["credentials"].(string)
+	adminEmail, _ := connection["admin_email"].(string)
+	domain, _ := connection["domain"].(string)
+	if credentials == "" || adminEmail == "" || domain == "" {
+		return nil, errors.New("missing required connection parameters for google backend: " +
+			"credentials, admin_email and domain are required")
+	}
+
+	config := GoogleConfig{
+		AdminEmail: adminEmail,
+		Domain:     strings.ToLower(domain),
+		BaseURL:    DefaultBaseURL,
+		MemberRole: RoleMember,
+	}
+	if err := config.loadServiceAccountKey(credentials); err != nil {
+		return nil, err
+	}
+	if baseURL, _ := connection["base_url"].(string); baseURL != "" {
+		config.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+	if role, _ := connection["member_role"].(string); role != "" {
+		role = strings.ToUpper(role)
+		if !memberRoles[role] {
+			return nil, fmt.Errorf("invalid member_role %q for google backend: expected MEMBER, MANAGER or OWNER", role)
+		}
+		config.MemberRole = role
+	}
+	allowExternalMembers, err := clients.ParseBoolConnection(connection, "allow_external_members")
+	if err != nil {
+		return nil, fmt.Errorf("%w for google backend", err)
+	}
+	config.AllowExternalMembers = allowExternalMembers
+
+	client, err := httpclient.InitializeClient(
+		"google",
+		poolCfg,
+		hystrixCfg,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &GoogleClient{
+		config: &config,
+		client: client,
+	}, nil
+}
+
+// loadServiceAccountKey parses the JSON key of the service account
+func (c *GoogleConfig) loadServiceAccountKey(credentials string) error {
+	var key ServiceAccountKey
+	if err := json.Unmarshal([]byte(credentials), &key); err != nil {
+		return fmt.Errorf("invalid credentials for google backend, expected a service account JSON key: %w", err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return errors.New("invalid credentials for google backend: client_email and private_key are required")
+	}
+
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return errors.New("invalid credentials for google backend: private_key isn't PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return fmt.Errorf("invalid credentials for google backend: %w", err)
+		}
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return errors.New("invalid credentials for google backend: private_key isn't an RSA key")
+	}
+
+	c.ClientEmail = key.ClientEmail
+	c.PrivateKey = privateKey
+	c.TokenURI = DefaultTokenURI
+	if key.TokenURI != "" {
+		c.TokenURI = key.TokenURI
+	}
+	return nil
+}
+
+// signedAssertion returns the JWT, signed with the key of the service account,
+// that requests a token impersonating the administrator
+func (c *GoogleClient) signedAssertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   c.config.ClientEmail,
+		"sub":   c.config.AdminEmail,
+		"scope": scopes,
+		"aud":   c.config.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(assertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(nil, c.config.PrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign assertion: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// accessToken returns the access token of the impersonated administrator,
+// exchanged for a signed assertion when missing or about to expire
+func (c *GoogleClient) accessToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	assertion, err := c.signedAssertion(time.Now())
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := request.NewRequest(ctx, http.MethodPost, c.config.TokenURI, []byte(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetHeaders(map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+	})
+
+	resp, headers, status, err := req.MakeRequestWithHeader(c.client, http.MethodPost, "google")
+	if err != nil {
+		return "", clients.Transient(fmt.Errorf("failed to fetch access token: %w", err))
+	}
+	if status != http.StatusOK {
+		err := fmt.Errorf("failed to fetch access token, status: %s, body: %s", http.StatusText(status), string(resp))
+		// a bad request tells the key was revoked or the domain-wide delegation isn't granted
+		var oauthErr OAuthError
+		if status == http.StatusBadRequest && json.Unmarshal(resp, &oauthErr) == nil &&
+			(oauthErr.Error == "invalid_grant" || oauthErr.Error == "unauthorized_client") {
+			return "", &clients.Error{Kind: clients.ErrUnauthorized, Err: err}
+		}
+		return "", clients.WrapStatus(status, headers, err)
+	}
+
+	var token TokenResponse
+	if err := json.Unmarshal(resp, &token); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("token response has no access token")
+	}
+
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryLeeway)
+	return c.token, nil
+}
+
+// makeRequest sends a request to the Directory API, endpoint is relative to its root
+func (c *GoogleClient) makeRequest(ctx context.Context, endpoint,
+	method string, body interface{}) ([]byte, http.Header, int, error) {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	req, err := request.NewRequest(ctx, method, c.config.BaseURL+directoryPath+endpoint, requestBody)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	req.SetHeaders(map[string]string{
+		"Authorization": "Bearer " + token,
+		"Content-Type":  "application/json",
+	})
+
+	resp, headers, status, err := req.MakeRequestWithHeader(c.client, method, "google")
+	if err != nil {
+		return nil, nil, status, clients.Transient(err)
+	}
+	return resp, headers, status, nil
+}
+
+// statusError returns the error of a response with an unexpected status. The Directory API answers
+// a 403 rather than a 429 to the calls over its quotas, telling it with the reason of the error.
+func statusError(status int, header http.Header, resp []byte, format string, args ...interface{}) error {
+	err := fmt.Errorf(format+", status: %s, body: %s", append(args, http.StatusText(status), string(resp))...)
+
+	var body DirectoryError
+	if status == http.StatusForbidden && json.Unmarshal(resp, &body) == nil {
+		for _, detail := range body.Error.Errors {
+			if quotaReasons[detail.Reason] {
+				// the quotas are per minute, the wait isn't told
+				return &clients.RateLimitError{Err: err}
+			}
+		}
+	}
+	return clients.WrapStatus(status, header, err)
+}
+
+// fetchAllWithPagination decodes the items of every page of a list endpoint, following the nextPageToken.
+// field is the name of the items in the pages, e.g. users or members
+func fetchAllWithPagination[T any](ctx context.Context, c *GoogleClient, endpoint, field string) ([]T, error) {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	var items []T
+	pageToken := ""
+	for {
+		pageURL := endpoint
+		if pageToken != "" {
+			pageURL += separator + "pageToken=" + url.QueryEscape(pageToken)
+		}
+		resp, headers, status, err := c.makeRequest(ctx, pageURL, http.MethodGet, nil)
+		if err != nil {
+			return nil, err
+		}
+		if status != http.StatusOK {
+			return nil, statusError(status, headers, resp, "failed to fetch data from %s", endpoint)
+		}
+
+		var page map[string]json.RawMessage
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse list response: %w", err)
+		}
+		if raw, ok := page[field]; ok {
+			var pageItems []T
+			if err := json.Unmarshal(raw, &pageItems); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", field, err)
+			}
+			items = append(items, pageItems...)
+		}
+
+		pageToken = ""
+		if raw, ok := page["nextPageToken"]; ok {
+			_ = json.Unmarshal(raw, &pageToken)
+		}
+		if pageToken == "" {
+			return items, nil
+		}
+	}
+}
+
+// GetConfig returns the client configuration
+func (c *GoogleClient) GetConfig() *GoogleConfig {
+	return c.config
+}
+
+// Capabilities returns the operations supported by the backend, the Workspace accounts are never
+// created and the members are given the configured role
+func (c *GoogleClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserListing:     true,
+		TeamListing:     true,
+		MembershipRoles: true,
+	}
+}
@@ -0,0 +1,362 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/clienttest"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// directory stands for the token endpoint, exchanging the assertions signed with the key of the
+// service account, and routes the Directory API calls with mux
+type directory struct {
+	mux    *http.ServeMux
+	key    *rsa.PrivateKey
+	issued int
+	// delegated tells whether the domain-wide delegation is granted to the service account
+	delegated bool
+}
+
+func newDirectoryClient(t *testing.T, connection map[string]interface{}) (*directory, *GoogleClient) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	fake := &directory{mux: http.NewServeMux(), key: key, delegated: true}
+
+	server := clienttest.NewServer(t, fake.mux, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == "/token" {
+			fake.serveToken(t, w, r)
+			return false
+		}
+		assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+		return true
+	})
+
+	// the keys created in the console are PKCS #8 encoded
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	credentials, err := json.Marshal(ServiceAccountKey{
+		Type:        "service_account",
+		ClientEmail: "usernaut@project.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		TokenURI:    server.URL + "/token",
+	})
+	require.NoError(t, err)
+
+	connection["credentials"] = string(credentials)
+	connection["admin_email"] = "admin@example.com"
+	connection["domain"] = "Example.com"
+	connection["base_url"] = server.URL
+	client, err := NewClient(connection, clienttest.PoolConfig, clienttest.HystrixConfig)
+	require.NoError(t, err)
+	return fake, client
+}
+
+func (f *directory) serveToken(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	require.NoError(t, r.ParseForm())
+	assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+
+	parts := strings.Split(r.PostForm.Get("assertion"), ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(&f.key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "usernaut@project.iam.gserviceaccount.com", claims["iss"])
+	assert.Equal(t, "admin@example.com", claims["sub"])
+	assert.Equal(t, "http://"+r.Host+"/token", claims["aud"])
+	assert.Equal(t, scopes, claims["scope"])
+	// Google rejects the assertions valid for more than an hour
+	assert.Equal(t, float64(3600), claims["exp"].(float64)-claims["iat"].(float64))
+
+	if !f.delegated {
+		clienttest.Reply(w, http.StatusBadRequest, OAuthError{
+			Error:            "unauthorized_client",
+			ErrorDescription: "Client is unauthorized to retrieve access tokens using this method",
+		})
+		return
+	}
+	f.issued++
+	clienttest.Reply(w, http.StatusOK, TokenResponse{AccessToken: "access-token", ExpiresIn: 3600})
+}
+
+// directoryError returns the body of a Directory API error of the given reason
+func directoryError(code int, reason, message string) DirectoryError {
+	var body DirectoryError
+	body.Error.Code, body.Error.Message = code, message
+	body.Error.Errors = append(body.Error.Errors, struct {
+		Reason string `json:"reason"`
+		Domain string `json:"domain"`
+	}{Reason: reason, Domain: "global"})
+	return body
+}
+
+func TestNewClient(t *testing.T) {
+	_, client := newDirectoryClient(t, map[string]interface{}{"member_role": "manager"})
+	assert.Equal(t, "example.com", client.GetConfig().Domain)
+	assert.Equal(t, RoleManager, client.GetConfig().MemberRole)
+	assert.False(t, client.GetConfig().AllowExternalMembers)
+
+	pool, hystrix := clienttest.PoolConfig, clienttest.HystrixConfig
+	_, err := NewClient(map[string]interface{}{"credentials": "{}", "admin_email": "admin@example.com"},
+		pool, hystrix)
+	assert.Error(t, err)
+
+	connection := map[string]interface{}{"admin_email": "admin@example.com", "domain": "example.com"}
+	connection["credentials"] = "not json"
+	_, err = NewClient(connection, pool, hystrix)
+	assert.ErrorContains(t, err, "service account JSON key")
+
+	connection["credentials"] = `{"client_email": "usernaut@project.iam.gserviceaccount.com", "private_key": "key"}`
+	_, err = NewClient(connection, pool, hystrix)
+	assert.ErrorContains(t, err, "isn't PEM encoded")
+
+	// the older keys are PKCS #1 encoded
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	credentials, err := json.Marshal(ServiceAccountKey{
+		ClientEmail: "usernaut@project.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+	})
+	require.NoError(t, err)
+	connection["credentials"] = string(credentials)
+	connection["member_role"] = "admin"
+	_, err = NewClient(connection, pool, hystrix)
+	assert.ErrorContains(t, err, "invalid member_role")
+
+	delete(connection, "member_role")
+	connection["allow_external_members"] = "maybe"
+	_, err = NewClient(connection, pool, hystrix)
+	assert.ErrorContains(t, err, "invalid allow_external_members")
+
+	// the Backend CRs set the flags as strings
+	connection["allow_external_members"] = "true"
+	client, err = NewClient(connection, pool, hystrix)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTokenURI, client.GetConfig().TokenURI)
+	assert.True(t, client.GetConfig().AllowExternalMembers)
+}
+
+func TestDelegationNotGranted(t *testing.T) {
+	fake, client := newDirectoryClient(t, map[string]interface{}{})
+	fake.delegated = false
+
+	_, err := client.FetchAllTeams(context.Background())
+	assert.ErrorIs(t, err, clients.ErrUnauthorized)
+	assert.ErrorContains(t, err, "unauthorized_client")
+}
+
+func TestFetchAllUsersFollowsPageToken(t *testing.T) {
+	fake, client := newDirectoryClient(t, map[string]interface{}{})
+	var pageTokens []string
+	fake.mux.HandleFunc("GET "+directoryPath+"/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "example.com", r.URL.Query().Get("domain"))
+		assert.Equal(t, "500", r.URL.Query().Get("maxResults"))
+		pageTokens = append(pageTokens, r.URL.Query().Get("pageToken"))
+		if r.URL.Query().Get("pageToken") == "" {
+			jdoe := DirectoryUser{ID: "1", PrimaryEmail: "JDoe@example.com"}
+			jdoe.Name.GivenName, jdoe.Name.FamilyName, jdoe.Name.FullName = "John", "Doe", "John Doe"
+			clienttest.Reply(w, http.StatusOK, map[string]interface{}{
+				"users":         []DirectoryUser{jdoe},
+				"nextPageToken": "Q+1/2=",
+			})
+			return
+		}
+		// the last page has no token
+		clienttest.Reply(w, http.StatusOK, map[string]interface{}{
+			"users": []DirectoryUser{{ID: "2", PrimaryEmail: "asmith@example.com"}},
+		})
+	})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "Q+1/2="}, pageTokens)
+	// the users are identified by their email, as the members of the groups
+	assert.Equal(t, byID, byEmail)
+	assert.Equal(t, &structs.User{
+		ID: "jdoe@example.com", UserName: "jdoe@example.com", Email: "jdoe@example.com",
+		FirstName: "John", LastName: "Doe", DisplayName: "John Doe",
+	}, byID["jdoe@example.com"])
+	assert.Contains(t, byID, "asmith@example.com")
+
+	// the token is reused across the pages
+	assert.Equal(t, 1, fake.issued)
+}
+
+func TestCreateUser(t *testing.T) {
+	for _, external := range []bool{false, true} {
+		fake, client := newDirectoryClient(t, map[string]interface{}{"allow_external_members": external})
+		fake.mux.HandleFunc("GET "+directoryPath+"/users/{user}", func(w http.ResponseWriter, r *http.Request) {
+			if r.PathValue("user") != "jdoe@example.com" {
+				clienttest.Reply(w, http.StatusNotFound,
+					directoryError(http.StatusNotFound, "notFound", "Resource Not Found: userKey"))
+				return
+			}
+			clienttest.Reply(w, http.StatusOK, DirectoryUser{ID: "1", PrimaryEmail: "jdoe@example.com"})
+		})
+		ctx := context.Background()
+
+		user, err := client.CreateUser(ctx, &structs.User{Email: "JDoe@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe@example.com", user.ID)
+
+		user, err = client.CreateUser(ctx, &structs.User{Email: "Partner@other.com", FirstName: "Pat"})
+		if external {
+			require.NoError(t, err)
+			assert.Equal(t, &structs.User{
+				ID: "partner@other.com", UserName: "partner@other.com", Email: "partner@other.com", FirstName: "Pat",
+			}, user)
+		} else {
+			assert.ErrorIs(t, err, ErrUserNotFound)
+			assert.ErrorIs(t, err, clients.ErrNotFound)
+		}
+
+		// the users without email can't be members, even external ones
+		_, err = client.CreateUser(ctx, &structs.User{UserName: "svc-bot"})
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.ErrorIs(t, client.DeleteUser(ctx, "jdoe@example.com"), ErrUserDeletionNotSupported)
+	}
+}
+
+func TestCreateTeamAdoptsExisting(t *testing.T) {
+	fake, client := newDirectoryClient(t, map[string]interface{}{})
+	fake.mux.HandleFunc("POST "+directoryPath+"/groups", func(w http.ResponseWriter, r *http.Request) {
+		var group DirectoryGroup
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&group))
+		// the email of the group is the lower cased name at the domain
+		assert.Equal(t, "data-platform@example.com", group.Email)
+		clienttest.Reply(w, http.StatusConflict,
+			directoryError(http.StatusConflict, "duplicate", "Entity already exists."))
+	})
+	fake.mux.HandleFunc("GET "+directoryPath+"/groups/{group}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "data-platform@example.com", r.PathValue("group"))
+		clienttest.Reply(w, http.StatusOK,
+			DirectoryGroup{ID: "03x", Email: "data-platform@example.com", Name: "Data-Platform"})
+	})
+
+	team, err := client.CreateTeam(context.Background(), &structs.Team{Name: "Data-Platform"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "03x", Name: "Data-Platform"}, team)
+}
+
+func TestMembers(t *testing.T) {
+	fake, client := newDirectoryClient(t, map[string]interface{}{"member_role": "manager"})
+	roles := map[string]string{"asmith@example.com": RoleOwner}
+	members := directoryPath + "/groups/g1/members"
+	fake.mux.HandleFunc("GET "+members, func(w http.ResponseWriter, r *http.Request) {
+		list := []DirectoryMember{{ID: "C01", Role: RoleMember, Type: "CUSTOMER"}}
+		for email, role := range roles {
+			list = append(list, DirectoryMember{Email: strings.ToUpper(email), Role: role, Type: "USER"})
+		}
+		clienttest.Reply(w, http.StatusOK, map[string]interface{}{"members": list})
+	})
+	fake.mux.HandleFunc("POST "+members, func(w http.ResponseWriter, r *http.Request) {
+		var member DirectoryMember
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&member))
+		if _, exists := roles[member.Email]; exists {
+			clienttest.Reply(w, http.StatusConflict,
+				directoryError(http.StatusConflict, "duplicate", "Member already exists."))
+			return
+		}
+		roles[member.Email] = member.Role
+		clienttest.Reply(w, http.StatusOK, member)
+	})
+	fake.mux.HandleFunc("PATCH "+members+"/{member}", func(w http.ResponseWriter, r *http.Request) {
+		var member DirectoryMember
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&member))
+		roles[r.PathValue("member")] = member.Role
+		clienttest.Reply(w, http.StatusOK, member)
+	})
+	fake.mux.HandleFunc("DELETE "+members+"/{member}", func(w http.ResponseWriter, r *http.Request) {
+		if _, exists := roles[r.PathValue("member")]; !exists {
+			clienttest.Reply(w, http.StatusNotFound,
+				directoryError(http.StatusNotFound, "notFound", "Resource Not Found: memberKey"))
+			return
+		}
+		delete(roles, r.PathValue("member"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ctx := context.Background()
+
+	// the existing members are given the configured role
+	require.NoError(t, client.AddUserToTeam(ctx, "g1", []string{"jdoe@example.com", "asmith@example.com"}))
+	assert.Equal(t, map[string]string{"jdoe@example.com": RoleManager, "asmith@example.com": RoleManager}, roles)
+
+	// the whole customer isn't a user member
+	users, err := client.FetchTeamMembersByTeamID(ctx, "g1")
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, RoleManager, users["jdoe@example.com"].Role)
+
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "g1", []string{"jdoe@example.com", "gone@example.com"}))
+	assert.Equal(t, map[string]string{"asmith@example.com": RoleManager}, roles)
+}
+
+func TestStatusErrors(t *testing.T) {
+	// the reasons of the Directory API errors by status
+	reasons := map[int]string{
+		http.StatusNotFound:            "notFound",
+		http.StatusUnauthorized:        "authError",
+		http.StatusForbidden:           "forbidden",
+		http.StatusTooManyRequests:     "rateLimitExceeded",
+		http.StatusInternalServerError: "backendError",
+		http.StatusServiceUnavailable:  "backendError",
+	}
+	fake, client := newDirectoryClient(t, map[string]interface{}{})
+	clienttest.StatusTest{
+		Route: "GET " + directoryPath + "/groups/g1/members",
+		Call: func(ctx context.Context) error {
+			_, err := client.FetchTeamMembersByTeamID(ctx, "g1")
+			return err
+		},
+		ErrorBody: func(status int) interface{} {
+			return directoryError(status, reasons[status], http.StatusText(status))
+		},
+		Cases: []clienttest.StatusCase{{
+			// the calls over the quotas are forbidden rather than throttled
+			Name:   "quota exceeded",
+			Status: http.StatusForbidden,
+			Body: directoryError(http.StatusForbidden, "userRateLimitExceeded",
+				"Quota exceeded for quota metric 'Queries' and limit 'Queries per minute per user'"),
+			Kind: clients.ErrRateLimited,
+		}},
+		DeleteRoute: "DELETE " + directoryPath + "/groups/g2",
+		Delete: func(ctx context.Context) error {
+			return client.DeleteTeamByID(ctx, "g2")
+		},
+	}.Run(t, fake.mux)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamMembersByTeamID fetches the members of the group, keyed by email
+func (c *GoogleClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "google",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	endpoint := "/groups/" + url.PathEscape(teamID) + "/members?maxResults=200"
+	members, err := fetchAllWithPagination[DirectoryMember](ctx, c, endpoint, "members")
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, err
+	}
+
+	users := make(map[string]*structs.User, len(members))
+	for _, member := range members {
+		// members without email are whole customers, e.g. everyone in the domain
+		if member.Email == "" {
+			continue
+		}
+		email := strings.ToLower(member.Email)
+		users[email] = &structs.User{
+			ID:       email,
+			UserName: email,
+			Email:    email,
+			Role:     member.Role,
+		}
+	}
+	return users, nil
+}
+
+// AddUserToTeam adds the users to the group with the configured role,
+// updating the role of the users already members with another one
+func (c *GoogleClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "google",
+		"teamID":     teamID,
+		"role":       c.config.MemberRole,
+		"user_count": len(userIDs),
+	})
+	log.Info("adding users to team")
+
+	var errs []error
+	for _, userID := range userIDs {
+		member := DirectoryMember{Email: userID, Role: c.config.MemberRole}
+		resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID)+"/members",
+			http.MethodPost, member)
+		if err == nil && status == http.StatusConflict {
+			resp, headers, status, err = c.makeRequest(ctx, c.memberEndpoint(teamID, userID), http.MethodPatch,
+				DirectoryMember{Role: c.config.MemberRole})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to add user %s: %w", userID, err))
+			continue
+		}
+		if status != http.StatusOK {
+			errs = append(errs, statusError(status, headers, resp, "failed to add user %s", userID))
+		}
+	}
+
+	if len(errs) > 0 {
+		log.WithField("failed_count", len(errs)).Error("error adding users to team")
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the group
+func (c *GoogleClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "google",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("removing users from team")
+
+	var errs []error
+	for _, userID := range userIDs {
+		resp, headers, status, err := c.makeRequest(ctx, c.memberEndpoint(teamID, userID), http.MethodDelete, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove user %s: %w", userID, err))
+			continue
+		}
+		if status != http.StatusNoContent && status != http.StatusOK && status != http.StatusNotFound {
+			errs = append(errs, statusError(status, headers, resp, "failed to remove user %s", userID))
+		}
+	}
+
+	if len(errs) > 0 {
+		log.WithField("failed_count", len(errs)).Error("error removing users from team")
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func (c *GoogleClient) memberEndpoint(teamID, userID string) string {
+	return "/groups/" + url.PathEscape(teamID) + "/members/" + url.PathEscape(userID)
+}
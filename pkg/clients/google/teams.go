@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchAllTeams fetches the groups of the domain, keyed by name
+func (c *GoogleClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("service", "google")
+
+	log.Info("fetching all teams")
+	endpoint := "/groups?maxResults=200&domain=" + url.QueryEscape(c.config.Domain)
+	groups, err := fetchAllWithPagination[DirectoryGroup](ctx, c, endpoint, "groups")
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team, len(groups))
+	for _, group := range groups {
+		teams[group.Name] = toTeam(group)
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates the group, its email is the team name at the domain
+func (c *GoogleClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "google",
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	payload := DirectoryGroup{
+		Email:       c.groupEmail(team.Name),
+		Name:        team.Name,
+		Description: team.Description,
+	}
+
+	resp, headers, status, err := c.makeRequest(ctx, "/groups", http.MethodPost, payload)
+	if err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, err
+	}
+	if status == http.StatusConflict {
+		log.Info("team already exists")
+		return c.FetchTeamDetails(ctx, payload.Email)
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return nil, statusError(status, headers, resp, "failed to create group %s", payload.Email)
+	}
+
+	var group DirectoryGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse create group response: %w", err)
+	}
+
+	log.Info("team created successfully")
+	createdTeam := toTeam(group)
+	return &createdTeam, nil
+}
+
+// FetchTeamDetails fetches the group by ID or email
+func (c *GoogleClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "google",
+		"teamID":  teamID,
+	})
+
+	log.Info("fetching team details")
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, headers, resp, "failed to fetch group %s", teamID)
+	}
+
+	var group DirectoryGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+
+	log.Info("successfully fetched team details")
+	team := toTeam(group)
+	return &team, nil
+}
+
+// DeleteTeamByID deletes the group
+func (c *GoogleClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "google",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	if status != http.StatusNoContent && status != http.StatusOK && status != http.StatusNotFound {
+		return statusError(status, headers, resp, "failed to delete group")
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+// groupEmail returns the email of the group of the team
+func (c *GoogleClient) groupEmail(name string) string {
+	return strings.ToLower(name) + "@" + c.config.Domain
+}
+
+func toTeam(group DirectoryGroup) structs.Team {
+	return structs.Team{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+	}
+}
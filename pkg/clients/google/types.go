@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"crypto/rsa"
+	"sync"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+)
+
+// Member roles of a Google group
+const (
+	RoleMember  = "MEMBER"
+	RoleManager = "MANAGER"
+	RoleOwner   = "OWNER"
+)
+
+// GoogleConfig holds the configuration for Google client
+type GoogleConfig struct {
+	// ClientEmail and PrivateKey are the service account key, TokenURI the endpoint it's exchanged at
+	ClientEmail string
+	PrivateKey  *rsa.PrivateKey
+	TokenURI    string
+	// AdminEmail is the administrator impersonated through domain-wide delegation
+	AdminEmail string
+	// Domain is the domain of the groups, their email is the team name at the domain
+	Domain string
+	// BaseURL is the Admin SDK endpoint
+	BaseURL string
+	// MemberRole is the role the users are given in the groups, MEMBER by default
+	MemberRole string
+	// AllowExternalMembers allows members without an account in the Workspace
+	AllowExternalMembers bool
+}
+
+// GoogleClient is the client for managing Google Groups through the Admin SDK Directory API
+type GoogleClient struct {
+	config *GoogleConfig
+	client heimdall.Doer
+
+	// the access token of the impersonated administrator, refreshed when it expires
+	tokenMu     sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// ServiceAccountKey is the JSON key of a service account
+type ServiceAccountKey struct {
+	Type        string `json:"type"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// TokenResponse is the response of the OAuth 2.0 token endpoint
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// OAuthError is the body of the errors of the OAuth 2.0 token endpoint
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// DirectoryError is the body of the errors of the Directory API
+type DirectoryError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Errors  []struct {
+			Reason string `json:"reason"`
+			Domain string `json:"domain"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+// DirectoryUser represents a Workspace user
+type DirectoryUser struct {
+	ID           string `json:"id"`
+	PrimaryEmail string `json:"primaryEmail"`
+	Suspended    bool   `json:"suspended"`
+	Name         struct {
+		GivenName  string `json:"givenName"`
+		FamilyName string `json:"familyName"`
+		FullName   string `json:"fullName"`
+	} `json:"name"`
+}
+
+// DirectoryGroup represents a Google group
+type DirectoryGroup struct {
+	ID          string `json:"id,omitempty"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// DirectoryMember represents a member of a Google group
+type DirectoryMember struct {
+	ID    string `json:"id,omitempty"`
+	Email string `json:"email"`
+	Role  string `json:"role,omitempty"`
+	Type  string `json:"type,omitempty"`
+}
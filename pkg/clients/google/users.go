@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// The users are identified by their email, as the members of the groups, which may be external to the Workspace.
+
+var (
+	// ErrUserNotFound is returned by CreateUser when the user has no Workspace account and external members
+	// aren't allowed
+	ErrUserNotFound = clients.NewError(clients.ErrNotFound, "no google workspace user found")
+	// ErrUserDeletionNotSupported is returned by DeleteUser, the accounts are owned by the Workspace
+	ErrUserDeletionNotSupported = errors.New("deleting users is not supported by the google backend")
+)
+
+// FetchAllUsers fetches the users of the domain
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email, the IDs being the emails
+func (c *GoogleClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "google")
+
+	log.Info("fetching all users")
+	endpoint := "/users?maxResults=500&domain=" + url.QueryEscape(c.config.Domain)
+	users, err := fetchAllWithPagination[DirectoryUser](ctx, c, endpoint, "users")
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	result := make(map[string]*structs.User, len(users))
+	for _, user := range users {
+		structUser := toUser(user)
+		result[structUser.ID] = structUser
+	}
+
+	log.WithField("total_user_count", len(result)).Info("found users")
+	return result, result, nil
+}
+
+// CreateUser looks up the Workspace account of the user, the accounts are never created.
+// Users without an account are returned as external members when allowed.
+func (c *GoogleClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	email := strings.ToLower(user.GetEmail())
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "google",
+		"email":   email,
+	})
+
+	log.Info("looking up user")
+	if email == "" {
+		return nil, fmt.Errorf("%w: no email for user %s", ErrUserNotFound, user.GetUserName())
+	}
+
+	details, err := c.FetchUserDetails(ctx, email)
+	if err == nil {
+		return details, nil
+	}
+	if !errors.Is(err, ErrUserNotFound) || !c.config.AllowExternalMembers {
+		log.WithError(err).Error("error looking up user")
+		return nil, err
+	}
+
+	log.Info("user has no workspace account, adding it as external member")
+	return &structs.User{
+		ID:          email,
+		UserName:    email,
+		Email:       email,
+		FirstName:   user.GetFirstName(),
+		LastName:    user.GetLastName(),
+		DisplayName: user.GetDisplayName(),
+	}, nil
+}
+
+// FetchUserDetails fetches the Workspace user by email
+func (c *GoogleClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	resp, headers, status, err := c.makeRequest(ctx, "/users/"+url.PathEscape(userID), http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, headers, resp, "failed to fetch user details")
+	}
+
+	var user DirectoryUser
+	if err := json.Unmarshal(resp, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+	return toUser(user), nil
+}
+
+// DeleteUser isn't supported, the accounts are owned by the Workspace
+func (c *GoogleClient) DeleteUser(_ context.Context, _ string) error {
+	return ErrUserDeletionNotSupported
+}
+
+func toUser(user DirectoryUser) *structs.User {
+	email := strings.ToLower(user.PrimaryEmail)
+	return &structs.User{
+		ID:          email,
+		UserName:    email,
+		Email:       email,
+		FirstName:   user.Name.GivenName,
+		LastName:    user.Name.FamilyName,
+		DisplayName: user.Name.FullName,
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+// pageSize is the number of items requested per page of the search endpoints
+const pageSize = 100
+
+// NewClient creates a new Grafana client with the given configuration
+func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionPoolConfig,
+	hystrixCfg httpclient.HystrixResiliencyConfig) (*GrafanaClient, error) {
+
+	// Extract connection parameters
+	baseURL, _ := connection["base_url"].(string)
+	token, _ := connection["token"].(string)
+	if baseURL == "" || token == "" {
+		return nil, errors.New("missing required connection parameters for grafana backend: " +
+			"base_url and token are required")
+	}
+
+	config := GrafanaConfig{
+		Token:            token,
+		BaseURL:          strings.TrimSuffix(baseURL, "/"),
+		UserLookup:       LookupByLogin,
+		FolderPermission: PermissionView,
+	}
+	if orgID, ok := connection["org_id"]; ok && orgID != nil {
+		config.OrgID = fmt.Sprint(orgID)
+	}
+	if lookup, _ := connection["user_lookup"].(string); lookup != "" {
+		lookup = strings.ToLower(lookup)
+		if lookup != LookupByLogin && lookup != LookupByEmail {
+			return nil, fmt.Errorf("invalid user_lookup %q for grafana backend: expected login or email", lookup)
+		}
+		config.UserLookup = lookup
+	}
+	config.FolderUID, _ = connection["folder_uid"].(string)
+	if permission, _ := connection["folder_permission"].(string); permission != "" {
+		value, ok := folderPermissions[strings.ToLower(permission)]
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid folder_permission %q for grafana backend: expected View, Edit or Admin", permission)
+		}
+		config.FolderPermission = value
+	}
+
+	client, err := httpclient.InitializeClient(
+		"grafana",
+		poolCfg,
+		hystrixCfg,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &GrafanaClient{
+		config: &config,
+		client: client,
+	}, nil
+}
+
+// makeRequest sends a request to the Grafana HTTP API, endpoint is relative to the base URL
+func (c *GrafanaClient) makeRequest(ctx context.Context, endpoint,
+	method string, body interface{}) ([]byte, http.Header, int, error) {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	req, err := request.NewRequest(ctx, method, c.config.BaseURL+endpoint, requestBody)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + c.config.Token,
+		"Content-Type":  "application/json",
+		"Accept":        "application/json",
+	}
+	if c.config.OrgID != "" {
+		headers["X-Grafana-Org-Id"] = c.config.OrgID
+	}
+	req.SetHeaders(headers)
+
+	resp, header, status, err := req.MakeRequestWithHeader(c.client, method, "grafana")
+	if err != nil {
+		return nil, nil, status, clients.Transient(err)
+	}
+	return resp, header, status, nil
+}
+
+// fetchAllWithPagination calls processPage with every page of a search endpoint until a page
+// isn't full. processPage returns the number of items of the page.
+func (c *GrafanaClient) fetchAllWithPagination(ctx context.Context,
+	endpoint string, processPage func([]byte) (int, error)) error {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	for page := 1; ; page++ {
+		pageURL := fmt.Sprintf("%s%sperpage=%d&page=%d", endpoint, separator, pageSize, page)
+		resp, header, status, err := c.makeRequest(ctx, pageURL, http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return clients.StatusError(status, header, "failed to fetch data from %s, status: %s, body: %s",
+				endpoint, http.StatusText(status), string(resp))
+		}
+
+		count, err := processPage(resp)
+		if err != nil {
+			return err
+		}
+		if count < pageSize {
+			return nil
+		}
+	}
+}
+
+// GetConfig returns the client configuration
+func (c *GrafanaClient) GetConfig() *GrafanaConfig {
+	return c.config
+}
+
+// Capabilities returns the operations supported by the backend, the Grafana accounts are provisioned through SSO
+func (c *GrafanaClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserListing: true,
+		TeamListing: true,
+		Rename:      true,
+	}
+}
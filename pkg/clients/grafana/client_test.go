@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/clienttest"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMuxClient returns a client of a Grafana instance routing the API calls with mux,
+// the service account token and the organization being checked first
+func newMuxClient(t *testing.T, mux *http.ServeMux, connection map[string]interface{}) *GrafanaClient {
+	server := clienttest.NewServer(t, mux, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("Authorization") != "Bearer glsa_token" {
+			clienttest.Reply(w, http.StatusUnauthorized, map[string]string{"message": "invalid API key"})
+			return false
+		}
+		assert.Equal(t, "2", r.Header.Get("X-Grafana-Org-Id"))
+		return true
+	})
+
+	connection["base_url"] = server.URL + "/"
+	connection["token"] = "glsa_token"
+	connection["org_id"] = 2
+	client, err := NewClient(connection, clienttest.PoolConfig, clienttest.HystrixConfig)
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	pool, hystrix := clienttest.PoolConfig, clienttest.HystrixConfig
+
+	client, err := NewClient(map[string]interface{}{
+		"base_url": "https://grafana.example.com/", "token": "t", "org_id": 2, "folder_permission": "edit",
+	}, pool, hystrix)
+	require.NoError(t, err)
+	assert.Equal(t, "https://grafana.example.com", client.GetConfig().BaseURL)
+	assert.Equal(t, "2", client.GetConfig().OrgID)
+	assert.Equal(t, PermissionEdit, client.GetConfig().FolderPermission)
+	assert.Equal(t, LookupByLogin, client.GetConfig().UserLookup)
+
+	_, err = NewClient(map[string]interface{}{"base_url": "https://grafana.example.com"}, pool, hystrix)
+	assert.Error(t, err)
+
+	for _, connection := range []map[string]interface{}{
+		{"folder_permission": "owner"},
+		{"user_lookup": "uid"},
+	} {
+		connection["base_url"], connection["token"] = "https://grafana.example.com", "t"
+		_, err = NewClient(connection, pool, hystrix)
+		assert.Error(t, err, connection)
+	}
+}
+
+func TestPaginationFullLastPage(t *testing.T) {
+	mux := http.NewServeMux()
+	var pages []string
+	mux.HandleFunc("GET /api/org/users/search", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, strconv.Itoa(pageSize), r.URL.Query().Get("perpage"))
+		pages = append(pages, r.URL.Query().Get("page"))
+		// the organization has exactly one page of users, only an empty page tells the end
+		users := []OrgUser{}
+		if r.URL.Query().Get("page") == "1" {
+			for i := range pageSize {
+				users = append(users, OrgUser{UserID: int64(i + 1), Login: fmt.Sprintf("user%d", i)})
+			}
+			users[0].Email, users[0].Name = "JDoe@example.com", "John Doe"
+		}
+		clienttest.Reply(w, http.StatusOK, OrgUsersPage{TotalCount: pageSize, OrgUsers: users})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Len(t, byID, pageSize)
+	assert.Equal(t, &structs.User{ID: "1", UserName: "user0", Email: "jdoe@example.com", DisplayName: "John Doe"},
+		byEmail["jdoe@example.com"])
+}
+
+func TestCreateUserExactMatch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/org/users/search", func(w http.ResponseWriter, r *http.Request) {
+		// the search matches the login, email and name partially
+		clienttest.Reply(w, http.StatusOK, OrgUsersPage{OrgUsers: []OrgUser{
+			{UserID: 7, Login: "jdoe2", Email: "jdoe@example.com.au"},
+			{UserID: 3, Login: "JDoe", Email: "jdoe@example.com"},
+		}})
+	})
+	ctx := context.Background()
+
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	user, err := client.CreateUser(ctx, &structs.User{UserName: "jdoe"})
+	require.NoError(t, err)
+	assert.Equal(t, "3", user.ID)
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "jdo"})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+
+	client = newMuxClient(t, mux, map[string]interface{}{"user_lookup": "email"})
+	user, err = client.CreateUser(ctx, &structs.User{UserName: "john", Email: "JDoe@example.com.au"})
+	require.NoError(t, err)
+	assert.Equal(t, "7", user.ID)
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "john"})
+	assert.ErrorContains(t, err, "no email for user john")
+	assert.ErrorIs(t, client.DeleteUser(ctx, "7"), ErrUserDeletionNotSupported)
+}
+
+func TestCreateTeamNameTaken(t *testing.T) {
+	mux := http.NewServeMux()
+	var permissions []string
+	mux.HandleFunc("POST /api/teams", func(w http.ResponseWriter, r *http.Request) {
+		var team GrafanaTeam
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&team))
+		if team.Name == "analysts" {
+			clienttest.Reply(w, http.StatusConflict, map[string]string{"message": "Team name taken"})
+			return
+		}
+		clienttest.Reply(w, http.StatusOK, CreateTeamResponse{TeamID: 12, UID: "abc"})
+	})
+	mux.HandleFunc("GET /api/teams/search", func(w http.ResponseWriter, r *http.Request) {
+		// the name filter is exact, unlike the query one
+		assert.Equal(t, "analysts", r.URL.Query().Get("name"))
+		clienttest.Reply(w, http.StatusOK, TeamsPage{Teams: []GrafanaTeam{{ID: 5, Name: "analysts"}}})
+	})
+	mux.HandleFunc("POST /api/access-control/folders/{folder}/teams/{team}", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if r.PathValue("folder") == "dash-engineers" {
+			// the dashboards of a data product may be added after its team
+			clienttest.Reply(w, http.StatusNotFound, map[string]string{"message": "folder not found"})
+			return
+		}
+		permissions = append(permissions, r.PathValue("folder")+"/"+r.PathValue("team")+"="+body["permission"])
+		clienttest.Reply(w, http.StatusOK, map[string]string{"message": "Permission updated"})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{"folder_uid": "dash-{team}", "folder_permission": "admin"})
+	ctx := context.Background()
+
+	// the existing team is adopted, and granted its folder permission too
+	team, err := client.CreateTeam(ctx, &structs.Team{Name: "analysts"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "5", Name: "analysts"}, team)
+
+	team, err = client.CreateTeam(ctx, &structs.Team{Name: "engineers"})
+	require.NoError(t, err)
+	assert.Equal(t, "12", team.ID)
+	assert.Equal(t, []string{"dash-analysts/5=Admin"}, permissions)
+}
+
+func TestRenameTeam(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/teams/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var team GrafanaTeam
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&team))
+		if team.Name == "analysts" {
+			clienttest.Reply(w, http.StatusConflict, map[string]string{"message": "Team name taken"})
+			return
+		}
+		clienttest.Reply(w, http.StatusOK, map[string]string{"message": "Team updated"})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	renamer, ok := clients.GetTeamRenamer(client)
+	require.True(t, ok)
+	team, err := renamer.RenameTeam(ctx, "12", "engineers")
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "12", Name: "engineers"}, team)
+
+	_, err = renamer.RenameTeam(ctx, "12", "analysts")
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+}
+
+func TestTeamMembers(t *testing.T) {
+	mux := http.NewServeMux()
+	members := map[int64]bool{1: true}
+	mux.HandleFunc("GET /api/teams/5/members", func(w http.ResponseWriter, r *http.Request) {
+		list := []TeamMember{}
+		for id := range members {
+			list = append(list, TeamMember{UserID: id, Login: "user" + strconv.FormatInt(id, 10)})
+		}
+		clienttest.Reply(w, http.StatusOK, list)
+	})
+	mux.HandleFunc("POST /api/teams/5/members", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]int64
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch {
+		case members[body["userId"]]:
+			// the versions before 11 answer a bad request
+			clienttest.Reply(w, http.StatusBadRequest, map[string]string{"message": "User is already added to this team"})
+		case body["userId"] == 9:
+			clienttest.Reply(w, http.StatusNotFound, map[string]string{"message": "user not found"})
+		default:
+			members[body["userId"]] = true
+			clienttest.Reply(w, http.StatusOK, map[string]string{"message": "Member added to Team"})
+		}
+	})
+	mux.HandleFunc("DELETE /api/teams/5/members/{user}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(r.PathValue("user"), 10, 64)
+		if !members[id] {
+			clienttest.Reply(w, http.StatusNotFound, map[string]string{"message": "Team member not found"})
+			return
+		}
+		delete(members, id)
+		clienttest.Reply(w, http.StatusOK, map[string]string{"message": "Team Member removed"})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	err := client.AddUserToTeam(ctx, "5", []string{"1", "2", "jdoe", "9"})
+	assert.ErrorContains(t, err, `invalid grafana user ID "jdoe"`)
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+	assert.Equal(t, map[int64]bool{1: true, 2: true}, members)
+
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "5", []string{"1", "3"}))
+	users, err := client.FetchTeamMembersByTeamID(ctx, "5")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{"2": {ID: "2", UserName: "user2"}}, users)
+}
+
+func TestStatusErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	clienttest.StatusTest{
+		Route: "GET /api/teams/5/members",
+		Call: func(ctx context.Context) error {
+			_, err := client.FetchTeamMembersByTeamID(ctx, "5")
+			return err
+		},
+		ErrorBody: func(status int) interface{} {
+			return map[string]string{"message": http.StatusText(status)}
+		},
+		DeleteRoute: "DELETE /api/teams/6",
+		Delete: func(ctx context.Context) error {
+			return client.DeleteTeamByID(ctx, "6")
+		},
+	}.Run(t, mux)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// teamPlaceholder is replaced by the team name in the configured folder UID
+const teamPlaceholder = "{team}"
+
+// setFolderPermission grants the team the configured permission on its folder, when one is configured.
+// Folders that don't exist yet are skipped, the dashboards of a data product may be added after its team.
+func (c *GrafanaClient) setFolderPermission(ctx context.Context, team *structs.Team) error {
+	if c.config.FolderUID == "" {
+		return nil
+	}
+
+	folderUID := strings.ReplaceAll(c.config.FolderUID, teamPlaceholder, team.Name)
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "grafana",
+		"teamID":     team.ID,
+		"folder":     folderUID,
+		"permission": c.config.FolderPermission,
+	})
+
+	log.Info("setting folder permission of the team")
+	endpoint := "/api/access-control/folders/" + url.PathEscape(folderUID) + "/teams/" + url.PathEscape(team.ID)
+	resp, header, status, err := c.makeRequest(ctx, endpoint, http.MethodPost,
+		map[string]string{"permission": c.config.FolderPermission})
+	if err != nil {
+		return fmt.Errorf("failed to set folder permission: %w", err)
+	}
+	if status == http.StatusNotFound {
+		log.Warn("folder not found, skipping its permission")
+		return nil
+	}
+	if status != http.StatusOK {
+		return clients.StatusError(status, header, "failed to set permission on folder %s, status: %s, body: %s",
+			folderUID, http.StatusText(status), string(resp))
+	}
+	return nil
+}
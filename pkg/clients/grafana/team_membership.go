@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamMembersByTeamID fetches the members of the team, keyed by user ID
+func (c *GrafanaClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "grafana",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	resp, header, status, err := c.makeRequest(ctx, "/api/teams/"+url.PathEscape(teamID)+"/members",
+		http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, header, "failed to fetch team members, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var members []TeamMember
+	if err := json.Unmarshal(resp, &members); err != nil {
+		return nil, fmt.Errorf("failed to parse team members response: %w", err)
+	}
+
+	users := make(map[string]*structs.User, len(members))
+	for _, member := range members {
+		user := toUser(member.UserID, member.Login, member.Email, member.Name)
+		users[user.ID] = user
+	}
+	return users, nil
+}
+
+// AddUserToTeam adds the users to the team
+func (c *GrafanaClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "grafana",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("adding users to team")
+
+	var errs []error
+	for _, userID := range userIDs {
+		id, err := strconv.ParseInt(userID, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid grafana user ID %q: %w", userID, err))
+			continue
+		}
+		resp, header, status, err := c.makeRequest(ctx, "/api/teams/"+url.PathEscape(teamID)+"/members",
+			http.MethodPost, map[string]int64{"userId": id})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to add user %s: %w", userID, err))
+			continue
+		}
+		// users already in the team are rejected with a 400, or a 409 on recent versions
+		if status != http.StatusOK && !strings.Contains(strings.ToLower(string(resp)), "already") {
+			errs = append(errs, clients.StatusError(status, header, "failed to add user %s, status: %s, body: %s",
+				userID, http.StatusText(status), string(resp)))
+		}
+	}
+
+	if len(errs) > 0 {
+		log.WithField("failed_count", len(errs)).Error("error adding users to team")
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the team
+func (c *GrafanaClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "grafana",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("removing users from team")
+
+	var errs []error
+	for _, userID := range userIDs {
+		endpoint := "/api/teams/" + url.PathEscape(teamID) + "/members/" + url.PathEscape(userID)
+		resp, header, status, err := c.makeRequest(ctx, endpoint, http.MethodDelete, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove user %s: %w", userID, err))
+			continue
+		}
+		if status != http.StatusOK && status != http.StatusNotFound {
+			errs = append(errs, clients.StatusError(status, header, "failed to remove user %s, status: %s, body: %s",
+				userID, http.StatusText(status), string(resp)))
+		}
+	}
+
+	if len(errs) > 0 {
+		log.WithField("failed_count", len(errs)).Error("error removing users from team")
+		return errors.Join(errs...)
+	}
+	return nil
+}
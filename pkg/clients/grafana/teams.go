@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchAllTeams fetches the teams of the organization, keyed by name
+func (c *GrafanaClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("service", "grafana")
+
+	log.Info("fetching all teams")
+	teams := make(map[string]structs.Team)
+	err := c.fetchAllWithPagination(ctx, "/api/teams/search", func(resp []byte) (int, error) {
+		var page TeamsPage
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return 0, fmt.Errorf("failed to parse teams response: %w", err)
+		}
+		for _, team := range page.Teams {
+			teams[team.Name] = toTeam(team)
+		}
+		return len(page.Teams), nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, err
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates the team and grants it the configured folder permission
+func (c *GrafanaClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "grafana",
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	resp, header, status, err := c.makeRequest(ctx, "/api/teams", http.MethodPost, GrafanaTeam{Name: team.Name})
+	if err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, err
+	}
+
+	var createdTeam *structs.Team
+	switch status {
+	case http.StatusOK:
+		var created CreateTeamResponse
+		if err := json.Unmarshal(resp, &created); err != nil {
+			return nil, fmt.Errorf("failed to parse create team response: %w", err)
+		}
+		createdTeam = &structs.Team{
+			ID:          strconv.FormatInt(created.TeamID, 10),
+			Name:        team.Name,
+			Description: team.Description,
+		}
+	case http.StatusConflict:
+		log.Info("team already exists")
+		if createdTeam, err = c.fetchTeamByName(ctx, team.Name); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, clients.StatusError(status, header, "failed to create team, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	if err := c.setFolderPermission(ctx, createdTeam); err != nil {
+		log.WithError(err).Error("error setting the folder permission of the team")
+		return nil, err
+	}
+
+	log.Info("team created successfully")
+	return createdTeam, nil
+}
+
+// fetchTeamByName fetches the team with the given name, the name filter of the search being exact
+func (c *GrafanaClient) fetchTeamByName(ctx context.Context, name string) (*structs.Team, error) {
+	resp, header, status, err := c.makeRequest(ctx, "/api/teams/search?name="+url.QueryEscape(name),
+		http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, header, "failed to search team %s, status: %s, body: %s",
+			name, http.StatusText(status), string(resp))
+	}
+
+	var page TeamsPage
+	if err := json.Unmarshal(resp, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse teams response: %w", err)
+	}
+	for _, team := range page.Teams {
+		if team.Name == name {
+			found := toTeam(team)
+			return &found, nil
+		}
+	}
+	return nil, clients.NewError(clients.ErrNotFound, "team %s not found", name)
+}
+
+// FetchTeamDetails fetches the team by its ID
+func (c *GrafanaClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "grafana",
+		"teamID":  teamID,
+	})
+
+	log.Info("fetching team details")
+	resp, header, status, err := c.makeRequest(ctx, "/api/teams/"+url.PathEscape(teamID), http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, header, "failed to fetch team %s, status: %s, body: %s",
+			teamID, http.StatusText(status), string(resp))
+	}
+
+	var team GrafanaTeam
+	if err := json.Unmarshal(resp, &team); err != nil {
+		return nil, fmt.Errorf("failed to parse team response: %w", err)
+	}
+
+	log.Info("successfully fetched team details")
+	details := toTeam(team)
+	return &details, nil
+}
+
+// RenameTeam renames the team, keeping its members and folder permissions
+func (c *GrafanaClient) RenameTeam(ctx context.Context, teamID, name string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "grafana",
+		"teamID":  teamID,
+		"team":    name,
+	})
+
+	log.Info("renaming team")
+	resp, header, status, err := c.makeRequest(ctx, "/api/teams/"+url.PathEscape(teamID), http.MethodPut,
+		GrafanaTeam{Name: name})
+	if err != nil {
+		log.WithError(err).Error("error renaming team")
+		return nil, err
+	}
+	// Grafana answers a conflict when another team has the name
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, header, "failed to rename team %s, status: %s, body: %s",
+			teamID, http.StatusText(status), string(resp))
+	}
+
+	log.Info("team renamed successfully")
+	return &structs.Team{ID: teamID, Name: name}, nil
+}
+
+// DeleteTeamByID deletes the team, its folder permissions are removed along with it
+func (c *GrafanaClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "grafana",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	resp, header, status, err := c.makeRequest(ctx, "/api/teams/"+url.PathEscape(teamID), http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	if status != http.StatusOK && status != http.StatusNotFound {
+		return clients.StatusError(status, header, "failed to delete team, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+func toTeam(team GrafanaTeam) structs.Team {
+	return structs.Team{
+		ID:   strconv.FormatInt(team.ID, 10),
+		Name: team.Name,
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import "github.com/gojek/heimdall/v7"
+
+// Ways of looking up the Grafana account of a user
+const (
+	LookupByLogin = "login"
+	LookupByEmail = "email"
+)
+
+// Permissions the teams can be granted on their folder
+const (
+	PermissionView  = "View"
+	PermissionEdit  = "Edit"
+	PermissionAdmin = "Admin"
+)
+
+// folderPermissions maps the permission names accepted in the configuration to their values
+var folderPermissions = map[string]string{
+	"view":  PermissionView,
+	"edit":  PermissionEdit,
+	"admin": PermissionAdmin,
+}
+
+// GrafanaConfig holds the configuration for Grafana client
+type GrafanaConfig struct {
+	// Token is the token of a service account with the Admin role in the organization
+	Token   string
+	BaseURL string
+	// OrgID, when set, selects the organization of the requests
+	OrgID string
+	// UserLookup is how users are matched to Grafana accounts, by login or by email
+	UserLookup string
+	// FolderUID, when set, is the folder the teams are granted FolderPermission on.
+	// Occurrences of {team} are replaced by the team name, giving each team its own folder.
+	FolderUID        string
+	FolderPermission string
+}
+
+// GrafanaClient is the client for interacting with Grafana HTTP API
+type GrafanaClient struct {
+	config *GrafanaConfig
+	client heimdall.Doer
+}
+
+// OrgUser represents a user of the organization from Grafana API response
+type OrgUser struct {
+	UserID int64  `json:"userId"`
+	Login  string `json:"login"`
+	Email  string `json:"email"`
+	Name   string `json:"name,omitempty"`
+	Role   string `json:"role,omitempty"`
+}
+
+// OrgUsersPage is a page of the organization users search
+type OrgUsersPage struct {
+	TotalCount int       `json:"totalCount"`
+	OrgUsers   []OrgUser `json:"orgUsers"`
+}
+
+// GrafanaUser represents a user from Grafana API response
+type GrafanaUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// GrafanaTeam represents a team from Grafana API response
+type GrafanaTeam struct {
+	ID          int64  `json:"id"`
+	UID         string `json:"uid,omitempty"`
+	Name        string `json:"name"`
+	Email       string `json:"email,omitempty"`
+	MemberCount int    `json:"memberCount,omitempty"`
+}
+
+// TeamsPage is a page of the teams search
+type TeamsPage struct {
+	TotalCount int           `json:"totalCount"`
+	Teams      []GrafanaTeam `json:"teams"`
+}
+
+// CreateTeamResponse is the response of the team creation
+type CreateTeamResponse struct {
+	TeamID int64  `json:"teamId"`
+	UID    string `json:"uid,omitempty"`
+}
+
+// TeamMember represents a team member from Grafana API response
+type TeamMember struct {
+	UserID int64  `json:"userId"`
+	Login  string `json:"login"`
+	Email  string `json:"email"`
+	Name   string `json:"name,omitempty"`
+}
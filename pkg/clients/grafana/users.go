@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrUserNotFound is returned by CreateUser when the user has no Grafana account
+	ErrUserNotFound = clients.NewError(clients.ErrNotFound, "no grafana user found")
+	// ErrUserDeletionNotSupported is returned by DeleteUser, Grafana accounts are provisioned on login
+	ErrUserDeletionNotSupported = errors.New("deleting users is not supported by the grafana backend")
+)
+
+// FetchAllUsers fetches the users of the organization
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
+func (c *GrafanaClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "grafana")
+
+	log.Info("fetching all users")
+	resultByID := make(map[string]*structs.User)
+	resultByEmail := make(map[string]*structs.User)
+
+	err := c.fetchAllWithPagination(ctx, "/api/org/users/search", func(resp []byte) (int, error) {
+		var page OrgUsersPage
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return 0, fmt.Errorf("failed to parse users response: %w", err)
+		}
+		for _, user := range page.OrgUsers {
+			structUser := toUser(user.UserID, user.Login, user.Email, user.Name)
+			resultByID[structUser.ID] = structUser
+			if structUser.Email != "" {
+				resultByEmail[structUser.Email] = structUser
+			}
+		}
+		return len(page.OrgUsers), nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, resultByEmail, nil
+}
+
+// CreateUser maps the user to an existing account of the organization, looked up by login or email.
+// Grafana accounts are provisioned on the first login through SSO, so none is created here.
+func (c *GrafanaClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "grafana",
+		"user":    user.GetUserName(),
+		"lookup":  c.config.UserLookup,
+	})
+
+	log.Info("looking up user")
+	value := user.GetUserName()
+	if c.config.UserLookup == LookupByEmail {
+		value = user.GetEmail()
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%w: no %s for user %s", ErrUserNotFound, c.config.UserLookup, user.GetUserName())
+	}
+
+	endpoint := fmt.Sprintf("/api/org/users/search?query=%s&perpage=%d", url.QueryEscape(value), pageSize)
+	resp, header, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error looking up user")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, header, "failed to look up user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var page OrgUsersPage
+	if err := json.Unmarshal(resp, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse users response: %w", err)
+	}
+	for _, found := range page.OrgUsers {
+		// search matches the login, email and name partially, only accept the exact account
+		match := found.Login
+		if c.config.UserLookup == LookupByEmail {
+			match = found.Email
+		}
+		if strings.EqualFold(match, value) {
+			mapped := toUser(found.UserID, found.Login, found.Email, found.Name)
+			log.WithField("userID", mapped.ID).Info("found grafana user")
+			return mapped, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUserNotFound, value)
+}
+
+// FetchUserDetails fetches the Grafana account by its ID
+func (c *GrafanaClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "grafana",
+		"userID":  userID,
+	})
+	log.Info("fetching user details by ID")
+
+	resp, header, status, err := c.makeRequest(ctx, "/api/users/"+url.PathEscape(userID), http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching user details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, header, "failed to fetch user details, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var user GrafanaUser
+	if err := json.Unmarshal(resp, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	log.Info("found user details")
+	return toUser(user.ID, user.Login, user.Email, user.Name), nil
+}
+
+// DeleteUser isn't supported, the accounts are provisioned by the identity provider of Grafana
+func (c *GrafanaClient) DeleteUser(_ context.Context, _ string) error {
+	return ErrUserDeletionNotSupported
+}
+
+func toUser(id int64, login, email, name string) *structs.User {
+	return &structs.User{
+		ID:          strconv.FormatInt(id, 10),
+		UserName:    login,
+		Email:       strings.ToLower(email),
+		DisplayName: name,
+	}
+}
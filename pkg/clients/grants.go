@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// GrantManager is implemented by the clients managing the privileges of the teams
+// on the objects of their backend
+type GrantManager interface {
+	// Returns the privileges of the team
+	FetchTeamGrants(ctx context.Context, teamID string) ([]structs.Grant, error)
+	// Grants the privileges to the team
+	GrantToTeam(ctx context.Context, teamID string, grants []structs.Grant) error
+	// Revokes the privileges from the team
+	RevokeFromTeam(ctx context.Context, teamID string, grants []structs.Grant) error
+}
+
+// GrantModifier is implemented by the GrantManagers holding a single privilege per object, e.g. the
+// role of a fivetran team membership, which is changed rather than revoked and granted again
+type GrantModifier interface {
+	// Replaces the privilege of the team on the objects of the grants
+	ModifyTeamGrants(ctx context.Context, teamID string, grants []structs.Grant) error
+}
+
+// GetGrantManager returns the client as a GrantManager when it manages the privileges of the teams
+func GetGrantManager(c Client) (GrantManager, bool) {
+	manager, ok := c.(GrantManager)
+	if !ok || !GetCapabilities(c).Grants {
+		return nil, false
+	}
+	return manager, true
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+const (
+	// pageSize is the number of items requested per page
+	pageSize = 100
+	// tokenExpiryLeeway is how long before its expiry the access token is refreshed
+	tokenExpiryLeeway = 30 * time.Second
+)
+
+// NewClient creates a new Keycloak client with the given configuration
+func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionPoolConfig,
+	hystrixCfg httpclient.HystrixResiliencyConfig) (*KeycloakClient, error) {
+
+	// Extract connection parameters
+	baseURL, _ := connection["base_url"].(string)
+	realm, _ := connection["realm"].(string)
+	clientID, _ := connection["client_id"].(string)
+	clientSecret, _ := connection["client_secret"].(string)
+	if baseURL == "" || realm == "" || clientID == "" || clientSecret == "" {
+		return nil, errors.New("missing required connection parameters for keycloak backend: " +
+			"base_url, realm, client_id and client_secret are required")
+	}
+
+	config := KeycloakConfig{
+		BaseURL:      strings.TrimSuffix(baseURL, "/"),
+		Realm:        realm,
+		AuthRealm:    realm,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+	if authRealm, _ := connection["auth_realm"].(string); authRealm != "" {
+		config.AuthRealm = authRealm
+	}
+	if parent, _ := connection["parent_group"].(string); parent != "" {
+		config.ParentGroup = "/" + strings.Trim(parent, "/")
+	}
+	config.LDAPProvider, _ = connection["ldap_provider"].(string)
+
+	client, err := httpclient.InitializeClient(
+		"keycloak",
+		poolCfg,
+		hystrixCfg,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &KeycloakClient{
+		config: &config,
+		client: client,
+	}, nil
+}
+
+// accessToken returns the access token of the service account,
+// fetched with the client credentials grant when missing or about to expire
+func (c *KeycloakClient) accessToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.config.ClientID},
+		"client_secret": {c.config.ClientSecret},
+	}
+	tokenURL := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token",
+		c.config.BaseURL, url.PathEscape(c.config.AuthRealm))
+	req, err := request.NewRequest(ctx, http.MethodPost, tokenURL, []byte(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetHeaders(map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+	})
+
+	resp, headers, status, err := req.MakeRequestWithHeader(c.client, http.MethodPost, "keycloak")
+	if err != nil {
+		return "", clients.Transient(fmt.Errorf("failed to fetch access token: %w", err))
+	}
+	if status != http.StatusOK {
+		return "", clients.StatusError(status, headers, "failed to fetch access token, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var token TokenResponse
+	if err := json.Unmarshal(resp, &token); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("token response has no access token")
+	}
+
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryLeeway)
+	return c.token, nil
+}
+
+// dropAccessToken forgets the access token if it's still the given one, e.g. revoked before its expiry
+func (c *KeycloakClient) dropAccessToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token == token {
+		c.token = ""
+	}
+}
+
+// makeRequest sends a request to the admin REST API of the realm, endpoint is relative to the realm
+func (c *KeycloakClient) makeRequest(ctx context.Context, endpoint,
+	method string, body interface{}) ([]byte, http.Header, int, error) {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	adminURL := fmt.Sprintf("%s/admin/realms/%s%s", c.config.BaseURL, url.PathEscape(c.config.Realm), endpoint)
+	for attempt := 0; ; attempt++ {
+		token, err := c.accessToken(ctx)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		req, err := request.NewRequest(ctx, method, adminURL, requestBody)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		req.SetHeaders(map[string]string{
+			"Authorization": "Bearer " + token,
+			"Content-Type":  "application/json",
+			"Accept":        "application/json",
+		})
+
+		resp, headers, status, err := req.MakeRequestWithHeader(c.client, method, "keycloak")
+		if err != nil {
+			return nil, nil, status, clients.Transient(err)
+		}
+		// the sessions of the service account may end before the token expires, e.g. on a restart
+		// of Keycloak, the call is retried once with a new token
+		if status == http.StatusUnauthorized && attempt == 0 {
+			c.dropAccessToken(token)
+			continue
+		}
+		return resp, headers, status, nil
+	}
+}
+
+// fetchAllWithPagination calls processPage with every page of a list endpoint,
+// using the first and max parameters until a page isn't full
+func (c *KeycloakClient) fetchAllWithPagination(ctx context.Context,
+	endpoint string, processPage func([]byte) (int, error)) error {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	for first := 0; ; first += pageSize {
+		pageURL := fmt.Sprintf("%s%sfirst=%d&max=%d", endpoint, separator, first, pageSize)
+		resp, headers, status, err := c.makeRequest(ctx, pageURL, http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return clients.StatusError(status, headers, "failed to fetch data from %s, status: %s, body: %s",
+				endpoint, http.StatusText(status), string(resp))
+		}
+
+		count, err := processPage(resp)
+		if err != nil {
+			return err
+		}
+		if count < pageSize {
+			return nil
+		}
+	}
+}
+
+// createdID returns the ID of a created resource, the last segment of its Location header
+func createdID(headers http.Header) (string, error) {
+	location := headers.Get("Location")
+	if location == "" {
+		return "", errors.New("created resource has no location")
+	}
+	return location[strings.LastIndex(location, "/")+1:], nil
+}
+
+// GetConfig returns the client configuration
+func (c *KeycloakClient) GetConfig() *KeycloakConfig {
+	return c.config
+}
+
+// Capabilities returns the operations supported by the backend, the teams may be created under a parent group
+func (c *KeycloakClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserProvisioning: true,
+		UserListing:      true,
+		TeamListing:      true,
+		NestedTeams:      true,
+		Rename:           true,
+	}
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/clienttest"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adminAPI is the path of the admin REST API of the managed realm
+const adminAPI = "/admin/realms/internal"
+
+// realm stands for the token endpoint of the master realm and routes the admin API of the
+// internal realm with mux, the tokens it issued being accepted until revoked
+type realm struct {
+	mux       *http.ServeMux
+	issued    int
+	expiresIn int
+	revoked   map[string]bool
+}
+
+func newRealmClient(t *testing.T, connection map[string]interface{}) (*realm, *KeycloakClient) {
+	fake := &realm{mux: http.NewServeMux(), expiresIn: 300, revoked: map[string]bool{}}
+	fake.mux.HandleFunc("POST /realms/master/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		if r.PostForm.Get("client_secret") != "secret" {
+			clienttest.Reply(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized_client"})
+			return
+		}
+		fake.issued++
+		clienttest.Reply(w, http.StatusOK,
+			TokenResponse{AccessToken: "token-" + strconv.Itoa(fake.issued), ExpiresIn: fake.expiresIn})
+	})
+
+	server := clienttest.NewServer(t, fake.mux, func(w http.ResponseWriter, r *http.Request) bool {
+		token := r.Header.Get("Authorization")
+		if r.URL.Path != "/realms/master/protocol/openid-connect/token" && (token == "" || fake.revoked[token]) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	})
+
+	connection["base_url"] = server.URL + "/"
+	connection["realm"] = "internal"
+	connection["auth_realm"] = "master"
+	connection["client_id"] = "usernaut"
+	if _, ok := connection["client_secret"]; !ok {
+		connection["client_secret"] = "secret"
+	}
+	client, err := NewClient(connection, clienttest.PoolConfig, clienttest.HystrixConfig)
+	require.NoError(t, err)
+	return fake, client
+}
+
+func TestNewClient(t *testing.T) {
+	poolCfg, hystrixCfg := clienttest.PoolConfig, clienttest.HystrixConfig
+
+	_, err := NewClient(map[string]interface{}{"base_url": "https://sso.example.com", "realm": "internal"},
+		poolCfg, hystrixCfg)
+	assert.Error(t, err)
+
+	client, err := NewClient(map[string]interface{}{
+		"base_url":      "https://sso.example.com/",
+		"realm":         "internal",
+		"client_id":     "usernaut",
+		"client_secret": "secret",
+		"parent_group":  "apps/usernaut/",
+	}, poolCfg, hystrixCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "https://sso.example.com", client.GetConfig().BaseURL)
+	assert.Equal(t, "internal", client.GetConfig().AuthRealm)
+	assert.Equal(t, "/apps/usernaut", client.GetConfig().ParentGroup)
+}
+
+func TestAccessToken(t *testing.T) {
+	fake, client := newRealmClient(t, map[string]interface{}{})
+	var tokens []string
+	fake.mux.HandleFunc("GET "+adminAPI+"/users/{user}", func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		clienttest.Reply(w, http.StatusOK, KeycloakUser{ID: r.PathValue("user"), Username: "jdoe"})
+	})
+	ctx := context.Background()
+
+	// the token is reused until it expires
+	for range 2 {
+		_, err := client.FetchUserDetails(ctx, "u1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-1"}, tokens)
+
+	// a token revoked before its expiry, e.g. on a restart of Keycloak, is replaced once
+	fake.revoked["Bearer token-1"] = true
+	_, err := client.FetchUserDetails(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", tokens[2])
+
+	fake.revoked["Bearer token-2"], fake.revoked["Bearer token-3"] = true, true
+	_, err = client.FetchUserDetails(ctx, "u1")
+	assert.ErrorIs(t, err, clients.ErrUnauthorized)
+	assert.Equal(t, 3, fake.issued)
+
+	// the tokens expiring within the leeway are refreshed on every call
+	fake.expiresIn = 10
+	client.token = ""
+	for range 2 {
+		_, err := client.FetchUserDetails(ctx, "u1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 5, fake.issued)
+
+	_, client = newRealmClient(t, map[string]interface{}{"client_secret": "rotated"})
+	_, err = client.FetchUserDetails(ctx, "u1")
+	assert.ErrorIs(t, err, clients.ErrUnauthorized)
+}
+
+func TestPaginationFullLastPage(t *testing.T) {
+	fake, client := newRealmClient(t, map[string]interface{}{})
+	var firsts []string
+	fake.mux.HandleFunc("GET "+adminAPI+"/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("briefRepresentation"))
+		assert.Equal(t, strconv.Itoa(pageSize), r.URL.Query().Get("max"))
+		firsts = append(firsts, r.URL.Query().Get("first"))
+		// the realm has exactly one page of users, only an empty page tells the end
+		users := []KeycloakUser{}
+		if r.URL.Query().Get("first") == "0" {
+			for i := range pageSize {
+				users = append(users, KeycloakUser{ID: fmt.Sprintf("u%d", i), Username: fmt.Sprintf("user%d", i)})
+			}
+			users[0].Email, users[0].FirstName, users[0].LastName = "JDoe@example.com", "John", "Doe"
+		}
+		clienttest.Reply(w, http.StatusOK, users)
+	})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "100"}, firsts)
+	assert.Len(t, byID, pageSize)
+	assert.Len(t, byEmail, 1)
+	assert.Equal(t, "John Doe", byEmail["jdoe@example.com"].DisplayName)
+}
+
+func TestCreateUser(t *testing.T) {
+	fake, client := newRealmClient(t, map[string]interface{}{"ldap_provider": "ldap"})
+	var created []KeycloakUser
+	fake.mux.HandleFunc("GET "+adminAPI+"/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("exact"))
+		switch r.URL.Query().Get("username") {
+		case "jdoe":
+			// the lookup imports the users of the LDAP federation
+			clienttest.Reply(w, http.StatusOK, []KeycloakUser{{ID: "u1", Username: "jdoe", FederationLink: "ldap"}})
+		default:
+			clienttest.Reply(w, http.StatusOK, []KeycloakUser{})
+		}
+	})
+	fake.mux.HandleFunc("POST "+adminAPI+"/users", func(w http.ResponseWriter, r *http.Request) {
+		var user KeycloakUser
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+		if user.Username == "jsmith" {
+			clienttest.Reply(w, http.StatusConflict, map[string]string{"errorMessage": "User exists with same email"})
+			return
+		}
+		created = append(created, user)
+		// only the location of the created user is returned
+		w.Header().Set("Location", "http://"+r.Host+"/admin/realms/internal/users/4f2e-9a")
+		w.WriteHeader(http.StatusCreated)
+	})
+	ctx := context.Background()
+
+	user, err := client.CreateUser(ctx, &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.ID)
+	assert.Empty(t, created)
+
+	user, err = client.CreateUser(ctx, &structs.User{
+		UserName: "bwayne", Email: "BWayne@example.com", FirstName: "Bruce", LastName: "Wayne",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "4f2e-9a", user.ID)
+	assert.Equal(t, "bwayne@example.com", user.Email)
+	assert.Equal(t, []KeycloakUser{{
+		Username: "bwayne", Email: "bwayne@example.com", FirstName: "Bruce", LastName: "Wayne",
+		Enabled: true, EmailVerified: true, FederationLink: "ldap",
+	}}, created)
+
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "jsmith", Email: "jdoe@example.com"})
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+
+	_, err = client.CreateUser(ctx, &structs.User{Email: "nousername@example.com"})
+	assert.ErrorContains(t, err, "username is required")
+}
+
+func TestCreateTeamUnderParentGroup(t *testing.T) {
+	fake, client := newRealmClient(t, map[string]interface{}{"parent_group": "/apps/data teams"})
+	lookups := 0
+	fake.mux.HandleFunc("GET "+adminAPI+"/group-by-path/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		assert.Equal(t, "/admin/realms/internal/group-by-path/apps/data%20teams", r.URL.EscapedPath())
+		clienttest.Reply(w, http.StatusOK, KeycloakGroup{ID: "g1", Name: "data teams", Path: "/apps/data teams"})
+	})
+	fake.mux.HandleFunc("GET "+adminAPI+"/groups/g1/children", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusOK, []KeycloakGroup{{ID: "g2", Name: "analysts", ParentID: "g1"}})
+	})
+	fake.mux.HandleFunc("POST "+adminAPI+"/groups/g1/children", func(w http.ResponseWriter, r *http.Request) {
+		var group KeycloakGroup
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&group))
+		if group.Name == "analysts" {
+			clienttest.Reply(w, http.StatusConflict,
+				map[string]string{"errorMessage": "Sibling group named 'analysts' already exists."})
+			return
+		}
+		// the child groups are returned, unlike the top level ones
+		clienttest.Reply(w, http.StatusCreated, KeycloakGroup{ID: "g3", Name: group.Name, ParentID: "g1"})
+	})
+	ctx := context.Background()
+
+	teams, err := client.FetchAllTeams(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"analysts": {ID: "g2", Name: "analysts"}}, teams)
+
+	team, err := client.CreateTeam(ctx, &structs.Team{Name: "engineers"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "g3", Name: "engineers"}, team)
+
+	_, err = client.CreateTeam(ctx, &structs.Team{Name: "analysts"})
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+
+	// the parent group is resolved once
+	assert.Equal(t, 1, lookups)
+}
+
+func TestRenameTeam(t *testing.T) {
+	fake, client := newRealmClient(t, map[string]interface{}{})
+	fake.mux.HandleFunc("PUT "+adminAPI+"/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var group map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&group))
+		// the attributes left out are kept
+		assert.Equal(t, []string{"name"}, slices.Collect(maps.Keys(group)))
+		if group["name"] == "analysts" {
+			clienttest.Reply(w, http.StatusConflict,
+				map[string]string{"errorMessage": "Sibling group named 'analysts' already exists."})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ctx := context.Background()
+
+	renamer, ok := clients.GetTeamRenamer(client)
+	require.True(t, ok)
+	team, err := renamer.RenameTeam(ctx, "g3", "engineers")
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "g3", Name: "engineers"}, team)
+
+	_, err = renamer.RenameTeam(ctx, "g3", "analysts")
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+}
+
+func TestMissingParentGroup(t *testing.T) {
+	fake, client := newRealmClient(t, map[string]interface{}{"parent_group": "/apps"})
+	fake.mux.HandleFunc("GET "+adminAPI+"/group-by-path/apps", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusNotFound, map[string]string{"error": "Group path does not exist"})
+	})
+
+	_, err := client.FetchAllTeams(context.Background())
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+	assert.ErrorContains(t, err, "failed to fetch parent group /apps")
+}
+
+func TestMembership(t *testing.T) {
+	fake, client := newRealmClient(t, map[string]interface{}{})
+	members := map[string]bool{}
+	fake.mux.HandleFunc("PUT "+adminAPI+"/users/{user}/groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("user") == "gone" {
+			clienttest.Reply(w, http.StatusNotFound, map[string]string{"error": "User not found"})
+			return
+		}
+		members[r.PathValue("user")] = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	fake.mux.HandleFunc("DELETE "+adminAPI+"/users/{user}/groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		delete(members, r.PathValue("user"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ctx := context.Background()
+
+	// the users are added one by one, the failures don't stop the others
+	err := client.AddUserToTeam(ctx, "g1", []string{"u1", "gone", "u2"})
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+	assert.ErrorContains(t, err, "user gone")
+	assert.Equal(t, map[string]bool{"u1": true, "u2": true}, members)
+
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "g1", []string{"u1"}))
+	assert.Equal(t, map[string]bool{"u2": true}, members)
+}
+
+func TestStatusErrors(t *testing.T) {
+	fake, client := newRealmClient(t, map[string]interface{}{})
+	clienttest.StatusTest{
+		Route: "GET " + adminAPI + "/groups/g1/members",
+		Call: func(ctx context.Context) error {
+			_, err := client.FetchTeamMembersByTeamID(ctx, "g1")
+			return err
+		},
+		ErrorBody: func(status int) interface{} {
+			return map[string]string{"error": http.StatusText(status)}
+		},
+		DeleteRoute: "DELETE " + adminAPI + "/groups/g2",
+		Delete: func(ctx context.Context) error {
+			return client.DeleteTeamByID(ctx, "g2")
+		},
+	}.Run(t, fake.mux)
+}
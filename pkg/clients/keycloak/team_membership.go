@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamMembersByTeamID fetches the direct members of the group, keyed by user ID
+func (c *KeycloakClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "keycloak",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	members := make(map[string]*structs.User)
+	endpoint := "/groups/" + url.PathEscape(teamID) + "/members?briefRepresentation=true"
+	err := c.fetchAllWithPagination(ctx, endpoint, func(resp []byte) (int, error) {
+		var users []KeycloakUser
+		if err := json.Unmarshal(resp, &users); err != nil {
+			return 0, fmt.Errorf("failed to parse members response: %w", err)
+		}
+		for _, user := range users {
+			members[user.ID] = toUser(user)
+		}
+		return len(users), nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group
+func (c *KeycloakClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return c.updateMembership(ctx, teamID, userIDs, http.MethodPut)
+}
+
+// RemoveUserFromTeam removes the users from the group
+func (c *KeycloakClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return c.updateMembership(ctx, teamID, userIDs, http.MethodDelete)
+}
+
+// updateMembership joins (PUT) or leaves (DELETE) the group for each user,
+// Keycloak has no bulk membership endpoint
+func (c *KeycloakClient) updateMembership(ctx context.Context, teamID string, userIDs []string, method string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "keycloak",
+		"teamID":     teamID,
+		"method":     method,
+		"user_count": len(userIDs),
+	})
+	log.Info("updating team membership")
+
+	var errs []error
+	for _, userID := range userIDs {
+		endpoint := fmt.Sprintf("/users/%s/groups/%s", url.PathEscape(userID), url.PathEscape(teamID))
+		resp, headers, status, err := c.makeRequest(ctx, endpoint, method, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to update membership of user %s: %w", userID, err))
+			continue
+		}
+		if status != http.StatusNoContent {
+			errs = append(errs, clients.StatusError(status, headers,
+				"failed to update membership of user %s, status: %s, body: %s",
+				userID, http.StatusText(status), string(resp)))
+		}
+	}
+
+	if len(errs) > 0 {
+		log.WithField("failed_count", len(errs)).Error("error updating team membership")
+		return errors.Join(errs...)
+	}
+	return nil
+}
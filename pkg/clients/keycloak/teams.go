@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchAllTeams fetches the groups managed by the backend, keyed by name:
+// the children of the parent group when one is configured, the top level groups otherwise
+func (c *KeycloakClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("service", "keycloak")
+
+	log.Info("fetching all teams")
+	endpoint, err := c.groupsEndpoint(ctx)
+	if err != nil {
+		log.WithError(err).Error("error resolving parent group")
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team)
+	err = c.fetchAllWithPagination(ctx, endpoint+"?briefRepresentation=true", func(resp []byte) (int, error) {
+		var groups []KeycloakGroup
+		if err := json.Unmarshal(resp, &groups); err != nil {
+			return 0, fmt.Errorf("failed to parse groups response: %w", err)
+		}
+		for _, group := range groups {
+			teams[group.Name] = toTeam(group)
+		}
+		return len(groups), nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, err
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates a new group, under the parent group when one is configured
+func (c *KeycloakClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "keycloak",
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	endpoint, err := c.groupsEndpoint(ctx)
+	if err != nil {
+		log.WithError(err).Error("error resolving parent group")
+		return nil, err
+	}
+
+	resp, headers, status, err := c.makeRequest(ctx, endpoint, http.MethodPost, KeycloakGroup{Name: team.Name})
+	if err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, err
+	}
+	if status != http.StatusCreated {
+		// Keycloak answers a conflict to the sibling groups of the same name
+		return nil, clients.StatusError(status, headers, "failed to create group, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	// creating a child group returns its representation, creating a top level group only its location
+	group := KeycloakGroup{Name: team.Name}
+	if len(resp) > 0 {
+		if err := json.Unmarshal(resp, &group); err != nil {
+			return nil, fmt.Errorf("failed to parse create group response: %w", err)
+		}
+	}
+	if group.ID == "" {
+		if group.ID, err = createdID(headers); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info("team created successfully")
+	createdTeam := toTeam(group)
+	return &createdTeam, nil
+}
+
+// FetchTeamDetails fetches the group by its ID
+func (c *KeycloakClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "keycloak",
+		"teamID":  teamID,
+	})
+
+	log.Info("fetching team details")
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, headers, "failed to fetch group %s, status: %s, body: %s",
+			teamID, http.StatusText(status), string(resp))
+	}
+
+	var group KeycloakGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+
+	log.Info("successfully fetched team details")
+	team := toTeam(group)
+	return &team, nil
+}
+
+// RenameTeam renames the group, keeping its members, subgroups and attributes
+func (c *KeycloakClient) RenameTeam(ctx context.Context, teamID, name string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "keycloak",
+		"teamID":  teamID,
+		"team":    name,
+	})
+
+	log.Info("renaming team")
+	// the attributes are only replaced when set, the name alone is updated
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodPut,
+		KeycloakGroup{Name: name})
+	if err != nil {
+		log.WithError(err).Error("error renaming team")
+		return nil, err
+	}
+	if status != http.StatusNoContent {
+		// Keycloak answers a conflict when a sibling group has the name
+		return nil, clients.StatusError(status, headers, "failed to rename group %s, status: %s, body: %s",
+			teamID, http.StatusText(status), string(resp))
+	}
+
+	log.Info("team renamed successfully")
+	return &structs.Team{ID: teamID, Name: name}, nil
+}
+
+// DeleteTeamByID deletes the group, along with its subgroups
+func (c *KeycloakClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "keycloak",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	resp, headers, status, err := c.makeRequest(ctx, "/groups/"+url.PathEscape(teamID), http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	if status != http.StatusNoContent && status != http.StatusNotFound {
+		return clients.StatusError(status, headers, "failed to delete group, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+// groupsEndpoint returns the endpoint listing and creating the managed groups
+func (c *KeycloakClient) groupsEndpoint(ctx context.Context) (string, error) {
+	if c.config.ParentGroup == "" {
+		return "/groups", nil
+	}
+
+	parentID, err := c.resolveParentGroup(ctx)
+	if err != nil {
+		return "", err
+	}
+	return "/groups/" + url.PathEscape(parentID) + "/children", nil
+}
+
+// resolveParentGroup returns the ID of the parent group, looked up by path on first use
+func (c *KeycloakClient) resolveParentGroup(ctx context.Context) (string, error) {
+	c.parentMu.Lock()
+	defer c.parentMu.Unlock()
+
+	if c.parentGroupID != "" {
+		return c.parentGroupID, nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(c.config.ParentGroup, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	resp, headers, status, err := c.makeRequest(ctx, "/group-by-path/"+strings.Join(segments, "/"), http.MethodGet, nil)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", clients.StatusError(status, headers, "failed to fetch parent group %s, status: %s, body: %s",
+			c.config.ParentGroup, http.StatusText(status), string(resp))
+	}
+
+	var group KeycloakGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return "", fmt.Errorf("failed to parse group response: %w", err)
+	}
+
+	c.parentGroupID = group.ID
+	return c.parentGroupID, nil
+}
+
+func toTeam(group KeycloakGroup) structs.Team {
+	return structs.Team{
+		ID:   group.ID,
+		Name: group.Name,
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+)
+
+// KeycloakConfig holds the configuration for Keycloak client
+type KeycloakConfig struct {
+	BaseURL string
+	// Realm is the realm whose groups and users are managed
+	Realm string
+	// AuthRealm is the realm of the service account client, defaults to Realm
+	AuthRealm    string
+	ClientID     string
+	ClientSecret string
+	// ParentGroup is the path of the group the teams are created under, e.g. /apps/usernaut
+	ParentGroup string
+	// LDAPProvider is the ID of the realm's LDAP user federation provider the created users are linked to
+	LDAPProvider string
+}
+
+// KeycloakClient is the client for interacting with the Keycloak admin REST API
+type KeycloakClient struct {
+	config *KeycloakConfig
+	client heimdall.Doer
+
+	// the access token of the service account, refreshed when it expires
+	tokenMu     sync.Mutex
+	token       string
+	tokenExpiry time.Time
+
+	// the ID of the parent group, resolved from its path on first use
+	parentMu      sync.Mutex
+	parentGroupID string
+}
+
+// TokenResponse is the response of the OpenID Connect token endpoint
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// KeycloakUser represents a Keycloak user
+type KeycloakUser struct {
+	ID             string              `json:"id,omitempty"`
+	Username       string              `json:"username"`
+	Email          string              `json:"email,omitempty"`
+	FirstName      string              `json:"firstName,omitempty"`
+	LastName       string              `json:"lastName,omitempty"`
+	Enabled        bool                `json:"enabled"`
+	EmailVerified  bool                `json:"emailVerified,omitempty"`
+	FederationLink string              `json:"federationLink,omitempty"`
+	Attributes     map[string][]string `json:"attributes,omitempty"`
+}
+
+// KeycloakGroup represents a Keycloak group
+type KeycloakGroup struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Path     string `json:"path,omitempty"`
+	ParentID string `json:"parentId,omitempty"`
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchAllUsers fetches all the users of the realm
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
+func (c *KeycloakClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "keycloak")
+
+	log.Info("fetching all users")
+	resultByID := make(map[string]*structs.User)
+	resultByEmail := make(map[string]*structs.User)
+
+	err := c.fetchAllWithPagination(ctx, "/users?briefRepresentation=true", func(resp []byte) (int, error) {
+		var users []KeycloakUser
+		if err := json.Unmarshal(resp, &users); err != nil {
+			return 0, fmt.Errorf("failed to parse users response: %w", err)
+		}
+		for _, user := range users {
+			structUser := toUser(user)
+			resultByID[structUser.ID] = structUser
+			if structUser.Email != "" {
+				resultByEmail[structUser.Email] = structUser
+			}
+		}
+		return len(users), nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, resultByEmail, nil
+}
+
+// CreateUser returns the realm user with the same username, which Keycloak imports from its
+// LDAP federation on lookup, and only creates the user when it doesn't exist yet
+func (c *KeycloakClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":  "keycloak",
+		"username": user.GetUserName(),
+	})
+
+	log.Info("creating user")
+	if user.GetUserName() == "" {
+		return nil, fmt.Errorf("username is required to create the keycloak user %s", user.GetEmail())
+	}
+
+	existing, err := c.findUser(ctx, user.GetUserName())
+	if err != nil {
+		log.WithError(err).Error("error looking up user")
+		return nil, err
+	}
+	if existing != nil {
+		log.Info("user already exists")
+		return toUser(*existing), nil
+	}
+
+	payload := KeycloakUser{
+		Username:       user.GetUserName(),
+		Email:          strings.ToLower(user.GetEmail()),
+		FirstName:      user.GetFirstName(),
+		LastName:       user.GetLastName(),
+		Enabled:        true,
+		EmailVerified:  user.GetEmail() != "",
+		FederationLink: c.config.LDAPProvider,
+	}
+	resp, headers, status, err := c.makeRequest(ctx, "/users", http.MethodPost, payload)
+	if err != nil {
+		log.WithError(err).Error("error creating user")
+		return nil, err
+	}
+	if status != http.StatusCreated {
+		// a user with the same email already exists, e.g. created out of the LDAP federation
+		return nil, clients.StatusError(status, headers, "failed to create user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	payload.ID, err = createdID(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("user created successfully")
+	return toUser(payload), nil
+}
+
+// FetchUserDetails fetches the user by its ID
+func (c *KeycloakClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "keycloak",
+		"userID":  userID,
+	})
+	log.Info("fetching user details by ID")
+
+	resp, headers, status, err := c.makeRequest(ctx, "/users/"+url.PathEscape(userID), http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching user details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, headers, "failed to fetch user details, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var user KeycloakUser
+	if err := json.Unmarshal(resp, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	log.Info("found user details")
+	return toUser(user), nil
+}
+
+// DeleteUser deletes the user from the realm
+func (c *KeycloakClient) DeleteUser(ctx context.Context, userID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "keycloak",
+		"userID":  userID,
+	})
+
+	log.Info("deleting user")
+	resp, headers, status, err := c.makeRequest(ctx, "/users/"+url.PathEscape(userID), http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting user")
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if status != http.StatusNoContent && status != http.StatusNotFound {
+		return clients.StatusError(status, headers, "failed to delete user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	log.Info("user deleted successfully")
+	return nil
+}
+
+// findUser returns the user with the given username, nil when there's none
+func (c *KeycloakClient) findUser(ctx context.Context, username string) (*KeycloakUser, error) {
+	query := url.Values{"username": {username}, "exact": {"true"}}
+	resp, headers, status, err := c.makeRequest(ctx, "/users?"+query.Encode(), http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, headers, "failed to look up user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	var users []KeycloakUser
+	if err := json.Unmarshal(resp, &users); err != nil {
+		return nil, fmt.Errorf("failed to parse users response: %w", err)
+	}
+	for _, user := range users {
+		if strings.EqualFold(user.Username, username) {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+func toUser(user KeycloakUser) *structs.User {
+	return &structs.User{
+		ID:          user.ID,
+		UserName:    user.Username,
+		Email:       strings.ToLower(user.Email),
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		DisplayName: strings.TrimSpace(user.FirstName + " " + user.LastName),
+	}
+}
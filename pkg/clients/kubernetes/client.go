@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the RoleBindings and OpenShift groups managed by the kubernetes backend, the roles it binds
+// are restricted to the ones listed here, to be extended along with the roles of the config files
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;create;update;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;clusterroles,verbs=bind,resourceNames=view;edit
+// +kubebuilder:rbac:groups=user.openshift.io,resources=groups,verbs=get;list;create;update;delete
+
+// NewClient creates a new Kubernetes client with the given configuration,
+// the objects are managed through k8sClient, the client of the manager
+func NewClient(backendName string, connection map[string]interface{},
+	k8sClient client.Client) (*KubernetesClient, error) {
+	if k8sClient == nil {
+		return nil, errors.New("kubernetes backend requires the kubernetes client of the manager")
+	}
+
+	config := KubernetesConfig{
+		Backend:       backendName,
+		RoleKind:      "ClusterRole",
+		BindingPrefix: defaultBindingPrefix,
+	}
+
+	config.Mode, _ = connection["mode"].(string)
+	config.Mode = strings.ToLower(config.Mode)
+	if config.Mode != ModeGroup && config.Mode != ModeRoleBinding {
+		return nil, fmt.Errorf("invalid mode %q for kubernetes backend: expected group or rolebinding", config.Mode)
+	}
+
+	switch namespaces := connection["namespaces"].(type) {
+	case string:
+		config.Namespaces = splitList(namespaces)
+	case []interface{}:
+		for _, namespace := range namespaces {
+			config.Namespaces = append(config.Namespaces, splitList(fmt.Sprint(namespace))...)
+		}
+	}
+	if config.Mode == ModeRoleBinding && len(config.Namespaces) == 0 {
+		return nil, errors.New("namespaces are required by the rolebinding mode of the kubernetes backend")
+	}
+
+	config.RoleName, _ = connection["role"].(string)
+	if len(config.Namespaces) > 0 && config.RoleName == "" {
+		return nil, errors.New("role is required to bind the teams in the namespaces of the kubernetes backend")
+	}
+	if roleKind, _ := connection["role_kind"].(string); roleKind != "" {
+		if roleKind != "ClusterRole" && roleKind != "Role" {
+			return nil, fmt.Errorf("invalid role_kind %q for kubernetes backend: expected ClusterRole or Role", roleKind)
+		}
+		config.RoleKind = roleKind
+	}
+	if prefix, ok := connection["binding_prefix"].(string); ok {
+		config.BindingPrefix = prefix
+	}
+	config.UserAttribute, _ = connection["user_attribute"].(string)
+
+	return &KubernetesClient{
+		config: &config,
+		client: k8sClient,
+	}, nil
+}
+
+// managedLabels returns the labels of the objects managed by the backend
+func (c *KubernetesClient) managedLabels() map[string]string {
+	return map[string]string{
+		ManagedByLabel: ManagedByValue,
+		BackendLabel:   c.config.Backend,
+	}
+}
+
+// bindingName returns the name of the RoleBindings of the team, team names may
+// contain underscores which aren't allowed in object names
+func (c *KubernetesClient) bindingName(team string) (string, error) {
+	name := strings.ToLower(strings.ReplaceAll(c.config.BindingPrefix+team, "_", "-"))
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid rolebinding name %q for team %s: %s", name, team, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
+
+// roleRef returns the role bound to the teams in the namespaces
+func (c *KubernetesClient) roleRef() rbacv1.RoleRef {
+	return rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     c.config.RoleKind,
+		Name:     c.config.RoleName,
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetConfig returns the client configuration
+func (c *KubernetesClient) GetConfig() *KubernetesConfig {
+	return c.config
+}
+
+// Capabilities returns the operations supported by the backend, the cluster has no user objects
+func (c *KubernetesClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserListing: true,
+		TeamListing: true,
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(rbacv1.SchemeGroupVersion.WithKind("RoleBinding"), meta.RESTScopeNamespace)
+	mapper.Add(GroupGVK, meta.RESTScopeRoot)
+	return fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objects...).Build()
+}
+
+func newClient(t *testing.T, connection map[string]interface{}, objects ...client.Object) (*KubernetesClient,
+	client.Client) {
+	k8sClient := newFakeClient(t, objects...)
+	c, err := NewClient("cluster", connection, k8sClient)
+	require.NoError(t, err)
+	return c, k8sClient
+}
+
+func TestNewClient(t *testing.T) {
+	k8sClient := newFakeClient(t)
+
+	c, err := NewClient("cluster", map[string]interface{}{
+		"mode":       "RoleBinding",
+		"namespaces": []interface{}{"team-a", "team-b, team-c"},
+		"role":       "edit",
+	}, k8sClient)
+	require.NoError(t, err)
+	assert.Equal(t, ModeRoleBinding, c.GetConfig().Mode)
+	assert.Equal(t, []string{"team-a", "team-b", "team-c"}, c.GetConfig().Namespaces)
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"}, c.roleRef())
+
+	for name, connection := range map[string]map[string]interface{}{
+		"missing mode":               {},
+		"invalid mode":               {"mode": "serviceaccount"},
+		"rolebinding without target": {"mode": "rolebinding", "role": "edit"},
+		"namespaces without role":    {"mode": "group", "namespaces": "team-a"},
+		"invalid role kind":          {"mode": "group", "namespaces": "team-a", "role": "edit", "role_kind": "Group"},
+	} {
+		_, err := NewClient("cluster", connection, k8sClient)
+		assert.Error(t, err, name)
+	}
+
+	_, err = NewClient("cluster", map[string]interface{}{"mode": "group"}, nil)
+	assert.Error(t, err)
+}
+
+func TestRoleBindingMode(t *testing.T) {
+	ctx := context.Background()
+	// a binding of the team that drifted, only present in one namespace and missing a user
+	drifted := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "usernaut-analysts",
+			Namespace:   "team-b",
+			Labels:      map[string]string{ManagedByLabel: ManagedByValue, BackendLabel: "cluster"},
+			Annotations: map[string]string{TeamAnnotation: "analysts"},
+		},
+		RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+		Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "jdoe"}},
+	}
+	unmanaged := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "usernaut-admins", Namespace: "team-a"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+	}
+	c, k8sClient := newClient(t, map[string]interface{}{
+		"mode":       "rolebinding",
+		"namespaces": "team-a,team-b",
+		"role":       "edit",
+	}, drifted, unmanaged)
+
+	team, err := c.CreateTeam(ctx, &structs.Team{Name: "data_engineers"})
+	require.NoError(t, err)
+	assert.Equal(t, "data_engineers", team.ID)
+
+	teams, err := c.FetchAllTeams(ctx)
+	require.NoError(t, err)
+	assert.Len(t, teams, 2)
+	assert.Contains(t, teams, "analysts")
+	assert.Contains(t, teams, "data_engineers")
+
+	_, err = c.FetchTeamDetails(ctx, "data_engineers")
+	require.NoError(t, err)
+	_, err = c.FetchTeamDetails(ctx, "admins")
+	assert.Error(t, err, "bindings of other owners aren't teams")
+	_, err = c.CreateTeam(ctx, &structs.Team{Name: "admins"})
+	assert.Error(t, err, "bindings of other owners aren't adopted")
+
+	members, err := c.FetchTeamMembersByTeamID(ctx, "analysts")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{"jdoe": {ID: "jdoe", UserName: "jdoe"}}, members)
+
+	// the change is applied to every namespace, creating the missing binding
+	require.NoError(t, c.AddUserToTeam(ctx, "analysts", []string{"asmith", "bwayne"}))
+	require.NoError(t, c.RemoveUserFromTeam(ctx, "analysts", []string{"bwayne"}))
+	for _, namespace := range []string{"team-a", "team-b"} {
+		binding := &rbacv1.RoleBinding{}
+		require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "usernaut-analysts"}, binding))
+		assert.Equal(t, []rbacv1.Subject{
+			{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "asmith"},
+			{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "jdoe"},
+		}, binding.Subjects, namespace)
+		assert.Equal(t, "edit", binding.RoleRef.Name)
+	}
+
+	users, byEmail, err := c.FetchAllUsers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Empty(t, byEmail)
+
+	require.NoError(t, c.DeleteTeamByID(ctx, "analysts"))
+	_, err = c.FetchTeamDetails(ctx, "analysts")
+	assert.Error(t, err)
+}
+
+func TestGroupMode(t *testing.T) {
+	ctx := context.Background()
+	c, k8sClient := newClient(t, map[string]interface{}{
+		"mode":       "group",
+		"namespaces": []interface{}{"team-a"},
+		"role":       "view",
+	})
+
+	_, err := c.CreateTeam(ctx, &structs.Team{Name: "analysts"})
+	require.NoError(t, err)
+	// creating the team again adopts the existing group
+	_, err = c.CreateTeam(ctx, &structs.Team{Name: "analysts"})
+	require.NoError(t, err)
+
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "usernaut-analysts"}, binding))
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "analysts"}},
+		binding.Subjects)
+
+	teams, err := c.FetchAllTeams(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"analysts": {ID: "analysts", Name: "analysts"}}, teams)
+
+	require.NoError(t, c.AddUserToTeam(ctx, "analysts", []string{"jdoe", "asmith"}))
+	require.NoError(t, c.RemoveUserFromTeam(ctx, "analysts", []string{"jdoe"}))
+
+	group := &unstructured.Unstructured{}
+	group.SetGroupVersionKind(GroupGVK)
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: "analysts"}, group))
+	users, _, _ := unstructured.NestedStringSlice(group.Object, "users")
+	assert.Equal(t, []string{"asmith"}, users)
+
+	members, err := c.FetchTeamMembersByTeamID(ctx, "analysts")
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Contains(t, members, "asmith")
+
+	require.NoError(t, c.DeleteTeamByID(ctx, "analysts"))
+	_, err = c.FetchTeamDetails(ctx, "analysts")
+	assert.Error(t, err)
+	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "usernaut-analysts"}, binding)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestCreateUser(t *testing.T) {
+	c, _ := newClient(t, map[string]interface{}{"mode": "group", "user_attribute": "kerberosName"})
+
+	user, err := c.CreateUser(context.Background(), &structs.User{
+		UserName:   "jdoe",
+		Email:      "jdoe@example.com",
+		Attributes: map[string]string{"kerberosName": "jdoe@EXAMPLE.COM"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe@EXAMPLE.COM", user.ID)
+
+	_, err = c.CreateUser(context.Background(), &structs.User{UserName: "asmith"})
+	assert.Error(t, err)
+}
+
+func TestRuntimeBackendRoles(t *testing.T) {
+	clients.SetKubernetesClient(newFakeClient(t))
+	t.Cleanup(func() { clients.SetKubernetesClient(nil) })
+	appConfig := &config.AppConfig{}
+
+	// the backends of Backend CRs can't choose the roles bound nor where
+	for key, value := range map[string]interface{}{
+		"role": "cluster-admin", "role_kind": "ClusterRole", "namespaces": "kube-system",
+	} {
+		require.NoError(t, appConfig.RegisterBackend("team-a/cluster", config.Backend{
+			Name: "cr-cluster", Type: "kubernetes", Enabled: true,
+			Connection: map[string]interface{}{"mode": "group", key: value},
+		}))
+		_, err := clients.New("cr-cluster", "kubernetes", appConfig)
+		assert.ErrorContains(t, err, key+" is only allowed in the config files")
+		config.UnregisterBackend("team-a/cluster")
+	}
+
+	require.NoError(t, appConfig.RegisterBackend("team-a/groups", config.Backend{
+		Name: "cr-groups", Type: "kubernetes", Enabled: true,
+		Connection: map[string]interface{}{"mode": "group"},
+	}))
+	t.Cleanup(func() { config.UnregisterBackend("team-a/groups") })
+	_, err := clients.New("cr-groups", "kubernetes", appConfig)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+)
+
+// FetchTeamMembersByTeamID fetches the users of the team, keyed by user name. In the rolebinding mode
+// they're the users bound in any of the namespaces so that removed users are unbound everywhere.
+func (c *KubernetesClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "kubernetes",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	var users []string
+	if c.config.Mode == ModeGroup {
+		group, err := c.getGroup(ctx, teamID)
+		if err != nil {
+			log.WithError(err).Error("error fetching team members by team ID")
+			return nil, err
+		}
+		users, _, _ = unstructured.NestedStringSlice(group.Object, "users")
+	} else {
+		bindings, err := c.getBindings(ctx, teamID)
+		if err != nil {
+			log.WithError(err).Error("error fetching team members by team ID")
+			return nil, err
+		}
+		users = boundUsers(bindings)
+	}
+
+	members := make(map[string]*structs.User, len(users))
+	for _, user := range users {
+		members[user] = &structs.User{ID: user, UserName: user}
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the team
+func (c *KubernetesClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "kubernetes",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	}).Info("adding users to team")
+
+	return c.updateMembers(ctx, teamID, func(users map[string]bool) {
+		for _, userID := range userIDs {
+			users[userID] = true
+		}
+	})
+}
+
+// RemoveUserFromTeam removes the users from the team
+func (c *KubernetesClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "kubernetes",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	}).Info("removing users from team")
+
+	return c.updateMembers(ctx, teamID, func(users map[string]bool) {
+		for _, userID := range userIDs {
+			delete(users, userID)
+		}
+	})
+}
+
+// updateMembers applies the change to the users of the OpenShift group or of the RoleBindings of the team
+func (c *KubernetesClient) updateMembers(ctx context.Context, teamID string, change func(map[string]bool)) error {
+	if c.config.Mode == ModeRoleBinding {
+		return c.updateBindings(ctx, teamID, change)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		group, err := c.getGroup(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		current, _, _ := unstructured.NestedStringSlice(group.Object, "users")
+		users := toSet(current)
+		change(users)
+
+		updated := sortedKeys(users)
+		if slices.Equal(current, updated) {
+			return nil
+		}
+		if err := unstructured.SetNestedStringSlice(group.Object, updated, "users"); err != nil {
+			return err
+		}
+		if err := c.client.Update(ctx, group); err != nil {
+			return fmt.Errorf("failed to update group %s: %w", teamID, err)
+		}
+		return nil
+	})
+}
+
+// updateBindings applies the change to the users bound in any namespace and binds the resulting
+// users in every namespace, creating the missing RoleBindings and aligning the ones that drifted
+func (c *KubernetesClient) updateBindings(ctx context.Context, team string, change func(map[string]bool)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		bindings, err := c.getBindings(ctx, team)
+		if err != nil {
+			return err
+		}
+
+		users := toSet(boundUsers(bindings))
+		if change != nil {
+			change(users)
+		}
+
+		subjects := make([]rbacv1.Subject, 0, len(users))
+		for _, user := range sortedKeys(users) {
+			subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: user})
+		}
+		for _, namespace := range c.config.Namespaces {
+			if err := c.applyBinding(ctx, namespace, team, bindings[namespace], subjects); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// boundUsers returns the users bound by any of the RoleBindings
+func boundUsers(bindings map[string]*rbacv1.RoleBinding) []string {
+	users := make(map[string]bool)
+	for _, binding := range bindings {
+		for _, subject := range binding.Subjects {
+			if subject.Kind == rbacv1.UserKind {
+				users[subject.Name] = true
+			}
+		}
+	}
+	return sortedKeys(users)
+}
+
+func equalSubjects(a, b []rbacv1.Subject) bool {
+	return slices.EqualFunc(a, b, func(x, y rbacv1.Subject) bool {
+		return x.Kind == y.Kind && x.Name == y.Name && x.Namespace == y.Namespace
+	})
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
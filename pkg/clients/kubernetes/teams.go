@@ -0,0 +1,288 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FetchAllTeams fetches the teams managed by the backend, keyed by name
+func (c *KubernetesClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "kubernetes",
+		"mode":    c.config.Mode,
+	})
+
+	log.Info("fetching all teams")
+	teams := make(map[string]structs.Team)
+	if c.config.Mode == ModeGroup {
+		groups := &unstructured.UnstructuredList{}
+		groups.SetGroupVersionKind(GroupGVK.GroupVersion().WithKind(GroupGVK.Kind + "List"))
+		if err := c.client.List(ctx, groups, client.MatchingLabels(c.managedLabels())); err != nil {
+			log.WithError(err).Error("error fetching list of teams")
+			return nil, fmt.Errorf("failed to list groups: %w", err)
+		}
+		for _, group := range groups.Items {
+			teams[group.GetName()] = structs.Team{ID: group.GetName(), Name: group.GetName()}
+		}
+	} else {
+		for _, namespace := range c.config.Namespaces {
+			bindings := &rbacv1.RoleBindingList{}
+			if err := c.client.List(ctx, bindings, client.InNamespace(namespace),
+				client.MatchingLabels(c.managedLabels())); err != nil {
+				log.WithError(err).Error("error fetching list of teams")
+				return nil, fmt.Errorf("failed to list rolebindings in namespace %s: %w", namespace, err)
+			}
+			for _, binding := range bindings.Items {
+				if team := binding.Annotations[TeamAnnotation]; team != "" {
+					teams[team] = structs.Team{ID: team, Name: team}
+				}
+			}
+		}
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates the OpenShift group of the team, or its RoleBindings in the target namespaces
+func (c *KubernetesClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "kubernetes",
+		"mode":    c.config.Mode,
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	var err error
+	if c.config.Mode == ModeGroup {
+		err = c.createGroup(ctx, team.Name)
+	} else {
+		err = c.updateBindings(ctx, team.Name, nil)
+	}
+	if err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, err
+	}
+
+	log.Info("team created successfully")
+	return &structs.Team{ID: team.Name, Name: team.Name}, nil
+}
+
+// FetchTeamDetails fetches the team by its ID, the name of the team
+func (c *KubernetesClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "kubernetes",
+		"teamID":  teamID,
+	})
+
+	log.Info("fetching team details")
+	var err error
+	if c.config.Mode == ModeGroup {
+		_, err = c.getGroup(ctx, teamID)
+	} else {
+		var bindings map[string]*rbacv1.RoleBinding
+		if bindings, err = c.getBindings(ctx, teamID); err == nil && len(bindings) == 0 {
+			err = fmt.Errorf("no rolebinding found for team %s", teamID)
+		}
+	}
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+
+	log.Info("successfully fetched team details")
+	return &structs.Team{ID: teamID, Name: teamID}, nil
+}
+
+// DeleteTeamByID deletes the OpenShift group of the team and its RoleBindings
+func (c *KubernetesClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "kubernetes",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	var errs []error
+	if c.config.Mode == ModeGroup {
+		group := &unstructured.Unstructured{}
+		group.SetGroupVersionKind(GroupGVK)
+		group.SetName(teamID)
+		if err := c.client.Delete(ctx, group); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete group %s: %w", teamID, err))
+		}
+	}
+
+	if len(c.config.Namespaces) > 0 {
+		errs = append(errs, c.deleteBindings(ctx, teamID)...)
+	}
+
+	if len(errs) > 0 {
+		log.WithError(errors.Join(errs...)).Error("error deleting team")
+		return errors.Join(errs...)
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+// deleteBindings deletes the RoleBindings of the team in the target namespaces
+func (c *KubernetesClient) deleteBindings(ctx context.Context, team string) []error {
+	name, err := c.bindingName(team)
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, namespace := range c.config.Namespaces {
+		binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		if err := c.client.Delete(ctx, binding); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete rolebinding %s/%s: %w", namespace, name, err))
+		}
+	}
+	return errs
+}
+
+// createGroup creates the OpenShift group of the team, bound to the role in the target namespaces
+func (c *KubernetesClient) createGroup(ctx context.Context, name string) error {
+	group := &unstructured.Unstructured{}
+	group.SetGroupVersionKind(GroupGVK)
+	group.SetName(name)
+	group.SetLabels(c.managedLabels())
+	if err := unstructured.SetNestedStringSlice(group.Object, []string{}, "users"); err != nil {
+		return err
+	}
+
+	if err := c.client.Create(ctx, group); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create group %s: %w", name, err)
+		}
+		// adopt a group left by a previous attempt, but not one owned by someone else
+		if _, err := c.getGroup(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	subjects := []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: name}}
+	for _, namespace := range c.config.Namespaces {
+		if err := c.applyBinding(ctx, namespace, name, nil, subjects); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getGroup returns the OpenShift group, failing when it isn't managed by the backend
+func (c *KubernetesClient) getGroup(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	group := &unstructured.Unstructured{}
+	group.SetGroupVersionKind(GroupGVK)
+	if err := c.client.Get(ctx, types.NamespacedName{Name: name}, group); err != nil {
+		return nil, fmt.Errorf("failed to fetch group %s: %w", name, err)
+	}
+	if !c.isManaged(group.GetLabels()) {
+		return nil, fmt.Errorf("group %s isn't managed by backend %s", name, c.config.Backend)
+	}
+	return group, nil
+}
+
+// getBindings returns the RoleBindings of the team, keyed by namespace
+func (c *KubernetesClient) getBindings(ctx context.Context, team string) (map[string]*rbacv1.RoleBinding, error) {
+	name, err := c.bindingName(team)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make(map[string]*rbacv1.RoleBinding, len(c.config.Namespaces))
+	for _, namespace := range c.config.Namespaces {
+		binding := &rbacv1.RoleBinding{}
+		err := c.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, binding)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch rolebinding %s/%s: %w", namespace, name, err)
+		}
+		if !c.isManaged(binding.Labels) {
+			return nil, fmt.Errorf("rolebinding %s/%s isn't managed by backend %s", namespace, name, c.config.Backend)
+		}
+		bindings[namespace] = binding
+	}
+	return bindings, nil
+}
+
+// applyBinding creates the RoleBinding of the team in the namespace, or updates the subjects of the existing one
+func (c *KubernetesClient) applyBinding(ctx context.Context, namespace, team string,
+	existing *rbacv1.RoleBinding, subjects []rbacv1.Subject) error {
+	name, err := c.bindingName(team)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Labels:      c.managedLabels(),
+				Annotations: map[string]string{TeamAnnotation: team},
+			},
+			RoleRef:  c.roleRef(),
+			Subjects: subjects,
+		}
+		err := c.client.Create(ctx, binding)
+		if err == nil || !apierrors.IsAlreadyExists(err) {
+			return wrapBindingError(err, namespace, name)
+		}
+
+		existing = &rbacv1.RoleBinding{}
+		if err := c.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, existing); err != nil {
+			return wrapBindingError(err, namespace, name)
+		}
+		if !c.isManaged(existing.Labels) {
+			return fmt.Errorf("rolebinding %s/%s isn't managed by backend %s", namespace, name, c.config.Backend)
+		}
+	}
+
+	if equalSubjects(existing.Subjects, subjects) {
+		return nil
+	}
+	existing.Subjects = subjects
+	return wrapBindingError(c.client.Update(ctx, existing), namespace, name)
+}
+
+func (c *KubernetesClient) isManaged(labels map[string]string) bool {
+	return labels[ManagedByLabel] == ManagedByValue && labels[BackendLabel] == c.config.Backend
+}
+
+func wrapBindingError(err error, namespace, name string) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("failed to apply rolebinding %s/%s: %w", namespace, name, err)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ModeGroup manages the teams as OpenShift groups
+	ModeGroup = "group"
+	// ModeRoleBinding manages the teams as RoleBindings of their users in the target namespaces
+	ModeRoleBinding = "rolebinding"
+
+	// ManagedByLabel and ManagedByValue mark the objects created by usernaut
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "usernaut"
+	// BackendLabel holds the name of the backend owning the object
+	BackendLabel = "usernaut.dataverse.redhat.com/backend"
+	// TeamAnnotation holds the name of the team a RoleBinding grants access to
+	TeamAnnotation = "usernaut.dataverse.redhat.com/team"
+
+	// defaultBindingPrefix is prepended to the team name to name its RoleBindings
+	defaultBindingPrefix = "usernaut-"
+)
+
+// GroupGVK is the kind of the OpenShift groups, handled as unstructured objects
+// so that the OpenShift API types aren't needed
+var GroupGVK = schema.GroupVersionKind{Group: "user.openshift.io", Version: "v1", Kind: "Group"}
+
+// KubernetesConfig holds the configuration for Kubernetes client
+type KubernetesConfig struct {
+	// Backend is the name of the backend, set as label on the managed objects
+	Backend string
+	// Mode is either ModeGroup or ModeRoleBinding
+	Mode string
+	// Namespaces are the namespaces the teams are given access to
+	Namespaces []string
+	// RoleKind and RoleName are the role bound in the namespaces, e.g. ClusterRole edit
+	RoleKind string
+	RoleName string
+	// BindingPrefix is prepended to the team name to name its RoleBindings
+	BindingPrefix string
+	// UserAttribute is the LDAP attribute holding the Kubernetes user name, the LDAP uid when empty
+	UserAttribute string
+}
+
+// KubernetesClient manages the teams as cluster objects through the manager's client
+type KubernetesClient struct {
+	config *KubernetesConfig
+	client client.Client
+}
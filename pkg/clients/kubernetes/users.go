@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// Kubernetes has no user objects: users are the names the cluster authenticates them with,
+// known from the LDAP uid or the configured user attribute, and exist only as members of the teams.
+
+// FetchAllUsers returns the members of the teams managed by the backend
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email, always empty as only user names are known
+func (c *KubernetesClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "kubernetes")
+
+	log.Info("fetching all users")
+	teams, err := c.FetchAllTeams(ctx)
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	resultByID := make(map[string]*structs.User)
+	for teamID := range teams {
+		members, err := c.FetchTeamMembersByTeamID(ctx, teamID)
+		if err != nil {
+			log.WithError(err).Error("error fetching list of users")
+			return nil, nil, err
+		}
+		for id, member := range members {
+			resultByID[id] = member
+		}
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, map[string]*structs.User{}, nil
+}
+
+// CreateUser returns the user with its Kubernetes user name as ID, nothing is created in the cluster
+func (c *KubernetesClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	name := user.GetUserName()
+	if c.config.UserAttribute != "" {
+		name = user.GetAttribute(c.config.UserAttribute)
+	}
+
+	logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":  "kubernetes",
+		"username": name,
+	}).Info("mapping user")
+	if name == "" {
+		return nil, fmt.Errorf("no kubernetes user name found for user %s", user.GetEmail())
+	}
+
+	return &structs.User{
+		ID:          name,
+		UserName:    name,
+		Email:       user.GetEmail(),
+		FirstName:   user.GetFirstName(),
+		LastName:    user.GetLastName(),
+		DisplayName: user.GetDisplayName(),
+	}, nil
+}
+
+// FetchUserDetails returns the user with the given user name
+func (c *KubernetesClient) FetchUserDetails(_ context.Context, userID string) (*structs.User, error) {
+	return &structs.User{ID: userID, UserName: userID}, nil
+}
+
+// DeleteUser does nothing, users leave the cluster when removed from the teams
+func (c *KubernetesClient) DeleteUser(_ context.Context, _ string) error {
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamMembersByTeamID fetches the members of the group, the placeholder excluded
+func (c *LDAPGroupsClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "ldap",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	group, err := c.getGroup(ctx, teamID)
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, err
+	}
+	return c.members(group), nil
+}
+
+// AddUserToTeam adds the users to the group, replacing the placeholder
+func (c *LDAPGroupsClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return c.updateMembers(ctx, teamID, userIDs, nil)
+}
+
+// RemoveUserFromTeam removes the users from the group, the placeholder is added back when it gets empty
+func (c *LDAPGroupsClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return c.updateMembers(ctx, teamID, nil, userIDs)
+}
+
+// updateMembers adds and removes the members with a single modification. Only the values that
+// change are sent, as adding an existing value or deleting a missing one fails the modification.
+func (c *LDAPGroupsClient) updateMembers(ctx context.Context, teamID string, add, remove []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":      "ldap",
+		"teamID":       teamID,
+		"add_count":    len(add),
+		"remove_count": len(remove),
+	})
+	log.Info("updating team membership")
+
+	group, err := c.getGroup(ctx, teamID)
+	if err != nil {
+		log.WithError(err).Error("error updating team membership")
+		return err
+	}
+
+	attribute := c.config.memberAttribute()
+	current := make(map[string]string)
+	for _, member := range group.GetAttributeValues(attribute) {
+		current[strings.ToLower(member)] = member
+	}
+
+	var toAdd, toDelete []string
+	for _, userID := range add {
+		dn := c.userDN(userID)
+		if _, ok := current[strings.ToLower(dn)]; !ok {
+			toAdd = append(toAdd, dn)
+			current[strings.ToLower(dn)] = dn
+		}
+	}
+	for _, userID := range remove {
+		dn := c.userDN(userID)
+		if member, ok := current[strings.ToLower(dn)]; ok {
+			toDelete = append(toDelete, member)
+			delete(current, strings.ToLower(dn))
+		}
+	}
+
+	// the object classes require a member, the placeholder fills in for the empty groups
+	placeholder := strings.ToLower(c.config.PlaceholderMember)
+	_, hasPlaceholder := current[placeholder]
+	if len(current) == 0 {
+		toAdd = append(toAdd, c.config.PlaceholderMember)
+	} else if hasPlaceholder && len(current) > 1 {
+		toDelete = append(toDelete, current[placeholder])
+	}
+	if len(toAdd) == 0 && len(toDelete) == 0 {
+		return nil
+	}
+
+	conn, err := c.conn.get()
+	if err != nil {
+		return err
+	}
+	request := ldap.NewModifyRequest(group.DN, nil)
+	if len(toAdd) > 0 {
+		request.Add(attribute, toAdd)
+	}
+	if len(toDelete) > 0 {
+		request.Delete(attribute, toDelete)
+	}
+	if err := conn.Modify(request); err != nil {
+		log.WithError(err).Error("error updating team membership")
+		return fmt.Errorf("failed to update members of group %s: %w", group.DN, err)
+	}
+	return nil
+}
+
+// members returns the members of the group entry, keyed by ID
+func (c *LDAPGroupsClient) members(group *ldap.Entry) map[string]*structs.User {
+	members := make(map[string]*structs.User)
+	for _, member := range group.GetAttributeValues(c.config.memberAttribute()) {
+		if strings.EqualFold(member, c.config.PlaceholderMember) {
+			continue
+		}
+		id := c.userID(member)
+		members[id] = &structs.User{ID: id, UserName: id}
+	}
+	return members
+}
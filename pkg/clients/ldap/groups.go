@@ -0,0 +1,261 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrUserDeletionNotSupported is returned by DeleteUser, the user entries aren't managed by the ldap backend
+var ErrUserDeletionNotSupported = errors.New("deleting users is not supported by the ldap backend")
+
+// FetchAllUsers returns the members of the managed groups, keyed by the value of their naming attribute
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email, always empty as only the DNs are known
+func (c *LDAPGroupsClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "ldap")
+
+	log.Info("fetching all users")
+	groups, err := c.searchGroups(ctx, ldap.ScopeSingleLevel, c.config.BaseDN)
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	resultByID := make(map[string]*structs.User)
+	for _, group := range groups {
+		for id, member := range c.members(group) {
+			resultByID[id] = member
+		}
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, map[string]*structs.User{}, nil
+}
+
+// CreateUser checks that the entry of the user exists, the users are owned by the directory
+func (c *LDAPGroupsClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "ldap",
+		"userID":  user.GetUserName(),
+	})
+
+	log.Info("mapping user")
+	if user.GetUserName() == "" {
+		return nil, fmt.Errorf("no user name found for user %s", user.GetEmail())
+	}
+
+	details, err := c.FetchUserDetails(ctx, user.GetUserName())
+	if err != nil {
+		log.WithError(err).Error("error mapping user")
+		return nil, err
+	}
+	return details, nil
+}
+
+// FetchUserDetails fetches the entry of the user
+func (c *LDAPGroupsClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	conn, err := c.conn.get()
+	if err != nil {
+		return nil, err
+	}
+
+	dn := c.userDN(userID)
+	resp, err := conn.Search(ldap.NewSearchRequest(
+		dn, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", []string{"mail", "cn"}, nil,
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, fmt.Errorf("user entry %s not found: %w", dn, ErrNoUserFound)
+		}
+		return nil, fmt.Errorf("failed to fetch user entry %s: %w", dn, err)
+	}
+	if len(resp.Entries) == 0 {
+		return nil, fmt.Errorf("user entry %s not found: %w", dn, ErrNoUserFound)
+	}
+
+	logger.Logger(ctx).WithField("service", "ldap").Debug("found user entry")
+	return &structs.User{
+		ID:          userID,
+		UserName:    userID,
+		Email:       strings.ToLower(resp.Entries[0].GetAttributeValue("mail")),
+		DisplayName: resp.Entries[0].GetAttributeValue("cn"),
+	}, nil
+}
+
+// DeleteUser isn't supported, the user entries belong to the directory
+func (c *LDAPGroupsClient) DeleteUser(_ context.Context, _ string) error {
+	return ErrUserDeletionNotSupported
+}
+
+// FetchAllTeams fetches the groups directly under the base DN, keyed by name
+func (c *LDAPGroupsClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("service", "ldap")
+
+	log.Info("fetching all teams")
+	groups, err := c.searchGroups(ctx, ldap.ScopeSingleLevel, c.config.BaseDN)
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team, len(groups))
+	for _, group := range groups {
+		name := group.GetAttributeValue(c.config.GroupAttribute)
+		teams[name] = structs.Team{ID: name, Name: name}
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates the group entry under the base DN, with the placeholder as only member
+func (c *LDAPGroupsClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "ldap",
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	conn, err := c.conn.get()
+	if err != nil {
+		return nil, err
+	}
+
+	request := ldap.NewAddRequest(c.groupDN(team.Name), nil)
+	request.Attribute("objectClass", []string{"top", c.config.ObjectClass})
+	request.Attribute(c.config.GroupAttribute, []string{team.Name})
+	request.Attribute(c.config.memberAttribute(), []string{c.config.PlaceholderMember})
+	if err := conn.Add(request); err != nil {
+		if !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+			log.WithError(err).Error("error creating team")
+			return nil, fmt.Errorf("failed to create group %s: %w", request.DN, err)
+		}
+		log.Info("team already exists")
+		return c.FetchTeamDetails(ctx, team.Name)
+	}
+
+	log.Info("team created successfully")
+	return &structs.Team{ID: team.Name, Name: team.Name}, nil
+}
+
+// FetchTeamDetails fetches the group entry by name
+func (c *LDAPGroupsClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	group, err := c.getGroup(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := group.GetAttributeValue(c.config.GroupAttribute)
+	return &structs.Team{ID: name, Name: name}, nil
+}
+
+// DeleteTeamByID deletes the group entry
+func (c *LDAPGroupsClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "ldap",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	conn, err := c.conn.get()
+	if err != nil {
+		return err
+	}
+
+	dn := c.groupDN(teamID)
+	if err := conn.Del(ldap.NewDelRequest(dn, nil)); err != nil &&
+		!ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to delete group %s: %w", dn, err)
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+// getGroup returns the group entry with its members
+func (c *LDAPGroupsClient) getGroup(ctx context.Context, name string) (*ldap.Entry, error) {
+	dn := c.groupDN(name)
+	groups, err := c.searchGroups(ctx, ldap.ScopeBaseObject, dn)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("group %s not found", dn)
+	}
+	return groups[0], nil
+}
+
+// searchGroups returns the group entries of the configured object class in the scope of the DN
+func (c *LDAPGroupsClient) searchGroups(_ context.Context, scope int, dn string) ([]*ldap.Entry, error) {
+	conn, err := c.conn.get()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := conn.Search(ldap.NewSearchRequest(
+		dn, scope, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(objectClass=%s)", ldap.EscapeFilter(c.config.ObjectClass)),
+		[]string{c.config.GroupAttribute, c.config.memberAttribute()}, nil,
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to search groups in %s: %w", dn, err)
+	}
+	return resp.Entries, nil
+}
+
+// groupDN returns the DN of the group entry
+func (c *LDAPGroupsClient) groupDN(name string) string {
+	return fmt.Sprintf("%s=%s,%s", c.config.GroupAttribute, ldap.EscapeDN(name), c.config.BaseDN)
+}
+
+// userDN returns the DN of the user entry, IDs of members outside of the
+// user DN template are their full DN
+func (c *LDAPGroupsClient) userDN(userID string) string {
+	if _, err := ldap.ParseDN(userID); err == nil && strings.Contains(userID, "=") {
+		return userID
+	}
+	return fmt.Sprintf(c.config.UserDN, ldap.EscapeDN(userID))
+}
+
+// userID returns the ID of the member DN, the value of its naming attribute
+// when it matches the user DN template and the full DN otherwise
+func (c *LDAPGroupsClient) userID(memberDN string) string {
+	member, err := ldap.ParseDN(memberDN)
+	if err != nil || len(member.RDNs) == 0 || len(member.RDNs[0].Attributes) != 1 {
+		return memberDN
+	}
+
+	template, err := ldap.ParseDN(fmt.Sprintf(c.config.UserDN, ldap.EscapeDN(member.RDNs[0].Attributes[0].Value)))
+	if err != nil || !template.EqualFold(member) {
+		return memberDN
+	}
+	return member.RDNs[0].Attributes[0].Value
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+const (
+	// GroupOfNames and GroupOfUniqueNames are the supported object classes of the managed groups
+	GroupOfNames       = "groupOfNames"
+	GroupOfUniqueNames = "groupOfUniqueNames"
+
+	defaultGroupAttribute = "cn"
+)
+
+// memberAttributes maps the group object classes to their member attribute
+var memberAttributes = map[string]string{
+	GroupOfNames:       "member",
+	GroupOfUniqueNames: "uniqueMember",
+}
+
+// LDAPWriteConnClient is the LDAP connection of the groups backend, which modifies the directory
+type LDAPWriteConnClient interface {
+	LDAPConnClient
+	Add(*ldap.AddRequest) error
+	Del(*ldap.DelRequest) error
+	Modify(*ldap.ModifyRequest) error
+	Close() error
+}
+
+// LDAPGroupsConfig holds the configuration for the LDAP groups backend
+type LDAPGroupsConfig struct {
+	Server       string
+	BindDN       string
+	BindPassword string
+	StartTLS     bool
+	// BaseDN is the entry the groups are created under
+	BaseDN string
+	// UserDN is the DN template of the members, e.g. uid=%s,ou=users,dc=org,dc=com
+	UserDN string
+	// ObjectClass is either GroupOfNames or GroupOfUniqueNames
+	ObjectClass string
+	// GroupAttribute is the naming attribute of the groups, cn by default
+	GroupAttribute string
+	// PlaceholderMember is the member of the empty groups, as the object classes require one
+	PlaceholderMember string
+}
+
+// LDAPGroupsClient manages groupOfNames or groupOfUniqueNames entries under a base DN
+type LDAPGroupsClient struct {
+	config *LDAPGroupsConfig
+	conn   *groupsConn
+}
+
+// groupsConn is an authenticated connection, re-established when closed
+type groupsConn struct {
+	mu     sync.Mutex
+	conn   LDAPWriteConnClient
+	config *LDAPGroupsConfig
+}
+
+// NewGroupsClient creates a new LDAP groups client with the given configuration
+func NewGroupsClient(connection map[string]interface{}) (*LDAPGroupsClient, error) {
+	// Extract connection parameters
+	config := LDAPGroupsConfig{
+		ObjectClass:    GroupOfNames,
+		GroupAttribute: defaultGroupAttribute,
+	}
+	config.Server, _ = connection["server"].(string)
+	config.BindDN, _ = connection["bind_dn"].(string)
+	config.BindPassword, _ = connection["bind_password"].(string)
+	config.BaseDN, _ = connection["base_dn"].(string)
+	config.UserDN, _ = connection["user_dn"].(string)
+	if config.Server == "" || config.BindDN == "" || config.BindPassword == "" ||
+		config.BaseDN == "" || config.UserDN == "" {
+		return nil, errors.New("missing required connection parameters for ldap backend: " +
+			"server, bind_dn, bind_password, base_dn and user_dn are required")
+	}
+	if !strings.Contains(config.UserDN, "%s") {
+		return nil, fmt.Errorf("user_dn %q of ldap backend must contain %%s", config.UserDN)
+	}
+	if _, err := ldap.ParseDN(config.BaseDN); err != nil {
+		return nil, fmt.Errorf("invalid base_dn of ldap backend: %w", err)
+	}
+
+	if objectClass, _ := connection["object_class"].(string); objectClass != "" {
+		if _, ok := memberAttributes[objectClass]; !ok {
+			return nil, fmt.Errorf("invalid object_class %q for ldap backend: expected %s or %s",
+				objectClass, GroupOfNames, GroupOfUniqueNames)
+		}
+		config.ObjectClass = objectClass
+	}
+	if attribute, _ := connection["group_attribute"].(string); attribute != "" {
+		config.GroupAttribute = attribute
+	}
+	// binding in cleartext when StartTLS was asked for, e.g. as "true" by a Backend CR, leaks the password
+	switch startTLS := connection["start_tls"].(type) {
+	case nil:
+	case bool:
+		config.StartTLS = startTLS
+	case string:
+		// config imports this package, so clients.ParseBoolConnection can't be used here
+		parsed, err := strconv.ParseBool(startTLS)
+		if err != nil && startTLS != "" {
+			return nil, fmt.Errorf("invalid start_tls %q for ldap backend: expected a boolean", startTLS)
+		}
+		config.StartTLS = parsed
+	default:
+		return nil, fmt.Errorf("invalid start_tls %v for ldap backend: expected a boolean", startTLS)
+	}
+	// the bind DN is a valid entry that grants nothing to the tools reading the groups
+	config.PlaceholderMember = config.BindDN
+	if placeholder, _ := connection["placeholder_member"].(string); placeholder != "" {
+		config.PlaceholderMember = placeholder
+	}
+
+	return &LDAPGroupsClient{
+		config: &config,
+		conn:   &groupsConn{config: &config},
+	}, nil
+}
+
+// get returns the connection, dialing and binding when it isn't established or was closed
+func (g *groupsConn) get() (LDAPWriteConnClient, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.conn != nil && !g.conn.IsClosing() {
+		return g.conn, nil
+	}
+
+	conn, err := ldap.DialURL(g.config.Server, ldap.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+	}
+	if g.config.StartTLS {
+		server, err := url.Parse(g.config.Server)
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("invalid LDAP server URL: %w", err)
+		}
+		if err := conn.StartTLS(&tls.Config{ServerName: server.Hostname(), MinVersion: tls.VersionTLS12}); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if err := conn.Bind(g.config.BindDN, g.config.BindPassword); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to bind as %s: %w", g.config.BindDN, err)
+	}
+
+	g.conn = conn
+	return g.conn, nil
+}
+
+// memberAttribute returns the attribute holding the members of the groups
+func (c *LDAPGroupsConfig) memberAttribute() string {
+	return memberAttributes[c.ObjectClass]
+}
+
+// GetConfig returns the client configuration
+func (c *LDAPGroupsClient) GetConfig() *LDAPGroupsConfig {
+	return c.config
+}
+
+// Close closes the connection, once the client is replaced or its backend removed
+func (c *LDAPGroupsClient) Close() error {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+	if c.conn.conn == nil {
+		return nil
+	}
+	err := c.conn.conn.Close()
+	c.conn.conn = nil
+	return err
+}
+
+// Capabilities returns the operations supported by the backend, the user entries are owned by the directory
+func (c *LDAPGroupsClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserListing: true,
+		TeamListing: true,
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBaseDN = "ou=adhoc,ou=managedGroups,dc=example,dc=com"
+	testBindDN = "uid=usernaut,ou=serviceAccounts,dc=example,dc=com"
+)
+
+// fakeDirectory is an in-memory directory enforcing the constraints the groups backend relies on
+type fakeDirectory struct {
+	entries map[string]*ldap.Entry
+	closed  bool
+}
+
+func newFakeDirectory(entries ...*ldap.Entry) *fakeDirectory {
+	d := &fakeDirectory{entries: map[string]*ldap.Entry{}}
+	for _, entry := range entries {
+		d.entries[strings.ToLower(entry.DN)] = entry
+	}
+	return d
+}
+
+func (d *fakeDirectory) IsClosing() bool { return d.closed }
+
+func (d *fakeDirectory) Close() error {
+	d.closed = true
+	return nil
+}
+
+func (d *fakeDirectory) Search(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	objectClass := strings.TrimSuffix(strings.TrimPrefix(request.Filter, "(objectClass="), ")")
+	matches := func(entry *ldap.Entry) bool {
+		return objectClass == "*" || contains(entry.GetAttributeValues("objectClass"), objectClass)
+	}
+
+	result := &ldap.SearchResult{}
+	if request.Scope == ldap.ScopeBaseObject {
+		entry, ok := d.entries[strings.ToLower(request.BaseDN)]
+		if !ok {
+			return nil, ldap.NewError(ldap.LDAPResultNoSuchObject, errors.New("no such object"))
+		}
+		if matches(entry) {
+			result.Entries = append(result.Entries, entry)
+		}
+		return result, nil
+	}
+
+	base, err := ldap.ParseDN(request.BaseDN)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range d.entries {
+		dn, err := ldap.ParseDN(entry.DN)
+		if err != nil {
+			return nil, err
+		}
+		parent := &ldap.DN{RDNs: dn.RDNs[1:]}
+		if parent.EqualFold(base) && matches(entry) {
+			result.Entries = append(result.Entries, entry)
+		}
+	}
+	return result, nil
+}
+
+func (d *fakeDirectory) Add(request *ldap.AddRequest) error {
+	if _, ok := d.entries[strings.ToLower(request.DN)]; ok {
+		return ldap.NewError(ldap.LDAPResultEntryAlreadyExists, errors.New("already exists"))
+	}
+	entry := &ldap.Entry{DN: request.DN}
+	for _, attribute := range request.Attributes {
+		entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(attribute.Type, attribute.Vals))
+	}
+	d.entries[strings.ToLower(request.DN)] = entry
+	return d.checkMembers(entry)
+}
+
+func (d *fakeDirectory) Del(request *ldap.DelRequest) error {
+	if _, ok := d.entries[strings.ToLower(request.DN)]; !ok {
+		return ldap.NewError(ldap.LDAPResultNoSuchObject, errors.New("no such object"))
+	}
+	delete(d.entries, strings.ToLower(request.DN))
+	return nil
+}
+
+func (d *fakeDirectory) Modify(request *ldap.ModifyRequest) error {
+	entry, ok := d.entries[strings.ToLower(request.DN)]
+	if !ok {
+		return ldap.NewError(ldap.LDAPResultNoSuchObject, errors.New("no such object"))
+	}
+
+	attributes := map[string][]string{}
+	for _, attribute := range entry.Attributes {
+		attributes[attribute.Name] = attribute.Values
+	}
+	for _, change := range request.Changes {
+		values := attributes[change.Modification.Type]
+		for _, value := range change.Modification.Vals {
+			switch change.Operation {
+			case ldap.AddAttribute:
+				if contains(values, value) {
+					return ldap.NewError(ldap.LDAPResultAttributeOrValueExists, errors.New(value))
+				}
+				values = append(values, value)
+			case ldap.DeleteAttribute:
+				if !contains(values, value) {
+					return ldap.NewError(ldap.LDAPResultNoSuchAttribute, errors.New(value))
+				}
+				values = removeValue(values, value)
+			}
+		}
+		attributes[change.Modification.Type] = values
+	}
+
+	modified := &ldap.Entry{DN: entry.DN}
+	for name, values := range attributes {
+		modified.Attributes = append(modified.Attributes, ldap.NewEntryAttribute(name, values))
+	}
+	if err := d.checkMembers(modified); err != nil {
+		return err
+	}
+	d.entries[strings.ToLower(request.DN)] = modified
+	return nil
+}
+
+// checkMembers enforces the member required by the groupOfNames and groupOfUniqueNames object classes
+func (d *fakeDirectory) checkMembers(entry *ldap.Entry) error {
+	for objectClass, attribute := range memberAttributes {
+		if contains(entry.GetAttributeValues("objectClass"), objectClass) && len(entry.GetAttributeValues(attribute)) == 0 {
+			return ldap.NewError(ldap.LDAPResultObjectClassViolation, errors.New("missing "+attribute))
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeValue(values []string, value string) []string {
+	kept := make([]string, 0, len(values))
+	for _, v := range values {
+		if !strings.EqualFold(v, value) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+func newGroupsClient(t *testing.T, connection map[string]interface{},
+	directory *fakeDirectory) *LDAPGroupsClient {
+	connection["server"] = "ldap://ldap.example.com:389"
+	connection["bind_dn"] = testBindDN
+	connection["bind_password"] = "secret"
+	connection["base_dn"] = testBaseDN
+	connection["user_dn"] = "uid=%s,ou=users,dc=example,dc=com"
+
+	client, err := NewGroupsClient(connection)
+	require.NoError(t, err)
+	// a connection that doesn't dial the server
+	client.conn = &groupsConn{conn: directory, config: client.config}
+	return client
+}
+
+func TestNewGroupsClient(t *testing.T) {
+	_, err := NewGroupsClient(map[string]interface{}{"server": "ldap://ldap.example.com"})
+	assert.Error(t, err)
+
+	connection := map[string]interface{}{
+		"server":        "ldap://ldap.example.com",
+		"bind_dn":       testBindDN,
+		"bind_password": "secret",
+		"base_dn":       testBaseDN,
+		"user_dn":       "uid=%s,ou=users,dc=example,dc=com",
+		"object_class":  GroupOfUniqueNames,
+	}
+	client, err := NewGroupsClient(connection)
+	require.NoError(t, err)
+	assert.Equal(t, "uniqueMember", client.GetConfig().memberAttribute())
+	assert.Equal(t, testBindDN, client.GetConfig().PlaceholderMember)
+
+	// the clients own their connection, which the pool closes once they are evicted
+	other, err := NewGroupsClient(connection)
+	require.NoError(t, err)
+	assert.NotSame(t, client.conn, other.conn)
+	assert.NoError(t, other.Close())
+
+	// the Backend CRs set StartTLS as a string
+	connection["start_tls"] = "true"
+	client, err = NewGroupsClient(connection)
+	require.NoError(t, err)
+	assert.True(t, client.GetConfig().StartTLS)
+	delete(connection, "start_tls")
+
+	for _, invalid := range []map[string]interface{}{
+		{"start_tls": "yes"},
+		{"start_tls": 1},
+		{"object_class": "posixGroup"},
+		{"user_dn": "ou=users,dc=example,dc=com"},
+		{"base_dn": "not a dn"},
+	} {
+		for key, value := range connection {
+			if _, ok := invalid[key]; !ok {
+				invalid[key] = value
+			}
+		}
+		_, err := NewGroupsClient(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestGroupsLifecycle(t *testing.T) {
+	for _, objectClass := range []string{GroupOfNames, GroupOfUniqueNames} {
+		t.Run(objectClass, func(t *testing.T) {
+			ctx := context.Background()
+			directory := newFakeDirectory(&ldap.Entry{
+				DN: "uid=jdoe,ou=users,dc=example,dc=com",
+				Attributes: []*ldap.EntryAttribute{
+					ldap.NewEntryAttribute("mail", []string{"JDoe@example.com"}),
+					ldap.NewEntryAttribute("cn", []string{"John Doe"}),
+				},
+			})
+			client := newGroupsClient(t, map[string]interface{}{"object_class": objectClass}, directory)
+			attribute := client.GetConfig().memberAttribute()
+
+			team, err := client.CreateTeam(ctx, &structs.Team{Name: "data, analysts"})
+			require.NoError(t, err)
+			assert.Equal(t, "data, analysts", team.ID)
+			group := directory.entries[strings.ToLower(`cn=data\, analysts,`+testBaseDN)]
+			require.NotNil(t, group)
+			assert.Equal(t, []string{testBindDN}, group.GetAttributeValues(attribute))
+
+			// creating the group again returns the existing one
+			_, err = client.CreateTeam(ctx, &structs.Team{Name: "data, analysts"})
+			require.NoError(t, err)
+
+			teams, err := client.FetchAllTeams(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]structs.Team{"data, analysts": *team}, teams)
+
+			members, err := client.FetchTeamMembersByTeamID(ctx, team.ID)
+			require.NoError(t, err)
+			assert.Empty(t, members, "the placeholder isn't a member")
+
+			external := "cn=svc-etl,ou=apps,dc=example,dc=com"
+			require.NoError(t, client.AddUserToTeam(ctx, team.ID, []string{"jdoe", "asmith", external}))
+			require.NoError(t, client.AddUserToTeam(ctx, team.ID, []string{"jdoe"}))
+			members, err = client.FetchTeamMembersByTeamID(ctx, team.ID)
+			require.NoError(t, err)
+			assert.Len(t, members, 3)
+			assert.Contains(t, members, "jdoe")
+			assert.Contains(t, members, "asmith")
+			assert.Contains(t, members, external)
+
+			users, byEmail, err := client.FetchAllUsers(ctx)
+			require.NoError(t, err)
+			assert.Len(t, users, 3)
+			assert.Empty(t, byEmail)
+
+			require.NoError(t, client.RemoveUserFromTeam(ctx, team.ID, []string{"asmith", external, "unknown"}))
+			require.NoError(t, client.RemoveUserFromTeam(ctx, team.ID, []string{"jdoe"}))
+			group = directory.entries[strings.ToLower(`cn=data\, analysts,`+testBaseDN)]
+			assert.Equal(t, []string{testBindDN}, group.GetAttributeValues(attribute))
+
+			require.NoError(t, client.DeleteTeamByID(ctx, team.ID))
+			require.NoError(t, client.DeleteTeamByID(ctx, team.ID))
+			_, err = client.FetchTeamDetails(ctx, team.ID)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGroupsUsers(t *testing.T) {
+	ctx := context.Background()
+	directory := newFakeDirectory(&ldap.Entry{
+		DN: "uid=jdoe,ou=users,dc=example,dc=com",
+		Attributes: []*ldap.EntryAttribute{
+			ldap.NewEntryAttribute("mail", []string{"JDoe@example.com"}),
+			ldap.NewEntryAttribute("cn", []string{"John Doe"}),
+		},
+	})
+	client := newGroupsClient(t, map[string]interface{}{}, directory)
+
+	user, err := client.CreateUser(ctx, &structs.User{UserName: "jdoe"})
+	require.NoError(t, err)
+	assert.Equal(t, &structs.User{ID: "jdoe", UserName: "jdoe", Email: "jdoe@example.com", DisplayName: "John Doe"}, user)
+
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "asmith"})
+	assert.ErrorIs(t, err, ErrNoUserFound)
+
+	assert.ErrorIs(t, client.DeleteUser(ctx, "jdoe"), ErrUserDeletionNotSupported)
+
+	// the connection is closed once the client is evicted from the pool
+	require.NoError(t, client.Close())
+	assert.True(t, directory.closed)
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import "context"
+
+// MemberRoleManager is implemented by the clients giving each member of a team its own role,
+// e.g. the access level of a GitLab group member
+type MemberRoleManager interface {
+	// Returns the role as reported in the Role of the team members, the default role of
+	// the backend when empty, and an error when the backend has no such role
+	MemberRole(role string) (string, error)
+	// Changes the role of a member of the team
+	SetTeamMemberRole(ctx context.Context, teamID, userID, role string) error
+}
+
+// GetMemberRoleManager returns the client as a MemberRoleManager when its members are given their own role
+func GetMemberRoleManager(c Client) (MemberRoleManager, bool) {
+	manager, ok := c.(MemberRoleManager)
+	if !ok || !GetCapabilities(c).MembershipRoles {
+		return nil, false
+	}
+	return manager, true
+}
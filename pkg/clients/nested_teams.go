@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// TeamNester is implemented by the clients nesting teams natively: the members of a
+// member team are members of the team without being added to it one by one
+type TeamNester interface {
+	// Returns the member teams of the team, keyed by ID
+	FetchMemberTeamsByTeamID(ctx context.Context, teamID string) (map[string]structs.Team, error)
+	// Makes the teams members of the team
+	AddTeamsToTeam(ctx context.Context, teamID string, memberTeamIDs []string) error
+	// Removes the member teams from the team
+	RemoveTeamsFromTeam(ctx context.Context, teamID string, memberTeamIDs []string) error
+}
+
+// GetTeamNester returns the client as a TeamNester when it nests teams natively
+// and its backend has team nesting enabled
+func GetTeamNester(c Client) (TeamNester, bool) {
+	nester, ok := c.(TeamNester)
+	if !ok || !GetCapabilities(c).NestedTeams {
+		return nil, false
+	}
+	return nester, true
+}
@@ -0,0 +1,417 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// configureTimeout bounds the Configure call made when connecting to a plugin
+const configureTimeout = 30 * time.Second
+
+// Connection keys of the plugin itself, the other ones are passed to the plugin on Configure
+const (
+	CommandKey = "command"
+	ArgsKey    = "args"
+	EnvKey     = "env"
+	AddressKey = "address"
+	TLSKey     = "tls"
+)
+
+var (
+	// plugins holds the connections to the plugins by backend name, the plugins are launched
+	// and configured once, their connection is shared by the clients of the backend
+	plugins   = map[string]*pluginConn{}
+	pluginsMu sync.Mutex
+)
+
+// Config holds the configuration for the plugin client
+type Config struct {
+	// Backend is the name of the backend, the plugins tell the backends they serve apart by it
+	Backend string `json:"backend"`
+	// Command and Args launch the plugin, served over its stdin and stdout
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	// Env is the whole environment of the launched plugin, which doesn't inherit the one of the operator
+	Env map[string]string `json:"env,omitempty"`
+	// Address is the address of a plugin already listening, e.g. 'unix:///run/usernaut/plugin.sock'
+	Address string `json:"address,omitempty"`
+	// TLS enables TLS on the connection to the address
+	TLS bool `json:"tls,omitempty"`
+	// Connection is passed to the plugin on Configure
+	Connection map[string]interface{} `json:"connection,omitempty"`
+}
+
+// CallError is the error of a call to a plugin, it carries the gRPC status returned by the plugin
+type CallError struct {
+	Backend string
+	Method  string
+	Status  *status.Status
+}
+
+func (e *CallError) Error() string {
+	return fmt.Sprintf("plugin of backend %s failed to %s: %s", e.Backend, e.Method, e.Status.Message())
+}
+
+// GRPCStatus returns the status of the call, for status.Code and status.FromError
+func (e *CallError) GRPCStatus() *status.Status {
+	return e.Status
+}
+
+// PluginClient is the client of a backend served by a plugin
+type PluginClient struct {
+	config *Config
+	conn   *pluginConn
+	closed bool
+}
+
+var _ Backend = (*PluginClient)(nil)
+
+// NewClient creates a new client of the plugin serving the backend, launching or connecting to it on first use
+func NewClient(backendName string, connection map[string]interface{}) (*PluginClient, error) {
+	config := Config{
+		Backend:    backendName,
+		Connection: map[string]interface{}{},
+	}
+	for key, value := range connection {
+		switch key {
+		case CommandKey:
+			config.Command, _ = value.(string)
+		case ArgsKey:
+			config.Args = stringList(value)
+		case EnvKey:
+			config.Env = stringMap(value)
+		case AddressKey:
+			config.Address, _ = value.(string)
+		case TLSKey:
+			// parsed below, set either as a boolean or as a string
+		default:
+			config.Connection[key] = value
+		}
+	}
+	// falling back to plaintext when TLS was asked for, e.g. as "true" by a Backend CR, exposes the traffic
+	tls, err := clients.ParseBoolConnection(connection, TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w for plugin backend", err)
+	}
+	config.TLS = tls
+	if (config.Command == "") == (config.Address == "") {
+		return nil, errors.New("invalid connection parameters for plugin backend: " +
+			"exactly one of command and address is required")
+	}
+
+	conn, err := getConn(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &PluginClient{config: &config, conn: conn}, nil
+}
+
+// getConn returns the connection to the plugin of the backend, launching or connecting
+// to it when there is none yet, the previous plugin exited or the configuration changed
+func getConn(config *Config) (*pluginConn, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := string(data)
+
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+
+	if conn, ok := plugins[config.Backend]; ok {
+		if conn.fingerprint == fingerprint && conn.alive() {
+			conn.clients++
+			return conn, nil
+		}
+		// the replaced connection is closed by the last of its clients, they may still be using it
+		delete(plugins, config.Backend)
+	}
+
+	var conn *pluginConn
+	if config.Command != "" {
+		conn, err = launch(config)
+	} else {
+		conn, err = dial(config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start plugin of backend %s: %w", config.Backend, err)
+	}
+	conn.fingerprint = fingerprint
+	conn.clients = 1
+
+	ctx, cancel := context.WithTimeout(context.Background(), configureTimeout)
+	defer cancel()
+	if err := configure(ctx, config, conn); err != nil {
+		conn.close()
+		return nil, err
+	}
+
+	plugins[config.Backend] = conn
+	return conn, nil
+}
+
+// Close stops the plugin of the backend or disconnects from it once the client is replaced or its
+// backend removed, the connection is kept until the other clients sharing it are closed as well
+func (c *PluginClient) Close() error {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	if c.conn.clients--; c.conn.clients > 0 {
+		return nil
+	}
+	if plugins[c.config.Backend] == c.conn {
+		delete(plugins, c.config.Backend)
+	}
+	c.conn.close()
+	return nil
+}
+
+// dial connects to a plugin listening on an address
+func dial(config *Config) (*pluginConn, error) {
+	creds := insecure.NewCredentials()
+	if config.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	grpcConn, err := grpc.NewClient(config.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &pluginConn{grpc: grpcConn}, nil
+}
+
+// configure passes the connection settings of the backend to the plugin
+func configure(ctx context.Context, config *Config, conn *pluginConn) error {
+	var resp ConfigureResponse
+	req := &ConfigureRequest{Connection: config.Connection}
+	if err := invoke(ctx, config, conn, methodConfigure, req, &resp); err != nil {
+		return err
+	}
+	if resp.ProtocolVersion != ProtocolVersion {
+		return fmt.Errorf("plugin of backend %s speaks protocol version %d, expected %d",
+			config.Backend, resp.ProtocolVersion, ProtocolVersion)
+	}
+	conn.capabilities.Store(resp.Capabilities)
+
+	logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "plugin",
+		"backend": config.Backend,
+	}).Info("plugin configured")
+	return nil
+}
+
+func invoke(ctx context.Context, config *Config, conn *pluginConn, method string, req, resp interface{}) error {
+	in, err := toStruct(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	out := &structpb.Struct{}
+	ctx = metadata.AppendToOutgoingContext(ctx, backendMetadataKey, config.Backend)
+	if err := conn.grpc.Invoke(ctx, fullMethod(method), in, out); err != nil {
+		return &CallError{Backend: config.Backend, Method: method, Status: status.Convert(err)}
+	}
+
+	if err := fromStruct(out, resp); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	return nil
+}
+
+// call calls a method of the plugin, configuring it again when it lost its configuration, e.g. on restart
+func (c *PluginClient) call(ctx context.Context, method string, req, resp interface{}) error {
+	err := invoke(ctx, c.config, c.conn, method, req, resp)
+	if status.Code(err) == codes.FailedPrecondition {
+		if err := configure(ctx, c.config, c.conn); err != nil {
+			return err
+		}
+		err = invoke(ctx, c.config, c.conn, method, req, resp)
+	}
+	return err
+}
+
+// stringMap returns the string values of a map setting, e.g. the environment of a plugin
+func stringMap(value interface{}) map[string]string {
+	switch v := value.(type) {
+	case map[string]string:
+		return v
+	case map[string]interface{}:
+		m := make(map[string]string, len(v))
+		for key, item := range v {
+			m[key] = fmt.Sprint(item)
+		}
+		return m
+	}
+	return nil
+}
+
+// stringList reads a list of strings set either as a list or as a space separated string
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []string:
+		return v
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			list = append(list, fmt.Sprint(item))
+		}
+		return list
+	}
+	return nil
+}
+
+// FetchAllUsers fetches the users of the backend
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
+func (c *PluginClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	var resp UsersResponse
+	if err := c.call(ctx, methodFetchAllUsers, &Empty{}, &resp); err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[string]*structs.User, len(resp.Users))
+	byEmail := make(map[string]*structs.User, len(resp.Users))
+	for _, user := range resp.Users {
+		byID[user.ID] = user
+		if user.Email != "" {
+			byEmail[user.Email] = user
+		}
+	}
+	return byID, byEmail, nil
+}
+
+// FetchUserDetails fetches the user by ID
+func (c *PluginClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	var resp UserResponse
+	if err := c.call(ctx, methodFetchUserDetails, &UserRequest{UserID: userID}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// CreateUser onboards the user on the backend
+func (c *PluginClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	var resp UserResponse
+	if err := c.call(ctx, methodCreateUser, &UserRequest{User: user}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// DeleteUser drops the user from the backend
+func (c *PluginClient) DeleteUser(ctx context.Context, userID string) error {
+	return c.call(ctx, methodDeleteUser, &UserRequest{UserID: userID}, &Empty{})
+}
+
+// FetchAllTeams fetches the teams of the backend, keyed by name
+func (c *PluginClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	var resp TeamsResponse
+	if err := c.call(ctx, methodFetchAllTeams, &Empty{}, &resp); err != nil {
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team, len(resp.Teams))
+	for _, team := range resp.Teams {
+		teams[team.Name] = team
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails fetches the team by ID
+func (c *PluginClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	var resp TeamResponse
+	if err := c.call(ctx, methodFetchTeamDetails, &TeamRequest{TeamID: teamID}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Team, nil
+}
+
+// CreateTeam creates the team on the backend
+func (c *PluginClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	var resp TeamResponse
+	if err := c.call(ctx, methodCreateTeam, &TeamRequest{Team: team}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Team, nil
+}
+
+// DeleteTeamByID drops the team from the backend
+func (c *PluginClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	return c.call(ctx, methodDeleteTeamByID, &TeamRequest{TeamID: teamID}, &Empty{})
+}
+
+// FetchTeamMembersByTeamID fetches the members of the team, keyed by user ID
+func (c *PluginClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	var resp UsersResponse
+	if err := c.call(ctx, methodFetchTeamMembersByTeamID, &TeamRequest{TeamID: teamID}, &resp); err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]*structs.User, len(resp.Users))
+	for _, user := range resp.Users {
+		members[user.ID] = user
+	}
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the team
+func (c *PluginClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return c.call(ctx, methodAddUserToTeam, &TeamRequest{TeamID: teamID, UserIDs: userIDs}, &Empty{})
+}
+
+// RemoveUserFromTeam removes the users from the team
+func (c *PluginClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return c.call(ctx, methodRemoveUserFromTeam, &TeamRequest{TeamID: teamID, UserIDs: userIDs}, &Empty{})
+}
+
+// Capabilities returns the operations declared by the plugin on Configure, the default ones when it declared none
+func (c *PluginClient) Capabilities() structs.Capabilities {
+	if capabilities := c.conn.capabilities.Load(); capabilities != nil {
+		return *capabilities
+	}
+	return clients.DefaultCapabilities()
+}
+
+// GetConfig returns the client configuration
+func (c *PluginClient) GetConfig() *Config {
+	return c.config
+}
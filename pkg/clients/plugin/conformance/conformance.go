@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance checks that a backend, typically a plugin, behaves as the controllers expect.
+// Plugin authors run it from their tests against a plugin.PluginClient connected to their plugin.
+package conformance
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TeamName is the name of the team created, and deleted, by the checks
+const TeamName = "usernaut-conformance"
+
+// Run runs the checks against the backend. user is an account the backend accepts in CreateUser,
+// either looked up or created, it gets added to and removed from the team of the checks.
+func Run(t *testing.T, backend plugin.Backend, user *structs.User) {
+	ctx := context.Background()
+	var created *structs.User
+	var team *structs.Team
+
+	steps := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{"CreateUser", func(t *testing.T) {
+			var err error
+			created, err = backend.CreateUser(ctx, user)
+			require.NoError(t, err)
+			require.NotNil(t, created)
+			require.NotEmpty(t, created.ID, "users must have an ID")
+
+			again, err := backend.CreateUser(ctx, user)
+			require.NoError(t, err, "CreateUser must accept existing users")
+			assert.Equal(t, created.ID, again.ID, "CreateUser must return the existing user")
+		}},
+		{"FetchUsers", func(t *testing.T) {
+			byID, _, err := backend.FetchAllUsers(ctx)
+			require.NoError(t, err)
+			assert.Contains(t, byID, created.ID, "FetchAllUsers must be keyed by ID")
+
+			details, err := backend.FetchUserDetails(ctx, created.ID)
+			require.NoError(t, err)
+			assert.Equal(t, created.ID, details.ID)
+		}},
+		{"CreateTeam", func(t *testing.T) {
+			// a team left over by a previous run
+			teams, err := backend.FetchAllTeams(ctx)
+			require.NoError(t, err)
+			if existing, ok := teams[TeamName]; ok {
+				require.NoError(t, backend.DeleteTeamByID(ctx, existing.ID))
+			}
+
+			team, err = backend.CreateTeam(ctx, &structs.Team{Name: TeamName, Description: "usernaut conformance"})
+			require.NoError(t, err)
+			require.NotNil(t, team)
+			require.NotEmpty(t, team.ID, "teams must have an ID")
+		}},
+		{"FetchTeams", func(t *testing.T) {
+			teams, err := backend.FetchAllTeams(ctx)
+			require.NoError(t, err)
+			require.Contains(t, teams, TeamName, "FetchAllTeams must be keyed by name")
+			assert.Equal(t, team.ID, teams[TeamName].ID)
+
+			details, err := backend.FetchTeamDetails(ctx, team.ID)
+			require.NoError(t, err)
+			assert.True(t, strings.EqualFold(TeamName, details.Name), "FetchTeamDetails must return the team")
+		}},
+		{"AddUserToTeam", func(t *testing.T) {
+			require.NoError(t, backend.AddUserToTeam(ctx, team.ID, []string{created.ID}))
+			require.NoError(t, backend.AddUserToTeam(ctx, team.ID, []string{created.ID}),
+				"adding a member again must succeed")
+
+			members, err := backend.FetchTeamMembersByTeamID(ctx, team.ID)
+			require.NoError(t, err)
+			assert.Contains(t, members, created.ID, "FetchTeamMembersByTeamID must be keyed by user ID")
+		}},
+		{"RemoveUserFromTeam", func(t *testing.T) {
+			require.NoError(t, backend.RemoveUserFromTeam(ctx, team.ID, []string{created.ID}))
+			require.NoError(t, backend.RemoveUserFromTeam(ctx, team.ID, []string{created.ID}),
+				"removing a user who isn't a member must succeed")
+
+			members, err := backend.FetchTeamMembersByTeamID(ctx, team.ID)
+			require.NoError(t, err)
+			assert.NotContains(t, members, created.ID)
+		}},
+		{"DeleteTeam", func(t *testing.T) {
+			require.NoError(t, backend.DeleteTeamByID(ctx, team.ID))
+
+			teams, err := backend.FetchAllTeams(ctx)
+			require.NoError(t, err)
+			assert.NotContains(t, teams, TeamName)
+		}},
+	}
+
+	// every step relies on the previous ones
+	for _, step := range steps {
+		if !t.Run(step.name, step.run) {
+			return
+		}
+	}
+}
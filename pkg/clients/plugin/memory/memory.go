@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package memory is the reference plugin, serving a backend that keeps its users and teams in memory.
+// It shows what a plugin implements and is the backend the plugin protocol is tested with.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// Backend keeps the users, the teams and their members in memory
+type Backend struct {
+	mu      sync.Mutex
+	nextID  int
+	users   map[string]*structs.User
+	teams   map[string]structs.Team
+	members map[string]map[string]bool
+}
+
+var _ plugin.Backend = (*Backend)(nil)
+
+// New creates the backend, it is the plugin.Factory of the plugin.
+// The users connection setting lists the emails of the users the backend starts with, comma separated.
+func New(_ string, connection map[string]interface{}) (plugin.Backend, error) {
+	b := &Backend{
+		users:   map[string]*structs.User{},
+		teams:   map[string]structs.Team{},
+		members: map[string]map[string]bool{},
+	}
+
+	users, ok := connection["users"]
+	if !ok {
+		return b, nil
+	}
+	emails, ok := users.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid users %v: expected a comma separated list of emails", users)
+	}
+	for _, email := range strings.Split(emails, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			b.addUser(&structs.User{Email: email})
+		}
+	}
+	return b, nil
+}
+
+func (b *Backend) newID(prefix string) string {
+	b.nextID++
+	return fmt.Sprintf("%s-%d", prefix, b.nextID)
+}
+
+func (b *Backend) addUser(user *structs.User) *structs.User {
+	created := *user
+	created.ID = b.newID("user")
+	created.Email = strings.ToLower(created.Email)
+	if created.UserName == "" {
+		created.UserName = strings.Split(created.Email, "@")[0]
+	}
+	b.users[created.ID] = &created
+	return &created
+}
+
+// FetchAllUsers returns the users
+func (b *Backend) FetchAllUsers(_ context.Context) (map[string]*structs.User, map[string]*structs.User, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	byID := make(map[string]*structs.User, len(b.users))
+	byEmail := make(map[string]*structs.User, len(b.users))
+	for id, user := range b.users {
+		byID[id] = user
+		if user.Email != "" {
+			byEmail[user.Email] = user
+		}
+	}
+	return byID, byEmail, nil
+}
+
+// FetchUserDetails returns the user by ID
+func (b *Backend) FetchUserDetails(_ context.Context, userID string) (*structs.User, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	user, ok := b.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %s not found", userID)
+	}
+	return user, nil
+}
+
+// CreateUser returns the user with the same email or username, creating it when there is none
+func (b *Backend) CreateUser(_ context.Context, user *structs.User) (*structs.User, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, existing := range b.users {
+		if (user.Email != "" && strings.EqualFold(existing.Email, user.Email)) ||
+			(user.UserName != "" && existing.UserName == user.UserName) {
+			return existing, nil
+		}
+	}
+	return b.addUser(user), nil
+}
+
+// DeleteUser deletes the user and its memberships
+func (b *Backend) DeleteUser(_ context.Context, userID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.users, userID)
+	for _, members := range b.members {
+		delete(members, userID)
+	}
+	return nil
+}
+
+// FetchAllTeams returns the teams, keyed by name
+func (b *Backend) FetchAllTeams(_ context.Context) (map[string]structs.Team, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	teams := make(map[string]structs.Team, len(b.teams))
+	for _, team := range b.teams {
+		teams[team.Name] = team
+	}
+	return teams, nil
+}
+
+// FetchTeamDetails returns the team by ID
+func (b *Backend) FetchTeamDetails(_ context.Context, teamID string) (*structs.Team, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	team, ok := b.teams[teamID]
+	if !ok {
+		return nil, fmt.Errorf("team %s not found", teamID)
+	}
+	return &team, nil
+}
+
+// CreateTeam returns the team with the same name, creating it when there is none
+func (b *Backend) CreateTeam(_ context.Context, team *structs.Team) (*structs.Team, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, existing := range b.teams {
+		if existing.Name == team.Name {
+			return &existing, nil
+		}
+	}
+
+	created := *team
+	created.ID = b.newID("team")
+	b.teams[created.ID] = created
+	b.members[created.ID] = map[string]bool{}
+	return &created, nil
+}
+
+// DeleteTeamByID deletes the team
+func (b *Backend) DeleteTeamByID(_ context.Context, teamID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.teams, teamID)
+	delete(b.members, teamID)
+	return nil
+}
+
+// FetchTeamMembersByTeamID returns the members of the team, keyed by ID
+func (b *Backend) FetchTeamMembersByTeamID(_ context.Context, teamID string) (map[string]*structs.User, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	members, ok := b.members[teamID]
+	if !ok {
+		return nil, fmt.Errorf("team %s not found", teamID)
+	}
+	users := make(map[string]*structs.User, len(members))
+	for id := range members {
+		users[id] = b.users[id]
+	}
+	return users, nil
+}
+
+// AddUserToTeam adds the users to the team
+func (b *Backend) AddUserToTeam(_ context.Context, teamID string, userIDs []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	members, ok := b.members[teamID]
+	if !ok {
+		return fmt.Errorf("team %s not found", teamID)
+	}
+	for _, id := range userIDs {
+		if _, ok := b.users[id]; !ok {
+			return fmt.Errorf("user %s not found", id)
+		}
+		members[id] = true
+	}
+	return nil
+}
+
+// RemoveUserFromTeam removes the users from the team
+func (b *Backend) RemoveUserFromTeam(_ context.Context, teamID string, userIDs []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	members, ok := b.members[teamID]
+	if !ok {
+		return fmt.Errorf("team %s not found", teamID)
+	}
+	for _, id := range userIDs {
+		delete(members, id)
+	}
+	return nil
+}
+
+// Capabilities returns the operations supported by the backend, declared to the operator on Configure
+func (b *Backend) Capabilities() structs.Capabilities {
+	return structs.Capabilities{
+		UserProvisioning: true,
+		UserListing:      true,
+		TeamListing:      true,
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin/conformance"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/plugin/memory"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// servePluginEnv makes the test binary serve the memory plugin, for the tests launching a plugin
+const servePluginEnv = "USERNAUT_TEST_SERVE_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(servePluginEnv) != "" {
+		if err := plugin.Serve(memory.New); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// listen serves the plugin on a unix socket, returning its address
+func listen(t *testing.T, factory plugin.Factory) string {
+	// unix socket paths are limited in length, the test temporary directories may be too long
+	dir, err := os.MkdirTemp("", "plugin")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	lis, err := net.Listen("unix", filepath.Join(dir, "plugin.sock"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+	go func() { _ = plugin.ServeListener(lis, factory) }()
+
+	return "unix://" + lis.Addr().String()
+}
+
+func TestLaunchedPlugin(t *testing.T) {
+	t.Setenv(servePluginEnv, "true")
+
+	// the environment of the test is not inherited, the plugin is told to serve by its own
+	t.Setenv(servePluginEnv, "")
+	env := map[string]interface{}{servePluginEnv: "true"}
+	client, err := plugin.NewClient("launched", map[string]interface{}{
+		"command": os.Args[0],
+		"env":     env,
+		"users":   "jdoe@example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, os.Args[0], client.GetConfig().Command)
+	assert.Equal(t, map[string]string{servePluginEnv: "true"}, client.GetConfig().Env)
+	assert.Equal(t, map[string]interface{}{"users": "jdoe@example.com"}, client.GetConfig().Connection)
+
+	conformance.Run(t, client, &structs.User{Email: "JDoe@example.com"})
+
+	// the plugin is launched once per backend
+	again, err := plugin.NewClient("launched", map[string]interface{}{
+		"command": os.Args[0],
+		"env":     env,
+		"users":   "jdoe@example.com",
+	})
+	require.NoError(t, err)
+	users, _, err := again.FetchAllUsers(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	// the plugin is stopped once the last of the clients sharing it is closed
+	require.NoError(t, again.Close())
+	require.NoError(t, again.Close())
+	_, _, err = client.FetchAllUsers(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	_, _, err = client.FetchAllUsers(t.Context())
+	assert.Error(t, err)
+}
+
+func TestListeningPlugin(t *testing.T) {
+	address := listen(t, memory.New)
+
+	first, err := plugin.NewClient("first", map[string]interface{}{"address": address})
+	require.NoError(t, err)
+	conformance.Run(t, first, &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+
+	// the backends served by the same plugin are kept apart
+	second, err := plugin.NewClient("second", map[string]interface{}{"address": address, "users": "a@example.com"})
+	require.NoError(t, err)
+	users, byEmail, err := second.FetchAllUsers(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Contains(t, byEmail, "a@example.com")
+}
+
+func TestPluginErrors(t *testing.T) {
+	address := listen(t, func(_ string, connection map[string]interface{}) (plugin.Backend, error) {
+		if connection["fail"] != nil {
+			return nil, errors.New("invalid connection")
+		}
+		return memory.New("", nil)
+	})
+
+	_, err := plugin.NewClient("invalid", map[string]interface{}{"address": address, "fail": true})
+	assert.ErrorContains(t, err, "invalid connection")
+
+	client, err := plugin.NewClient("errors", map[string]interface{}{"address": address})
+	require.NoError(t, err)
+	_, err = client.FetchTeamDetails(t.Context(), "missing")
+	assert.ErrorContains(t, err, "plugin of backend errors failed to FetchTeamDetails: team missing not found")
+
+	_, err = plugin.NewClient("none", map[string]interface{}{})
+	assert.Error(t, err)
+	_, err = plugin.NewClient("both", map[string]interface{}{"address": address, "command": "plugin"})
+	assert.Error(t, err)
+
+	// the Backend CRs set the flags as strings
+	_, err = plugin.NewClient("tls", map[string]interface{}{"address": address, "tls": "secure"})
+	assert.ErrorContains(t, err, "invalid tls")
+	_, err = plugin.NewClient("plaintext", map[string]interface{}{"address": address, "tls": "false"})
+	assert.NoError(t, err)
+}
+
+func TestRuntimeBackendCommand(t *testing.T) {
+	address := listen(t, memory.New)
+	appConfig := &config.AppConfig{}
+
+	// the backends of Backend CRs can't launch a plugin
+	require.NoError(t, appConfig.RegisterBackend("team-a/launched", config.Backend{
+		Name: "cr-launched", Type: "plugin", Enabled: true,
+		Connection: map[string]interface{}{"command": os.Args[0]},
+	}))
+	t.Cleanup(func() { config.UnregisterBackend("team-a/launched") })
+	_, err := clients.New("cr-launched", "plugin", appConfig)
+	assert.ErrorContains(t, err, "command is only allowed in the config files")
+
+	require.NoError(t, appConfig.RegisterBackend("team-a/listening", config.Backend{
+		Name: "cr-listening", Type: "plugin", Enabled: true,
+		Connection: map[string]interface{}{"address": address},
+	}))
+	t.Cleanup(func() { config.UnregisterBackend("team-a/listening") })
+	_, err = clients.New("cr-listening", "plugin", appConfig)
+	assert.NoError(t, err)
+}
+
+// lookupOnly declares a backend only resolving its users
+type lookupOnly struct{ plugin.Backend }
+
+func (lookupOnly) Capabilities() structs.Capabilities {
+	return structs.Capabilities{UserListing: true, TeamListing: true}
+}
+
+func TestPluginCapabilities(t *testing.T) {
+	address := listen(t, func(name string, connection map[string]interface{}) (plugin.Backend, error) {
+		backend, err := memory.New(name, connection)
+		switch name {
+		case "lookup":
+			return lookupOnly{backend}, err
+		case "undeclared":
+			// embedding the interface hides the Capabilities of the memory backend
+			return struct{ plugin.Backend }{backend}, err
+		}
+		return backend, err
+	})
+
+	lookup, err := plugin.NewClient("lookup", map[string]interface{}{"address": address})
+	require.NoError(t, err)
+	assert.Equal(t, structs.Capabilities{UserListing: true, TeamListing: true}, clients.GetCapabilities(lookup))
+
+	undeclared, err := plugin.NewClient("undeclared", map[string]interface{}{"address": address})
+	require.NoError(t, err)
+	assert.Equal(t, clients.DefaultCapabilities(), clients.GetCapabilities(undeclared))
+}
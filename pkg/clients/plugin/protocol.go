@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin implements the backends served by external plugins. The plugins serve the
+// gRPC service described in backend.proto, which mirrors the clients.Client interface, either
+// over their stdin and stdout when launched by the operator or on an address they listen on.
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// ProtocolVersion is the version of the plugin protocol, returned by the plugins on Configure
+	ProtocolVersion = 1
+	// ServiceName is the name of the gRPC service served by the plugins
+	ServiceName = "usernaut.plugin.v1.Backend"
+	// backendMetadataKey is the metadata carrying the name of the backend a call is for,
+	// a plugin listening on an address may serve several backends
+	backendMetadataKey = "usernaut-backend"
+)
+
+// Methods of the plugin service
+const (
+	methodConfigure                = "Configure"
+	methodFetchAllUsers            = "FetchAllUsers"
+	methodFetchUserDetails         = "FetchUserDetails"
+	methodCreateUser               = "CreateUser"
+	methodDeleteUser               = "DeleteUser"
+	methodFetchAllTeams            = "FetchAllTeams"
+	methodFetchTeamDetails         = "FetchTeamDetails"
+	methodCreateTeam               = "CreateTeam"
+	methodDeleteTeamByID           = "DeleteTeamByID"
+	methodFetchTeamMembersByTeamID = "FetchTeamMembersByTeamID"
+	methodAddUserToTeam            = "AddUserToTeam"
+	methodRemoveUserFromTeam       = "RemoveUserFromTeam"
+)
+
+// Backend is the interface implemented by the plugins, it mirrors clients.Client
+type Backend interface {
+	FetchAllUsers(ctx context.Context) (map[string]*structs.User, map[string]*structs.User, error)
+	FetchUserDetails(ctx context.Context, userID string) (*structs.User, error)
+	CreateUser(ctx context.Context, u *structs.User) (*structs.User, error)
+	DeleteUser(ctx context.Context, userID string) error
+
+	FetchAllTeams(ctx context.Context) (map[string]structs.Team, error)
+	FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error)
+	CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error)
+	DeleteTeamByID(ctx context.Context, teamID string) error
+
+	FetchTeamMembersByTeamID(ctx context.Context, teamID string) (map[string]*structs.User, error)
+	AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error
+	RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error
+}
+
+// Factory creates the Backend of a plugin from the name and connection settings of a configured backend
+type Factory func(backendName string, connection map[string]interface{}) (Backend, error)
+
+// The messages are exchanged as google.protobuf.Struct, holding the JSON encoding of the types below
+
+// ConfigureRequest configures the plugin for a backend
+type ConfigureRequest struct {
+	// Connection holds the connection settings of the backend, without the ones of the plugin itself
+	Connection map[string]interface{} `json:"connection,omitempty"`
+}
+
+// ConfigureResponse is the response of Configure
+type ConfigureResponse struct {
+	ProtocolVersion int `json:"protocol_version"`
+	// Capabilities are the operations supported by the backend, declared by the backends implementing
+	// clients.CapabilityProvider, the operator assumes the default ones when they are missing
+	Capabilities *structs.Capabilities `json:"capabilities,omitempty"`
+}
+
+// UserRequest is the request of the calls on a user
+type UserRequest struct {
+	UserID string        `json:"user_id,omitempty"`
+	User   *structs.User `json:"user,omitempty"`
+}
+
+// UserResponse is the response of the calls returning a user
+type UserResponse struct {
+	User *structs.User `json:"user,omitempty"`
+}
+
+// UsersResponse is the response of the calls returning users
+type UsersResponse struct {
+	Users []*structs.User `json:"users"`
+}
+
+// TeamRequest is the request of the calls on a team
+type TeamRequest struct {
+	TeamID  string        `json:"team_id,omitempty"`
+	Team    *structs.Team `json:"team,omitempty"`
+	UserIDs []string      `json:"user_ids,omitempty"`
+}
+
+// TeamResponse is the response of the calls returning a team
+type TeamResponse struct {
+	Team *structs.Team `json:"team,omitempty"`
+}
+
+// TeamsResponse is the response of FetchAllTeams
+type TeamsResponse struct {
+	Teams []structs.Team `json:"teams"`
+}
+
+// Empty is the request and response of the calls without any
+type Empty struct{}
+
+// toStruct encodes a message as a google.protobuf.Struct
+func toStruct(message interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	encoded := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, encoded); err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+// fromStruct decodes a message from a google.protobuf.Struct
+func fromStruct(encoded *structpb.Struct, message interface{}) error {
+	data, err := protojson.Marshal(encoded)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, message)
+}
+
+func fullMethod(method string) string {
+	return "/" + ServiceName + "/" + method
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+func init() {
+	// either command or address is required, which NewClient checks
+	clients.Register("plugin", func(backend config.Backend, _ config.HttpClientConfig) (clients.Client, error) {
+		// anyone creating a Backend CR would otherwise run commands as the operator
+		if backend.Source != "" {
+			for _, key := range []string{CommandKey, ArgsKey, EnvKey} {
+				if _, found := backend.Connection[key]; found {
+					return nil, fmt.Errorf("invalid connection parameters for plugin backend %s defined by %s: "+
+						"%s is only allowed in the config files, use address instead", backend.Name, backend.Source, key)
+				}
+			}
+		}
+		return NewClient(backend.Name, backend.Connection)
+	})
+}
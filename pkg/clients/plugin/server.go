@@ -0,0 +1,261 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// AddressEnv is the environment variable of the address a plugin listens on, e.g. ':9000' or
+// 'unix:///run/usernaut/plugin.sock'. Plugins launched by the operator leave it unset and are
+// served over their stdin and stdout, so they must log to stderr only.
+const AddressEnv = "USERNAUT_PLUGIN_ADDRESS"
+
+// Serve serves the backends created by factory, on the address of AddressEnv when set
+// and over stdin and stdout otherwise. It returns once the operator closes stdin.
+func Serve(factory Factory) error {
+	address := os.Getenv(AddressEnv)
+	if address == "" {
+		return ServeListener(newStdioListener(), factory)
+	}
+
+	network := "tcp"
+	if path, ok := strings.CutPrefix(address, "unix://"); ok {
+		network, address = "unix", path
+	}
+	lis, err := net.Listen(network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+	return ServeListener(lis, factory)
+}
+
+// ServeListener serves the backends created by factory on the connections accepted by lis
+func ServeListener(lis net.Listener, factory Factory) error {
+	grpcServer := grpc.NewServer()
+	grpcServer.RegisterService(serviceDesc(), &server{factory: factory, backends: map[string]Backend{}})
+
+	err := grpcServer.Serve(lis)
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// server dispatches the calls to the backend they are for
+type server struct {
+	factory Factory
+
+	mu       sync.Mutex
+	backends map[string]Backend
+}
+
+func backendName(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, backendMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// backend returns the backend a call is for, the operator configures it again
+// on the FailedPrecondition returned when it isn't known, e.g. after a restart
+func (s *server) backend(ctx context.Context) (Backend, error) {
+	name := backendName(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	backend, ok := s.backends[name]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "backend %q is not configured", name)
+	}
+	return backend, nil
+}
+
+func (s *server) configure(ctx context.Context, req *ConfigureRequest) (interface{}, error) {
+	name := backendName(ctx)
+	backend, err := s.factory(name, req.Connection)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to configure backend %q: %v", name, err)
+	}
+
+	resp := &ConfigureResponse{ProtocolVersion: ProtocolVersion}
+	if provider, ok := backend.(clients.CapabilityProvider); ok {
+		capabilities := provider.Capabilities()
+		resp.Capabilities = &capabilities
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backends[name] = backend
+	return resp, nil
+}
+
+// serviceDesc describes the plugin service, every method taking and returning a google.protobuf.Struct
+func serviceDesc() *grpc.ServiceDesc {
+	return &grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*interface{})(nil),
+		Metadata:    "backend.proto",
+		Methods: []grpc.MethodDesc{
+			{MethodName: methodConfigure, Handler: handler(methodConfigure, (*server).configure)},
+			{MethodName: methodFetchAllUsers, Handler: backendHandler(methodFetchAllUsers, fetchAllUsers)},
+			{MethodName: methodFetchUserDetails, Handler: backendHandler(methodFetchUserDetails, fetchUserDetails)},
+			{MethodName: methodCreateUser, Handler: backendHandler(methodCreateUser, createUser)},
+			{MethodName: methodDeleteUser, Handler: backendHandler(methodDeleteUser, deleteUser)},
+			{MethodName: methodFetchAllTeams, Handler: backendHandler(methodFetchAllTeams, fetchAllTeams)},
+			{MethodName: methodFetchTeamDetails, Handler: backendHandler(methodFetchTeamDetails, fetchTeamDetails)},
+			{MethodName: methodCreateTeam, Handler: backendHandler(methodCreateTeam, createTeam)},
+			{MethodName: methodDeleteTeamByID, Handler: backendHandler(methodDeleteTeamByID, deleteTeamByID)},
+			{MethodName: methodFetchTeamMembersByTeamID,
+				Handler: backendHandler(methodFetchTeamMembersByTeamID, fetchTeamMembersByTeamID)},
+			{MethodName: methodAddUserToTeam, Handler: backendHandler(methodAddUserToTeam, addUserToTeam)},
+			{MethodName: methodRemoveUserFromTeam, Handler: backendHandler(methodRemoveUserFromTeam, removeUserFromTeam)},
+		},
+	}
+}
+
+func fetchAllUsers(ctx context.Context, b Backend, _ *Empty) (interface{}, error) {
+	byID, _, err := b.FetchAllUsers(ctx)
+	resp := &UsersResponse{Users: make([]*structs.User, 0, len(byID))}
+	for _, user := range byID {
+		resp.Users = append(resp.Users, user)
+	}
+	return resp, err
+}
+
+func fetchUserDetails(ctx context.Context, b Backend, req *UserRequest) (interface{}, error) {
+	user, err := b.FetchUserDetails(ctx, req.UserID)
+	return &UserResponse{User: user}, err
+}
+
+func createUser(ctx context.Context, b Backend, req *UserRequest) (interface{}, error) {
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+	user, err := b.CreateUser(ctx, req.User)
+	return &UserResponse{User: user}, err
+}
+
+func deleteUser(ctx context.Context, b Backend, req *UserRequest) (interface{}, error) {
+	return &Empty{}, b.DeleteUser(ctx, req.UserID)
+}
+
+func fetchAllTeams(ctx context.Context, b Backend, _ *Empty) (interface{}, error) {
+	teams, err := b.FetchAllTeams(ctx)
+	resp := &TeamsResponse{Teams: make([]structs.Team, 0, len(teams))}
+	for _, team := range teams {
+		resp.Teams = append(resp.Teams, team)
+	}
+	return resp, err
+}
+
+func fetchTeamDetails(ctx context.Context, b Backend, req *TeamRequest) (interface{}, error) {
+	team, err := b.FetchTeamDetails(ctx, req.TeamID)
+	return &TeamResponse{Team: team}, err
+}
+
+func createTeam(ctx context.Context, b Backend, req *TeamRequest) (interface{}, error) {
+	if req.Team == nil {
+		return nil, status.Error(codes.InvalidArgument, "team is required")
+	}
+	team, err := b.CreateTeam(ctx, req.Team)
+	return &TeamResponse{Team: team}, err
+}
+
+func deleteTeamByID(ctx context.Context, b Backend, req *TeamRequest) (interface{}, error) {
+	return &Empty{}, b.DeleteTeamByID(ctx, req.TeamID)
+}
+
+func fetchTeamMembersByTeamID(ctx context.Context, b Backend, req *TeamRequest) (interface{}, error) {
+	members, err := b.FetchTeamMembersByTeamID(ctx, req.TeamID)
+	resp := &UsersResponse{Users: make([]*structs.User, 0, len(members))}
+	for _, user := range members {
+		resp.Users = append(resp.Users, user)
+	}
+	return resp, err
+}
+
+func addUserToTeam(ctx context.Context, b Backend, req *TeamRequest) (interface{}, error) {
+	return &Empty{}, b.AddUserToTeam(ctx, req.TeamID, req.UserIDs)
+}
+
+func removeUserFromTeam(ctx context.Context, b Backend, req *TeamRequest) (interface{}, error) {
+	return &Empty{}, b.RemoveUserFromTeam(ctx, req.TeamID, req.UserIDs)
+}
+
+// methodHandler is the signature of the handlers of grpc.MethodDesc
+type methodHandler = func(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error)
+
+// handler returns the gRPC handler of a method, decoding its request from a google.protobuf.Struct
+// and encoding its response
+func handler[Req any](method string,
+	call func(*server, context.Context, *Req) (interface{}, error)) methodHandler {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error,
+		interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := &structpb.Struct{}
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+
+		handle := func(ctx context.Context, in interface{}) (interface{}, error) {
+			var req Req
+			if err := fromStruct(in.(*structpb.Struct), &req); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid %s request: %v", method, err)
+			}
+
+			resp, err := call(srv.(*server), ctx, &req)
+			if err != nil {
+				if _, isStatus := status.FromError(err); isStatus {
+					return nil, err
+				}
+				return nil, status.Error(codes.Unknown, err.Error())
+			}
+			return toStruct(resp)
+		}
+
+		if interceptor == nil {
+			return handle(ctx, in)
+		}
+		return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(method)}, handle)
+	}
+}
+
+// backendHandler returns the gRPC handler of a method calling the backend the call is for
+func backendHandler[Req any](method string,
+	call func(context.Context, Backend, *Req) (interface{}, error)) methodHandler {
+	return handler(method, func(s *server, ctx context.Context, req *Req) (interface{}, error) {
+		backend, err := s.backend(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return call(ctx, backend, req)
+	})
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// stopTimeout is how long a launched plugin is given to exit once its stdin is closed
+const stopTimeout = 5 * time.Second
+
+// pipeConn is a net.Conn over a pair of pipes, the stdin and stdout of a plugin
+type pipeConn struct {
+	io.Reader
+	io.Writer
+	closers []io.Closer
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newPipeConn(r io.Reader, w io.Writer, closers ...io.Closer) *pipeConn {
+	return &pipeConn{Reader: r, Writer: w, closers: closers, closed: make(chan struct{})}
+}
+
+func (c *pipeConn) Close() error {
+	var errs []error
+	c.closeOnce.Do(func() {
+		for _, closer := range c.closers {
+			errs = append(errs, closer.Close())
+		}
+		close(c.closed)
+	})
+	return errors.Join(errs...)
+}
+
+func (c *pipeConn) LocalAddr() net.Addr                { return pipeAddr{} }
+func (c *pipeConn) RemoteAddr() net.Addr               { return pipeAddr{} }
+func (c *pipeConn) SetDeadline(_ time.Time) error      { return nil }
+func (c *pipeConn) SetReadDeadline(_ time.Time) error  { return nil }
+func (c *pipeConn) SetWriteDeadline(_ time.Time) error { return nil }
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string { return "pipe" }
+func (pipeAddr) String() string  { return "stdio" }
+
+// stdioListener accepts a single connection, over stdin and stdout, and no other once it's closed
+type stdioListener struct {
+	conn *pipeConn
+
+	mu       sync.Mutex
+	accepted bool
+}
+
+func newStdioListener() *stdioListener {
+	return &stdioListener{conn: newPipeConn(os.Stdin, os.Stdout, os.Stdin, os.Stdout)}
+}
+
+func (l *stdioListener) Accept() (net.Conn, error) {
+	l.mu.Lock()
+	first := !l.accepted
+	l.accepted = true
+	l.mu.Unlock()
+
+	if first {
+		return l.conn, nil
+	}
+	<-l.conn.closed
+	return nil, net.ErrClosed
+}
+
+func (l *stdioListener) Close() error   { return l.conn.Close() }
+func (l *stdioListener) Addr() net.Addr { return pipeAddr{} }
+
+// pluginConn is the connection to a plugin, launched by the operator or listening on an address
+type pluginConn struct {
+	grpc *grpc.ClientConn
+	// fingerprint identifies the configuration the connection was made with
+	fingerprint string
+	// capabilities are the ones declared by the plugin on the last Configure
+	capabilities atomic.Pointer[structs.Capabilities]
+	// clients counts the clients sharing the connection, guarded by pluginsMu
+	clients int
+	// exited is closed once the launched plugin exits, nil for plugins listening on an address
+	exited chan struct{}
+	stdio  *pipeConn
+}
+
+// alive reports whether the launched plugin is still running
+func (p *pluginConn) alive() bool {
+	if p.exited == nil {
+		return true
+	}
+	select {
+	case <-p.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// close closes the connection, launched plugins exit once their stdin is closed
+func (p *pluginConn) close() {
+	_ = p.grpc.Close()
+	if p.stdio != nil {
+		_ = p.stdio.Close()
+	}
+}
+
+// launch starts the plugin command and connects to it over its stdin and stdout,
+// its stderr is forwarded to the operator log
+func launch(config *Config) (*pluginConn, error) {
+	log := logger.Logger(context.Background()).WithFields(logrus.Fields{
+		"service": "plugin",
+		"backend": config.Backend,
+		"command": config.Command,
+	})
+
+	// the pipes are created here rather than with cmd.StdinPipe and cmd.StdoutPipe,
+	// which Wait closes while the gRPC transport may still be using them
+	stdinReader, stdinWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		_ = stdinReader.Close()
+		_ = stdinWriter.Close()
+		return nil, err
+	}
+
+	cmd := exec.Command(config.Command, config.Args...)
+	// the environment of the operator holds its credentials, the plugin only gets the one configured
+	cmd.Env = make([]string, 0, len(config.Env))
+	for name, value := range config.Env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+	slices.Sort(cmd.Env)
+	cmd.Stdin = stdinReader
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = &logWriter{log: log}
+	err = cmd.Start()
+	// the ends of the pipes of the plugin are only needed by the plugin
+	_ = stdinReader.Close()
+	_ = stdoutWriter.Close()
+	if err != nil {
+		_ = stdinWriter.Close()
+		_ = stdoutReader.Close()
+		return nil, err
+	}
+	log.WithField("pid", cmd.Process.Pid).Info("plugin started")
+
+	exited := make(chan struct{})
+	stdio := newPipeConn(stdoutReader, stdinWriter, stdinWriter, stdoutReader)
+	go func() {
+		err := cmd.Wait()
+		close(exited)
+		_ = stdio.Close()
+		log.WithError(err).Info("plugin exited")
+	}()
+	go func() {
+		// plugins exit on their own once their stdin is closed, those that don't are killed
+		<-stdio.closed
+		select {
+		case <-exited:
+		case <-time.After(stopTimeout):
+			_ = cmd.Process.Kill()
+		}
+	}()
+
+	// the pipes only make one connection, gRPC doesn't get to reconnect
+	var dialed sync.Once
+	grpcConn, err := grpc.NewClient("passthrough:///"+config.Backend,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			var conn net.Conn
+			dialed.Do(func() { conn = stdio })
+			if conn == nil {
+				return nil, errors.New("plugin connection closed")
+			}
+			return conn, nil
+		}))
+	if err != nil {
+		_ = stdio.Close()
+		return nil, err
+	}
+
+	return &pluginConn{grpc: grpcConn, exited: exited, stdio: stdio}, nil
+}
+
+// logWriter logs every line written by a plugin on its stderr
+type logWriter struct {
+	log *logrus.Entry
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		w.log.Info(line)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultPort     = "5432"
+	defaultDatabase = "postgres"
+	defaultSSLMode  = "require"
+	// maxOpenConns limits the connections of the pool of a server
+	maxOpenConns = 5
+)
+
+// NewClient creates a new PostgreSQL client with the given configuration
+func NewClient(connection map[string]interface{}) (*PostgresClient, error) {
+	// Extract connection parameters
+	config := PostgresConfig{
+		Port:     defaultPort,
+		Database: defaultDatabase,
+		SSLMode:  defaultSSLMode,
+	}
+	config.Host, _ = connection["host"].(string)
+	config.User, _ = connection["user"].(string)
+	config.Password, _ = connection["password"].(string)
+	if config.Host == "" || config.User == "" {
+		return nil, errors.New("missing required connection parameters for postgres backend: host and user are required")
+	}
+
+	if port, ok := connection["port"]; ok && port != nil && fmt.Sprint(port) != "" {
+		config.Port = fmt.Sprint(port)
+	}
+	if database, _ := connection["database"].(string); database != "" {
+		config.Database = database
+	}
+	if sslMode, _ := connection["sslmode"].(string); sslMode != "" {
+		config.SSLMode = sslMode
+	}
+	config.UserAttribute, _ = connection["user_attribute"].(string)
+
+	db, err := openDB(config.connectionString())
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresClient{
+		config: &config,
+		db:     db,
+	}, nil
+}
+
+// openDB opens the connection pool of the client, the connections are established lazily, on the first query
+func openDB(connectionString string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+	db.SetMaxOpenConns(maxOpenConns)
+	return db, nil
+}
+
+// connectionString returns the URL the server is connected to with
+func (c *PostgresConfig) connectionString() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, c.Port),
+		Path:     "/" + c.Database,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+// quoteIdentifiers quotes the role names for use in a statement
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, pq.QuoteIdentifier(name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// GetConfig returns the client configuration
+func (c *PostgresClient) GetConfig() *PostgresConfig {
+	return c.config
+}
+
+// Close closes the connection pool, once the client is replaced or its backend removed
+func (c *PostgresClient) Close() error {
+	return c.db.Close()
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const membersQuery = `SELECT m.rolname, COALESCE(shobj_description(m.oid, 'pg_authid'), '')
+FROM pg_auth_members am
+JOIN pg_roles r ON r.oid = am.roleid
+JOIN pg_roles m ON m.oid = am.member
+WHERE r.rolname = $1`
+
+// FetchTeamMembersByTeamID fetches the direct members of the group role, keyed by role name
+func (c *PostgresClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "postgres",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	rows, err := c.db.QueryContext(ctx, membersQuery, teamID)
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, fmt.Errorf("failed to list members of group role %s: %w", teamID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	members := make(map[string]*structs.User)
+	for rows.Next() {
+		var name, comment string
+		if err := rows.Scan(&name, &comment); err != nil {
+			return nil, fmt.Errorf("failed to read member of group role %s: %w", teamID, err)
+		}
+		members[name] = toUser(name, comment)
+	}
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, fmt.Errorf("failed to list members of group role %s: %w", teamID, err)
+	}
+
+	return members, nil
+}
+
+// AddUserToTeam grants the group role to the users
+func (c *PostgresClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return c.updateMembership(ctx, "GRANT %s TO %s", teamID, userIDs)
+}
+
+// RemoveUserFromTeam revokes the group role from the users
+func (c *PostgresClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	return c.updateMembership(ctx, "REVOKE %s FROM %s", teamID, userIDs)
+}
+
+// updateMembership grants or revokes the group role to all the users in a single statement
+func (c *PostgresClient) updateMembership(ctx context.Context, statement, teamID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "postgres",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("updating team membership")
+
+	query := fmt.Sprintf(statement, pq.QuoteIdentifier(teamID), quoteIdentifiers(userIDs))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		log.WithError(err).Error("error updating team membership")
+		return fmt.Errorf("failed to update members of group role %s: %w", teamID, err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// The teams are NOLOGIN group roles, identified by name.
+
+const listTeamsQuery = `SELECT r.rolname
+FROM pg_roles r
+WHERE NOT r.rolcanlogin AND NOT r.rolsuper AND r.rolname NOT LIKE 'pg\_%'`
+
+const teamQuery = `SELECT r.rolname, r.rolcanlogin
+FROM pg_roles r
+WHERE r.rolname = $1`
+
+// FetchAllTeams fetches the group roles of the server, the built-in and superuser roles excluded
+func (c *PostgresClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("service", "postgres")
+
+	log.Info("fetching all teams")
+	rows, err := c.db.QueryContext(ctx, listTeamsQuery)
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, fmt.Errorf("failed to list group roles: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	teams := make(map[string]structs.Team)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read group role: %w", err)
+		}
+		teams[name] = structs.Team{ID: name, Name: name}
+	}
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, fmt.Errorf("failed to list group roles: %w", err)
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates the NOLOGIN group role of the team
+func (c *PostgresClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "postgres",
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	existing, err := c.FetchTeamDetails(ctx, team.Name)
+	if err == nil {
+		log.Info("team already exists")
+		return existing, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if _, err := c.db.ExecContext(ctx, fmt.Sprintf("CREATE ROLE %s NOLOGIN", pq.QuoteIdentifier(team.Name))); err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, fmt.Errorf("failed to create group role %s: %w", team.Name, err)
+	}
+
+	log.Info("team created successfully")
+	return &structs.Team{ID: team.Name, Name: team.Name}, nil
+}
+
+// FetchTeamDetails fetches the group role by name, the error wraps sql.ErrNoRows when it doesn't exist
+func (c *PostgresClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	var name string
+	var canLogin bool
+	if err := c.db.QueryRowContext(ctx, teamQuery, teamID).Scan(&name, &canLogin); err != nil {
+		return nil, fmt.Errorf("failed to fetch group role %s: %w", teamID, err)
+	}
+	// a login role with the team name would grant the team's privileges to whoever logs in with it
+	if canLogin {
+		return nil, fmt.Errorf("role %s is a login role, not a group role", teamID)
+	}
+	return &structs.Team{ID: name, Name: name}, nil
+}
+
+// DeleteTeamByID drops the group role, which fails while it owns objects
+func (c *PostgresClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "postgres",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	if _, err := c.db.ExecContext(ctx, "DROP ROLE IF EXISTS "+pq.QuoteIdentifier(teamID)); err != nil {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to drop group role %s: %w", teamID, err)
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
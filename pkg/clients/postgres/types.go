@@ -0,0 +1,37 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import "database/sql"
+
+// PostgresConfig holds the configuration for PostgreSQL client
+type PostgresConfig struct {
+	Host     string
+	Port     string
+	Database string
+	User     string
+	Password string
+	SSLMode  string
+	// UserAttribute is the LDAP attribute holding the login role name, the LDAP uid when empty
+	UserAttribute string
+}
+
+// PostgresClient manages the group and login roles of a PostgreSQL server
+type PostgresClient struct {
+	config *PostgresConfig
+	db     *sql.DB
+}
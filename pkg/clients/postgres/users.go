@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// The users are LOGIN roles, their email is kept in the comment of the role
+// as PostgreSQL has no other place to map them to LDAP with.
+
+const listUsersQuery = `SELECT r.rolname, COALESCE(shobj_description(r.oid, 'pg_authid'), '')
+FROM pg_roles r
+WHERE r.rolcanlogin AND NOT r.rolsuper AND r.rolname NOT LIKE 'pg\_%'`
+
+const userQuery = `SELECT r.rolname, COALESCE(shobj_description(r.oid, 'pg_authid'), '')
+FROM pg_roles r
+WHERE r.rolcanlogin AND r.rolname = $1`
+
+// FetchAllUsers fetches the login roles of the server, superusers excluded
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
+func (c *PostgresClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "postgres")
+
+	log.Info("fetching all users")
+	rows, err := c.db.QueryContext(ctx, listUsersQuery)
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, fmt.Errorf("failed to list login roles: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	resultByID := make(map[string]*structs.User)
+	resultByEmail := make(map[string]*structs.User)
+	for rows.Next() {
+		var name, comment string
+		if err := rows.Scan(&name, &comment); err != nil {
+			return nil, nil, fmt.Errorf("failed to read login role: %w", err)
+		}
+		user := toUser(name, comment)
+		resultByID[user.ID] = user
+		if user.Email != "" {
+			resultByEmail[user.Email] = user
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, fmt.Errorf("failed to list login roles: %w", err)
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, resultByEmail, nil
+}
+
+// CreateUser creates the LOGIN role of the user, commented with its email. The role has no password,
+// the server authenticates the users against LDAP or Kerberos.
+func (c *PostgresClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	name := user.GetUserName()
+	if c.config.UserAttribute != "" {
+		name = user.GetAttribute(c.config.UserAttribute)
+	}
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "postgres",
+		"role":    name,
+	})
+
+	log.Info("creating user")
+	if name == "" {
+		return nil, fmt.Errorf("no role name found for user %s", user.GetEmail())
+	}
+
+	existing, err := c.FetchUserDetails(ctx, name)
+	if err == nil {
+		log.Info("user already exists")
+		return existing, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	email := strings.ToLower(user.GetEmail())
+	statements := []string{fmt.Sprintf("CREATE ROLE %s LOGIN", pq.QuoteIdentifier(name))}
+	if email != "" {
+		statements = append(statements,
+			fmt.Sprintf("COMMENT ON ROLE %s IS %s", pq.QuoteIdentifier(name), pq.QuoteLiteral(email)))
+	}
+	if err := c.execInTx(ctx, statements...); err != nil {
+		log.WithError(err).Error("error creating user")
+		return nil, fmt.Errorf("failed to create login role %s: %w", name, err)
+	}
+
+	log.Info("user created successfully")
+	return toUser(name, email), nil
+}
+
+// FetchUserDetails fetches the login role by name, the error wraps sql.ErrNoRows when it doesn't exist
+func (c *PostgresClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	var name, comment string
+	if err := c.db.QueryRowContext(ctx, userQuery, userID).Scan(&name, &comment); err != nil {
+		return nil, fmt.Errorf("failed to fetch login role %s: %w", userID, err)
+	}
+	return toUser(name, comment), nil
+}
+
+// DeleteUser drops the login role, which fails while it owns objects
+func (c *PostgresClient) DeleteUser(ctx context.Context, userID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "postgres",
+		"role":    userID,
+	})
+
+	log.Info("deleting user")
+	if _, err := c.db.ExecContext(ctx, "DROP ROLE IF EXISTS "+pq.QuoteIdentifier(userID)); err != nil {
+		log.WithError(err).Error("error deleting user")
+		return fmt.Errorf("failed to drop login role %s: %w", userID, err)
+	}
+
+	log.Info("user deleted successfully")
+	return nil
+}
+
+// execInTx runs the statements in a transaction
+func (c *PostgresClient) execInTx(ctx context.Context, statements ...string) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+	}
+	return tx.Commit()
+}
+
+func toUser(name, comment string) *structs.User {
+	user := &structs.User{ID: name, UserName: name}
+	if strings.Contains(comment, "@") {
+		user.Email = strings.ToLower(strings.TrimSpace(comment))
+	}
+	return user
+}
@@ -20,9 +20,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/request"
 	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
 	"github.com/redhat-data-and-ai/usernaut/pkg/utils"
@@ -106,5 +108,12 @@ func (rC *RoverClient) sendRequest(ctx context.Context, url string, method strin
 	}
 	req.SetHeaders(headers)
 
-	return req.MakeRequest(rC.client, methodName, "redhat_rover")
+	resp, header, status, err := req.MakeRequestWithHeader(rC.client, methodName, "redhat_rover")
+	if err != nil {
+		return nil, status, clients.Transient(err)
+	}
+	if status == http.StatusTooManyRequests {
+		return resp, status, clients.StatusError(status, header, "rover rate limited %s: %s", methodName, string(resp))
+	}
+	return resp, status, nil
 }
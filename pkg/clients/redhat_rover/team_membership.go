@@ -25,6 +25,7 @@ import (
 
 	ot "github.com/opentracing/opentracing-go"
 
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
 )
@@ -48,7 +49,8 @@ func (rC *RoverClient) FetchTeamMembersByTeamID(ctx context.Context, teamID stri
 
 	if respCode != http.StatusOK {
 		log.Error("failed to fetch rover group members")
-		return nil, errors.New("failed to fetch rover group members with response code: " + http.StatusText(respCode))
+		return nil, clients.StatusError(respCode, nil,
+			"failed to fetch rover group members with response code: %s", http.StatusText(respCode))
 	}
 
 	var roverGroup RoverGroup
@@ -112,7 +114,8 @@ func (rC *RoverClient) modify(
 
 	if respCode != http.StatusOK {
 		log.Errorf("failed to %s users in rover group", action)
-		return fmt.Errorf("failed to %s users in rover group with response code: %s", action, http.StatusText(respCode))
+		return clients.StatusError(respCode, nil,
+			"failed to %s users in rover group with response code: %s", action, http.StatusText(respCode))
 	}
 
 	return nil
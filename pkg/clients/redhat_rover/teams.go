@@ -24,6 +24,7 @@ import (
 
 	ot "github.com/opentracing/opentracing-go"
 
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
 )
@@ -38,6 +39,12 @@ func (rC *RoverClient) FetchTeamDetails(ctx context.Context, teamID string) (*st
 	return nil, fmt.Errorf("fetching team details is not supported")
 }
 
+// Capabilities returns the operations supported by Rover, which is the LDAP: the users are neither
+// created nor listed, their user name is their ID, and the teams are resolved by name instead of listed
+func (rC *RoverClient) Capabilities() structs.Capabilities {
+	return structs.Capabilities{}
+}
+
 // CreateTeam creates a new team in Rover. If the team already exists, it returns the existing team details.
 func (rC *RoverClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "backend.redhatrover.CreateTeam")
@@ -64,24 +71,17 @@ func (rC *RoverClient) CreateTeam(ctx context.Context, team *structs.Team) (*str
 		http.MethodPost, roverGroup,
 		headers, "backend.redhatrover.CreateTeam")
 	if err != nil {
-		if respCode != http.StatusForbidden {
-			return nil, err
-		}
+		return nil, err
 	}
 
-	// API return 403 Forbidden if the group already exists.
-	if respCode == http.StatusForbidden {
+	switch respCode {
+	case http.StatusCreated:
+	case http.StatusForbidden:
+		// API return 403 Forbidden if the group already exists, it is adopted as its ID is its name
 		log.WithField("response", string(resp)).Warn("Rover group already exists, fetching existing group details")
-		return &structs.Team{
-			ID:          team.Name,
-			Name:        team.Name,
-			Description: team.Description,
-		}, nil
-	}
-
-	if respCode != http.StatusCreated {
+	default:
 		log.Error("failed to create rover group")
-		return nil, fmt.Errorf("failed to create rover group: %s", string(resp))
+		return nil, clients.StatusError(respCode, nil, "failed to create rover group: %s", string(resp))
 	}
 
 	return &structs.Team{
@@ -116,7 +116,7 @@ func (rC *RoverClient) DeleteTeamByID(ctx context.Context, teamID string) error
 	// Accept both 200 (OK) and 204 (No Content) as successful deletion
 	if respCode != http.StatusOK && respCode != http.StatusNoContent {
 		log.Error("failed to delete rover group")
-		return fmt.Errorf("failed to delete rover group: %s", string(resp))
+		return clients.StatusError(respCode, nil, "failed to delete rover group: %s", string(resp))
 	}
 
 	log.Info("Rover group deleted successfully")
@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/config"
+)
+
+// Factory builds the client of a backend from its configuration and the HTTP client settings
+// that apply to it, either its own or the application wide ones
+type Factory func(backend config.Backend, httpClient config.HttpClientConfig) (Client, error)
+
+var (
+	// factories holds the factories of the backend types, registered by the backend packages
+	factories   = map[string]Factory{}
+	factoriesMu sync.RWMutex
+)
+
+// Register makes the backends of the given type available, built by factory. requiredConnectionFields
+// are checked by the configuration validation, their names are lower case as the config loader is case
+// insensitive. It is called from the init function of the backend packages and panics on duplicates.
+func Register(backendType string, factory Factory, requiredConnectionFields ...string) {
+	backendType = strings.ToLower(backendType)
+
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	if _, ok := factories[backendType]; ok {
+		panic(fmt.Sprintf("clients: backend type %q registered twice", backendType))
+	}
+	factories[backendType] = factory
+	config.RegisterBackendType(backendType, requiredConnectionFields...)
+}
+
+func getFactory(backendType string) (Factory, bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	factory, ok := factories[strings.ToLower(backendType)]
+	return factory, ok
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+)
+
+const (
+	// defaultPageSize is the number of resources requested per page when not configured
+	defaultPageSize = 100
+	contentType     = "application/scim+json"
+)
+
+// NewClient creates a new SCIM client with the given configuration
+func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionPoolConfig,
+	hystrixCfg httpclient.HystrixResiliencyConfig) (*SCIMClient, error) {
+
+	// Extract connection parameters
+	token, _ := connection["token"].(string)
+	baseURL, _ := connection["base_url"].(string)
+	if token == "" || baseURL == "" {
+		return nil, errors.New("missing required connection parameters for scim backend: token and base_url are required")
+	}
+
+	config := SCIMConfig{
+		Token:            token,
+		BaseURL:          strings.TrimSuffix(baseURL, "/"),
+		PageSize:         defaultPageSize,
+		AttributeMapping: make(map[string]string, len(defaultAttributeMapping)),
+	}
+	for attribute, ldapAttribute := range defaultAttributeMapping {
+		config.AttributeMapping[attribute] = ldapAttribute
+	}
+
+	if pageSize, ok := connection["page_size"]; ok {
+		size, err := strconv.Atoi(fmt.Sprint(pageSize))
+		if err != nil || size < 1 {
+			return nil, fmt.Errorf("invalid page_size %v for scim backend", pageSize)
+		}
+		config.PageSize = size
+	}
+
+	// attribute_mapping overrides the LDAP attribute of SCIM attributes, an empty value unsets it
+	if mapping, ok := connection["attribute_mapping"].(map[string]interface{}); ok {
+		for attribute, ldapAttribute := range flattenMapping("", mapping) {
+			if _, supported := defaultAttributeMapping[attribute]; !supported && attribute != AttrGivenName {
+				return nil, fmt.Errorf("unsupported scim attribute %q in attribute_mapping", attribute)
+			}
+			config.AttributeMapping[attribute] = ldapAttribute
+		}
+	}
+	if config.AttributeMapping[AttrUserName] == "" {
+		return nil, errors.New("attribute_mapping of the scim backend must map userName")
+	}
+
+	client, err := httpclient.InitializeClient(
+		"scim",
+		poolCfg,
+		hystrixCfg,
+		heimdall.NewRetrier(heimdall.NewConstantBackoff(100*time.Millisecond, 50*time.Millisecond)), 3,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize http client: %w", err)
+	}
+
+	return &SCIMClient{
+		config: &config,
+		client: client,
+	}, nil
+}
+
+// flattenMapping flattens the attribute mapping into lower case dotted attribute names,
+// the config loader splits keys like name.givenName into nested maps
+func flattenMapping(prefix string, mapping map[string]interface{}) map[string]string {
+	flat := make(map[string]string, len(mapping))
+	for key, value := range mapping {
+		key = prefix + strings.ToLower(key)
+		if nested, ok := value.(map[string]interface{}); ok {
+			for nestedKey, nestedValue := range flattenMapping(key+".", nested) {
+				flat[nestedKey] = nestedValue
+			}
+			continue
+		}
+		flat[key] = fmt.Sprint(value)
+		if value == nil {
+			flat[key] = ""
+		}
+	}
+	return flat
+}
+
+// makeRequest sends a request to the SCIM service provider with bearer token authentication
+func (c *SCIMClient) makeRequest(ctx context.Context, endpoint,
+	method string, body interface{}) ([]byte, http.Header, int, error) {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	req, err := request.NewRequest(ctx, method, c.config.BaseURL+endpoint, requestBody)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	req.SetHeaders(map[string]string{
+		"Authorization": "Bearer " + c.config.Token,
+		"Content-Type":  contentType,
+		"Accept":        contentType,
+	})
+
+	resp, header, status, err := req.MakeRequestWithHeader(c.client, method, "scim")
+	if err != nil {
+		return nil, nil, status, clients.Transient(err)
+	}
+	return resp, header, status, nil
+}
+
+// statusError returns the error of a response with an unexpected status. Some service providers
+// answer a 400 rather than a 409 to the resources already existing, telling it with the scimType.
+func statusError(status int, header http.Header, resp []byte, format string, args ...interface{}) error {
+	var scimErr SCIMError
+	if json.Unmarshal(resp, &scimErr) == nil && scimErr.ScimType == "uniqueness" {
+		return clients.NewError(clients.ErrAlreadyExists, format+", detail: %s", append(args, scimErr.Detail)...)
+	}
+	return clients.StatusError(status, header, format+", status: %s, body: %s",
+		append(args, http.StatusText(status), string(resp))...)
+}
+
+// fetchAllWithPagination calls processPage with the resources of every page of a list endpoint,
+// using the startIndex and count parameters of the SCIM protocol
+func (c *SCIMClient) fetchAllWithPagination(ctx context.Context,
+	endpoint string, processPage func(json.RawMessage) (int, error)) error {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	// SCIM indexes are 1-based
+	startIndex := 1
+	for {
+		pageURL := fmt.Sprintf("%s%sstartIndex=%d&count=%d", endpoint, separator, startIndex, c.config.PageSize)
+		resp, header, status, err := c.makeRequest(ctx, pageURL, http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return statusError(status, header, resp, "failed to fetch data from %s", endpoint)
+		}
+
+		var page ListResponse
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return fmt.Errorf("failed to parse list response: %w", err)
+		}
+
+		count := 0
+		if len(page.Resources) > 0 {
+			if count, err = processPage(page.Resources); err != nil {
+				return err
+			}
+		}
+
+		startIndex += count
+		if count == 0 || startIndex > page.TotalResults {
+			return nil
+		}
+	}
+}
+
+// filterEq returns a SCIM filter matching the attribute to the value, e.g. userName eq "jdoe"
+func filterEq(attribute, value string) string {
+	escaped := strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `"`, `\"`)
+	return fmt.Sprintf(`%s eq "%s"`, attribute, escaped)
+}
+
+// GetConfig returns the client configuration
+func (c *SCIMClient) GetConfig() *SCIMConfig {
+	return c.config
+}
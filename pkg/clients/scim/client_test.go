@@ -0,0 +1,302 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients/clienttest"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMuxClient returns a client of a service provider routing the calls with mux
+func newMuxClient(t *testing.T, mux *http.ServeMux, connection map[string]interface{}) *SCIMClient {
+	server := clienttest.NewServer(t, mux, func(w http.ResponseWriter, r *http.Request) bool {
+		w.Header().Set("Content-Type", contentType)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			clienttest.Reply(w, http.StatusUnauthorized, scimError(http.StatusUnauthorized))
+			return false
+		}
+		return true
+	})
+
+	connection["token"] = "token"
+	connection["base_url"] = server.URL + "/scim/v2"
+	client, err := NewClient(connection, clienttest.PoolConfig, clienttest.HystrixConfig)
+	require.NoError(t, err)
+	return client
+}
+
+func scimError(status int) interface{} {
+	return SCIMError{Schemas: []string{ErrorSchema}, Status: strconv.Itoa(status)}
+}
+
+func listOf(total int, resources interface{}) ListResponse {
+	raw, _ := json.Marshal(resources)
+	return ListResponse{Schemas: []string{ListResponseSchema}, TotalResults: total, Resources: raw}
+}
+
+func TestNewClient(t *testing.T) {
+	poolCfg := clienttest.PoolConfig
+	hystrixCfg := clienttest.HystrixConfig
+
+	_, err := NewClient(map[string]interface{}{"token": "token"}, poolCfg, hystrixCfg)
+	assert.Error(t, err)
+
+	client, err := NewClient(map[string]interface{}{
+		"token":     "token",
+		"base_url":  "https://scim.example.com/v2/",
+		"page_size": 50,
+		// the config loader splits dotted keys into nested maps
+		"attribute_mapping": map[string]interface{}{
+			"externalid": "employeeNumber",
+			"name":       map[string]interface{}{"givenname": "givenName"},
+			"emails":     "",
+		},
+	}, poolCfg, hystrixCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "https://scim.example.com/v2", client.GetConfig().BaseURL)
+	assert.Equal(t, 50, client.GetConfig().PageSize)
+	assert.Equal(t, "employeeNumber", client.GetConfig().AttributeMapping[AttrExternalID])
+	assert.Equal(t, "givenName", client.GetConfig().AttributeMapping[AttrGivenName])
+	assert.Equal(t, "", client.GetConfig().AttributeMapping[AttrEmail])
+	assert.Equal(t, "mail", client.GetConfig().AttributeMapping[AttrUserName])
+
+	for _, connection := range []map[string]interface{}{
+		{"page_size": "zero"},
+		{"attribute_mapping": map[string]interface{}{"nickname": "uid"}},
+		{"attribute_mapping": map[string]interface{}{"username": ""}},
+	} {
+		connection["token"] = "token"
+		connection["base_url"] = "https://scim.example.com/v2"
+		_, err := NewClient(connection, poolCfg, hystrixCfg)
+		assert.Error(t, err, connection)
+	}
+}
+
+func TestPaginationCappedPages(t *testing.T) {
+	users := []SCIMUser{
+		{ID: "u1", UserName: "jdoe", Emails: []SCIMMultiValued{{Value: "other@example.com"},
+			{Value: "JDoe@example.com", Primary: true}}},
+		{ID: "u2", UserName: "asmith", Emails: []SCIMMultiValued{{Value: "asmith@example.com"}}},
+		{ID: "u3", UserName: "bot"},
+		{ID: "u4", UserName: "bwayne", Emails: []SCIMMultiValued{{Value: "bwayne@example.com"}}},
+		{ID: "u5", UserName: "ckent"},
+	}
+	var startIndexes []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /scim/v2/Users", func(w http.ResponseWriter, r *http.Request) {
+		startIndexes = append(startIndexes, r.URL.Query().Get("startIndex"))
+		assert.Equal(t, "10", r.URL.Query().Get("count"))
+		// the service providers may return fewer resources than requested
+		start, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+		clienttest.Reply(w, http.StatusOK, listOf(len(users), users[start-1:min(start+1, len(users))]))
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{"page_size": 10})
+
+	byID, byEmail, err := client.FetchAllUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3", "5"}, startIndexes)
+	assert.Len(t, byID, 5)
+	assert.Len(t, byEmail, 3)
+	assert.Equal(t, "u1", byEmail["jdoe@example.com"].ID)
+}
+
+func TestPaginationStopsOnEmptyPage(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /scim/v2/Groups", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// members are left out of the listing, they can make the pages very large
+		assert.Equal(t, "members", r.URL.Query().Get("excludedAttributes"))
+		if r.URL.Query().Get("startIndex") != "1" {
+			// a total overestimated by the provider mustn't loop forever
+			clienttest.Reply(w, http.StatusOK, ListResponse{Schemas: []string{ListResponseSchema}, TotalResults: 10})
+			return
+		}
+		clienttest.Reply(w, http.StatusOK, listOf(10, []SCIMGroup{{ID: "g1", DisplayName: "data-team"}}))
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+
+	teams, err := client.FetchAllTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]structs.Team{"data-team": {ID: "g1", Name: "data-team"}}, teams)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCreateUser(t *testing.T) {
+	var created []SCIMUser
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /scim/v2/Users", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("filter") {
+		case `userName eq "jdoe@example.com"`:
+			// the userName is case insensitive
+			clienttest.Reply(w, http.StatusOK, listOf(1, []SCIMUser{{ID: "u1", UserName: "JDoe@example.com"}}))
+		case `userName eq "a\"b@example.com"`:
+			clienttest.Reply(w, http.StatusOK, listOf(0, []SCIMUser{}))
+		default:
+			clienttest.Reply(w, http.StatusOK, ListResponse{Schemas: []string{ListResponseSchema}})
+		}
+	})
+	mux.HandleFunc("POST /scim/v2/Users", func(w http.ResponseWriter, r *http.Request) {
+		var user SCIMUser
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+		created = append(created, user)
+		user.ID = "u" + strconv.Itoa(len(created)+10)
+		clienttest.Reply(w, http.StatusCreated, user)
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{
+		"attribute_mapping": map[string]interface{}{"name": map[string]interface{}{"givenName": "givenName"}},
+	})
+	ctx := context.Background()
+
+	user, err := client.CreateUser(ctx, &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.ID)
+	assert.Empty(t, created)
+
+	user, err = client.CreateUser(ctx, &structs.User{UserName: "ab", Email: `a"b@example.com`})
+	require.NoError(t, err)
+	assert.Equal(t, "u11", user.ID)
+
+	_, err = client.CreateUser(ctx, &structs.User{
+		UserName:   "bwayne",
+		Email:      "bwayne@example.com",
+		FirstName:  "Bruce Wayne",
+		LastName:   "Wayne",
+		Attributes: map[string]string{"givenName": "Bruce"},
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.Equal(t, SCIMUser{
+		Schemas:     []string{UserSchema},
+		UserName:    "bwayne@example.com",
+		ExternalID:  "bwayne",
+		DisplayName: "Bruce Wayne",
+		Name:        &SCIMName{GivenName: "Bruce", FamilyName: "Wayne"},
+		Emails:      []SCIMMultiValued{{Value: "bwayne@example.com", Type: "work", Primary: true}},
+		Active:      created[1].Active,
+	}, created[1])
+	assert.True(t, *created[1].Active)
+
+	// the userName is mapped from the email by default
+	_, err = client.CreateUser(ctx, &structs.User{UserName: "nomail"})
+	assert.ErrorContains(t, err, "userName is required")
+}
+
+func TestUniquenessErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /scim/v2/Users", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusOK, listOf(0, []SCIMUser{}))
+	})
+	// created by someone else between the lookup and the creation
+	mux.HandleFunc("POST /scim/v2/Users", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusConflict,
+			SCIMError{Schemas: []string{ErrorSchema}, Status: "409", ScimType: "uniqueness"})
+	})
+	// some service providers answer a bad request to the duplicates
+	mux.HandleFunc("POST /scim/v2/Groups", func(w http.ResponseWriter, r *http.Request) {
+		clienttest.Reply(w, http.StatusBadRequest, SCIMError{
+			Schemas:  []string{ErrorSchema},
+			Status:   "400",
+			ScimType: "uniqueness",
+			Detail:   "displayName must be unique",
+		})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	_, err := client.CreateUser(ctx, &structs.User{UserName: "jdoe", Email: "jdoe@example.com"})
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+
+	_, err = client.CreateTeam(ctx, &structs.Team{Name: "data-team"})
+	assert.ErrorIs(t, err, clients.ErrAlreadyExists)
+	assert.ErrorContains(t, err, "displayName must be unique")
+}
+
+func TestPatchMembers(t *testing.T) {
+	var patches []PatchRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /scim/v2/Groups/{group}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "g1", r.PathValue("group"))
+		assert.Equal(t, contentType, r.Header.Get("Content-Type"))
+		var patch PatchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+		patches = append(patches, patch)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	ctx := context.Background()
+
+	require.NoError(t, client.AddUserToTeam(ctx, "g1", []string{"u1", "u2"}))
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "g1", []string{"u1", `u"3`}))
+	require.NoError(t, client.RemoveUserFromTeam(ctx, "g1", nil))
+
+	// a single request for all the users, none without users
+	require.Len(t, patches, 2)
+	assert.Equal(t, []string{PatchOpSchema}, patches[0].Schemas)
+	assert.Equal(t, []PatchOperation{{Op: "add", Path: "members", Value: []interface{}{
+		map[string]interface{}{"value": "u1"}, map[string]interface{}{"value": "u2"},
+	}}}, patches[0].Operations)
+	assert.Equal(t, []PatchOperation{
+		{Op: "remove", Path: `members[value eq "u1"]`},
+		{Op: "remove", Path: `members[value eq "u\"3"]`},
+	}, patches[1].Operations)
+}
+
+func TestFetchTeamMembers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /scim/v2/Groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "members", r.URL.Query().Get("attributes"))
+		clienttest.Reply(w, http.StatusOK, SCIMGroup{ID: "g1", DisplayName: "data-team",
+			Members: []SCIMMultiValued{{Value: "u1", Display: "John Doe"}}})
+	})
+	client := newMuxClient(t, mux, map[string]interface{}{})
+
+	members, err := client.FetchTeamMembersByTeamID(context.Background(), "g1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*structs.User{"u1": {ID: "u1", DisplayName: "John Doe"}}, members)
+}
+
+func TestStatusErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	client := newMuxClient(t, mux, map[string]interface{}{})
+	clienttest.StatusTest{
+		Route: "GET /scim/v2/Groups/g1",
+		Call: func(ctx context.Context) error {
+			_, err := client.FetchTeamDetails(ctx, "g1")
+			return err
+		},
+		ErrorBody:   scimError,
+		DeleteRoute: "DELETE /scim/v2/Groups/g2",
+		Delete: func(ctx context.Context) error {
+			return client.DeleteTeamByID(ctx, "g2")
+		},
+	}.Run(t, mux)
+}
+
+func TestFilterEq(t *testing.T) {
+	assert.Equal(t, `userName eq "jdoe"`, filterEq("userName", "jdoe"))
+	assert.Equal(t, `userName eq "a\"b\\c"`, filterEq("userName", `a"b\c`))
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamMembersByTeamID fetches the members of the group, keyed by user ID
+func (c *SCIMClient) FetchTeamMembersByTeamID(ctx context.Context,
+	teamID string) (map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "scim",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team members by team ID")
+
+	group, err := c.fetchGroup(ctx, teamID, "attributes=members")
+	if err != nil {
+		log.WithError(err).Error("error fetching team members by team ID")
+		return nil, err
+	}
+
+	members := make(map[string]*structs.User, len(group.Members))
+	for _, member := range group.Members {
+		members[member.Value] = &structs.User{
+			ID:          member.Value,
+			DisplayName: member.Display,
+		}
+	}
+
+	return members, nil
+}
+
+// AddUserToTeam adds the users to the group with a single PATCH request
+func (c *SCIMClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "scim",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("adding users to team")
+
+	members := make([]SCIMMultiValued, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, SCIMMultiValued{Value: userID})
+	}
+
+	return c.patchGroup(ctx, teamID, []PatchOperation{{Op: "add", Path: "members", Value: members}})
+}
+
+// RemoveUserFromTeam removes the users from the group with a single PATCH request
+func (c *SCIMClient) RemoveUserFromTeam(ctx context.Context, teamID string, userIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "scim",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+	})
+	log.Info("removing users from team")
+
+	operations := make([]PatchOperation, 0, len(userIDs))
+	for _, userID := range userIDs {
+		operations = append(operations, PatchOperation{
+			Op:   "remove",
+			Path: fmt.Sprintf("members[%s]", filterEq("value", userID)),
+		})
+	}
+
+	return c.patchGroup(ctx, teamID, operations)
+}
+
+// patchGroup applies the operations to the group
+func (c *SCIMClient) patchGroup(ctx context.Context, teamID string, operations []PatchOperation) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	payload := PatchRequest{
+		Schemas:    []string{PatchOpSchema},
+		Operations: operations,
+	}
+	resp, header, status, err := c.makeRequest(ctx, "/Groups/"+url.PathEscape(teamID), http.MethodPatch, payload)
+	if err != nil {
+		return fmt.Errorf("failed to update members of team %s: %w", teamID, err)
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return statusError(status, header, resp, "failed to update members of team %s", teamID)
+	}
+	return nil
+}
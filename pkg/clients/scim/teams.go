@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchAllTeams fetches all the groups of the service provider, keyed by display name
+func (c *SCIMClient) FetchAllTeams(ctx context.Context) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithField("service", "scim")
+
+	log.Info("fetching all teams")
+	teams := make(map[string]structs.Team)
+
+	// members aren't needed and can make the pages very large
+	err := c.fetchAllWithPagination(ctx, "/Groups?excludedAttributes=members",
+		func(resources json.RawMessage) (int, error) {
+			var groups []SCIMGroup
+			if err := json.Unmarshal(resources, &groups); err != nil {
+				return 0, fmt.Errorf("failed to parse groups response: %w", err)
+			}
+			for _, group := range groups {
+				teams[group.DisplayName] = toTeam(group)
+			}
+			return len(groups), nil
+		})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of teams")
+		return nil, err
+	}
+
+	log.WithField("total_teams_count", len(teams)).Info("found teams")
+	return teams, nil
+}
+
+// CreateTeam creates a new group
+func (c *SCIMClient) CreateTeam(ctx context.Context, team *structs.Team) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "scim",
+		"team":    team.Name,
+	})
+
+	log.Info("creating team")
+	payload := SCIMGroup{
+		Schemas:     []string{GroupSchema},
+		DisplayName: team.Name,
+	}
+
+	resp, header, status, err := c.makeRequest(ctx, "/Groups", http.MethodPost, payload)
+	if err != nil {
+		log.WithError(err).Error("error creating team")
+		return nil, err
+	}
+	if status != http.StatusCreated && status != http.StatusOK {
+		return nil, statusError(status, header, resp, "failed to create group %s", team.Name)
+	}
+
+	var group SCIMGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse create group response: %w", err)
+	}
+
+	createdTeam := toTeam(group)
+	return &createdTeam, nil
+}
+
+// FetchTeamDetails fetches the group by its SCIM ID
+func (c *SCIMClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "scim",
+		"teamID":  teamID,
+	})
+
+	log.Info("fetching team details")
+	group, err := c.fetchGroup(ctx, teamID, "excludedAttributes=members")
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+
+	log.Info("successfully fetched team details")
+	team := toTeam(*group)
+	return &team, nil
+}
+
+// DeleteTeamByID deletes the group by its SCIM ID
+func (c *SCIMClient) DeleteTeamByID(ctx context.Context, teamID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "scim",
+		"teamID":  teamID,
+	})
+
+	log.Info("deleting team")
+	resp, header, status, err := c.makeRequest(ctx, "/Groups/"+url.PathEscape(teamID), http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting team")
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	if status != http.StatusNoContent && status != http.StatusOK && status != http.StatusNotFound {
+		return statusError(status, header, resp, "failed to delete group")
+	}
+
+	log.Info("team deleted successfully")
+	return nil
+}
+
+func (c *SCIMClient) fetchGroup(ctx context.Context, groupID, query string) (*SCIMGroup, error) {
+	resp, header, status, err := c.makeRequest(ctx, "/Groups/"+url.PathEscape(groupID)+"?"+query, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, header, resp, "failed to fetch group %s", groupID)
+	}
+
+	var group SCIMGroup
+	if err := json.Unmarshal(resp, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+	return &group, nil
+}
+
+func toTeam(group SCIMGroup) structs.Team {
+	return structs.Team{
+		ID:   group.ID,
+		Name: group.DisplayName,
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"encoding/json"
+
+	"github.com/gojek/heimdall/v7"
+)
+
+// SCIM schema URNs, RFC 7643 and RFC 7644
+const (
+	UserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIM user attributes the LDAP attributes can be mapped to
+const (
+	AttrUserName    = "username"
+	AttrExternalID  = "externalid"
+	AttrDisplayName = "displayname"
+	AttrGivenName   = "name.givenname"
+	AttrFamilyName  = "name.familyname"
+	AttrEmail       = "emails"
+)
+
+// defaultAttributeMapping maps the SCIM user attributes to the LDAP attributes they're read from
+var defaultAttributeMapping = map[string]string{
+	AttrUserName:    "mail",
+	AttrExternalID:  "uid",
+	AttrDisplayName: "displayName",
+	AttrFamilyName:  "sn",
+	AttrEmail:       "mail",
+}
+
+// SCIMConfig holds the configuration for SCIM client
+type SCIMConfig struct {
+	Token   string
+	BaseURL string
+	// PageSize is the number of resources requested per page
+	PageSize int
+	// AttributeMapping maps the SCIM user attributes to the LDAP attributes they're read from
+	AttributeMapping map[string]string
+}
+
+// SCIMClient is the client for interacting with a SCIM 2.0 service provider
+type SCIMClient struct {
+	config *SCIMConfig
+	client heimdall.Doer
+}
+
+// ListResponse is the envelope of the SCIM list and query responses
+type ListResponse struct {
+	Schemas      []string        `json:"schemas"`
+	TotalResults int             `json:"totalResults"`
+	ItemsPerPage int             `json:"itemsPerPage"`
+	StartIndex   int             `json:"startIndex"`
+	Resources    json.RawMessage `json:"Resources"`
+}
+
+// SCIMName is the name of a SCIM user
+type SCIMName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMMultiValued is an entry of a SCIM multi-valued attribute, e.g. emails or members
+type SCIMMultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMUser represents a SCIM user resource
+type SCIMUser struct {
+	Schemas     []string          `json:"schemas,omitempty"`
+	ID          string            `json:"id,omitempty"`
+	ExternalID  string            `json:"externalId,omitempty"`
+	UserName    string            `json:"userName"`
+	DisplayName string            `json:"displayName,omitempty"`
+	Name        *SCIMName         `json:"name,omitempty"`
+	Emails      []SCIMMultiValued `json:"emails,omitempty"`
+	Active      *bool             `json:"active,omitempty"`
+}
+
+// SCIMGroup represents a SCIM group resource
+type SCIMGroup struct {
+	Schemas     []string          `json:"schemas,omitempty"`
+	ID          string            `json:"id,omitempty"`
+	ExternalID  string            `json:"externalId,omitempty"`
+	DisplayName string            `json:"displayName"`
+	Members     []SCIMMultiValued `json:"members,omitempty"`
+}
+
+// PatchOperation is an operation of a SCIM PATCH request
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PatchRequest is the body of a SCIM PATCH request
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// SCIMError is the body of the SCIM error responses
+type SCIMError struct {
+	Schemas []string `json:"schemas"`
+	Status  string   `json:"status"`
+	// ScimType is the kind of the error, e.g. uniqueness or invalidFilter
+	ScimType string `json:"scimType,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+}
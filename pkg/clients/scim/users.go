@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchAllUsers fetches all the users of the service provider
+// Returns 2 maps: 1st map keyed by ID, 2nd map keyed by email
+func (c *SCIMClient) FetchAllUsers(ctx context.Context) (map[string]*structs.User,
+	map[string]*structs.User, error) {
+	log := logger.Logger(ctx).WithField("service", "scim")
+
+	log.Info("fetching all users")
+	resultByID := make(map[string]*structs.User)
+	resultByEmail := make(map[string]*structs.User)
+
+	err := c.fetchAllWithPagination(ctx, "/Users", func(resources json.RawMessage) (int, error) {
+		var users []SCIMUser
+		if err := json.Unmarshal(resources, &users); err != nil {
+			return 0, fmt.Errorf("failed to parse users response: %w", err)
+		}
+		for _, user := range users {
+			structUser := toUser(user)
+			resultByID[structUser.ID] = structUser
+			if structUser.Email != "" {
+				resultByEmail[structUser.Email] = structUser
+			}
+		}
+		return len(users), nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching list of users")
+		return nil, nil, err
+	}
+
+	log.WithField("total_user_count", len(resultByID)).Info("found users")
+	return resultByID, resultByEmail, nil
+}
+
+// CreateUser provisions the user, with its attributes mapped from LDAP through the attribute mapping.
+// An existing user with the same userName is returned instead of failing with a conflict.
+func (c *SCIMClient) CreateUser(ctx context.Context, user *structs.User) (*structs.User, error) {
+	scimUser := c.toSCIMUser(user)
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":  "scim",
+		"userName": scimUser.UserName,
+	})
+
+	log.Info("creating user")
+	if scimUser.UserName == "" {
+		return nil, fmt.Errorf("userName is required to create the scim user %s, mapped from LDAP attribute %s",
+			user.GetUserName(), c.config.AttributeMapping[AttrUserName])
+	}
+
+	existing, err := c.findUser(ctx, scimUser.UserName)
+	if err != nil {
+		log.WithError(err).Error("error looking up user")
+		return nil, err
+	}
+	if existing != nil {
+		log.Info("user already exists")
+		return toUser(*existing), nil
+	}
+
+	resp, header, status, err := c.makeRequest(ctx, "/Users", http.MethodPost, scimUser)
+	if err != nil {
+		log.WithError(err).Error("error creating user")
+		return nil, err
+	}
+	if status != http.StatusCreated && status != http.StatusOK {
+		return nil, statusError(status, header, resp, "failed to create user %s", scimUser.UserName)
+	}
+
+	var createdUser SCIMUser
+	if err := json.Unmarshal(resp, &createdUser); err != nil {
+		return nil, fmt.Errorf("failed to parse create user response: %w", err)
+	}
+	return toUser(createdUser), nil
+}
+
+// FetchUserDetails fetches the user by its SCIM ID
+func (c *SCIMClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "scim",
+		"userID":  userID,
+	})
+	log.Info("fetching user details by ID")
+
+	resp, header, status, err := c.makeRequest(ctx, "/Users/"+url.PathEscape(userID), http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching user details")
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, header, resp, "failed to fetch user details")
+	}
+
+	var user SCIMUser
+	if err := json.Unmarshal(resp, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	log.Info("found user details")
+	return toUser(user), nil
+}
+
+// DeleteUser deprovisions the user
+func (c *SCIMClient) DeleteUser(ctx context.Context, userID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "scim",
+		"userID":  userID,
+	})
+
+	log.Info("deleting user")
+	resp, header, status, err := c.makeRequest(ctx, "/Users/"+url.PathEscape(userID), http.MethodDelete, nil)
+	if err != nil {
+		log.WithError(err).Error("error deleting user")
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if status != http.StatusNoContent && status != http.StatusOK && status != http.StatusNotFound {
+		return statusError(status, header, resp, "failed to delete user")
+	}
+
+	log.Info("user deleted successfully")
+	return nil
+}
+
+// findUser returns the user with the given userName, nil when there's none
+func (c *SCIMClient) findUser(ctx context.Context, userName string) (*SCIMUser, error) {
+	endpoint := "/Users?filter=" + url.QueryEscape(filterEq("userName", userName))
+	resp, header, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, header, resp, "failed to look up user")
+	}
+
+	var page ListResponse
+	if err := json.Unmarshal(resp, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse list response: %w", err)
+	}
+	var users []SCIMUser
+	if len(page.Resources) > 0 {
+		if err := json.Unmarshal(page.Resources, &users); err != nil {
+			return nil, fmt.Errorf("failed to parse users response: %w", err)
+		}
+	}
+	for _, user := range users {
+		if strings.EqualFold(user.UserName, userName) {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+// ldapValue returns the value of the LDAP attribute mapped to the SCIM attribute
+func (c *SCIMClient) ldapValue(user *structs.User, scimAttribute string) string {
+	ldapAttribute := c.config.AttributeMapping[scimAttribute]
+	if ldapAttribute == "" {
+		return ""
+	}
+	if value := user.GetAttribute(ldapAttribute); value != "" {
+		return value
+	}
+
+	// the attributes are only set when the user comes from LDAP, fall back to the mapped fields
+	switch ldapAttribute {
+	case "mail":
+		return user.GetEmail()
+	case "uid":
+		return user.GetUserName()
+	case "sn":
+		return user.GetLastName()
+	case "displayName":
+		return user.GetFirstName()
+	}
+	return ""
+}
+
+// toSCIMUser builds the SCIM resource of the user from the mapped LDAP attributes
+func (c *SCIMClient) toSCIMUser(user *structs.User) SCIMUser {
+	active := true
+	scimUser := SCIMUser{
+		Schemas:     []string{UserSchema},
+		UserName:    c.ldapValue(user, AttrUserName),
+		ExternalID:  c.ldapValue(user, AttrExternalID),
+		DisplayName: c.ldapValue(user, AttrDisplayName),
+		Active:      &active,
+	}
+
+	name := SCIMName{
+		GivenName:  c.ldapValue(user, AttrGivenName),
+		FamilyName: c.ldapValue(user, AttrFamilyName),
+	}
+	if name != (SCIMName{}) {
+		scimUser.Name = &name
+	}
+
+	if email := c.ldapValue(user, AttrEmail); email != "" {
+		scimUser.Emails = []SCIMMultiValued{{Value: email, Type: "work", Primary: true}}
+	}
+	return scimUser
+}
+
+// primaryEmail returns the primary email of the user, or the first one when none is primary
+func primaryEmail(user SCIMUser) string {
+	for _, email := range user.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(user.Emails) > 0 {
+		return user.Emails[0].Value
+	}
+	return ""
+}
+
+func toUser(user SCIMUser) *structs.User {
+	structUser := &structs.User{
+		ID:          user.ID,
+		UserName:    user.UserName,
+		Email:       strings.ToLower(primaryEmail(user)),
+		DisplayName: user.DisplayName,
+	}
+	if user.Name != nil {
+		structUser.FirstName = user.Name.GivenName
+		structUser.LastName = user.Name.FamilyName
+	}
+	return structUser
+}
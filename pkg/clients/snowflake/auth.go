@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// keyPairTokenType is the token type of the JWTs signed with the key of the user
+	keyPairTokenType = "KEYPAIR_JWT"
+
+	// jwtLifetime is the validity of the JWTs, Snowflake rejects the ones valid for over an hour
+	jwtLifetime = 59 * time.Minute
+	// jwtRefreshMargin is the time before their expiry when the JWTs are renewed
+	jwtRefreshMargin = 5 * time.Minute
+)
+
+// keyPairAuth signs the JWTs authenticating a Snowflake user with its RSA key pair,
+// a JWT is reused until it is about to expire
+type keyPairAuth struct {
+	key *rsa.PrivateKey
+	// subject is the qualified user, ACCOUNT.USER
+	subject string
+	// issuer is the subject qualified by the fingerprint of the public key
+	issuer string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	now     func() time.Time
+}
+
+// newKeyPairAuth parses the PEM encoded unencrypted private key, PKCS#8 or PKCS#1, of the user.
+// The account is the account identifier, e.g. MYORG-MYACCOUNT.
+func newKeyPairAuth(privateKey, account, user string) (*keyPairAuth, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, errors.New("invalid private_key for snowflake backend: no PEM data found")
+	}
+
+	var key *rsa.PrivateKey
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("invalid private_key for snowflake backend: not an RSA key")
+		}
+		key = rsaKey
+	} else if rsaKey, pkcs1Err := x509.ParsePKCS1PrivateKey(block.Bytes); pkcs1Err == nil {
+		key = rsaKey
+	} else {
+		return nil, fmt.Errorf("invalid private_key for snowflake backend: %w", err)
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the public key: %w", err)
+	}
+	fingerprint := sha256.Sum256(publicKey)
+
+	subject := strings.ToUpper(account) + "." + strings.ToUpper(user)
+	return &keyPairAuth{
+		key:     key,
+		subject: subject,
+		issuer:  subject + ".SHA256:" + base64.StdEncoding.EncodeToString(fingerprint[:]),
+		now:     time.Now,
+	}, nil
+}
+
+// Token returns a valid JWT, signing a new one when the current one is about to expire
+func (a *keyPairAuth) Token() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if a.token != "" && now.Add(jwtRefreshMargin).Before(a.expires) {
+		return a.token, nil
+	}
+
+	expires := now.Add(jwtLifetime)
+	token, err := a.sign(now, expires)
+	if err != nil {
+		return "", err
+	}
+	a.token, a.expires = token, expires
+	return token, nil
+}
+
+// sign returns a JWT signed with RS256
+func (a *keyPairAuth) sign(issuedAt, expires time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": a.issuer,
+		"sub": a.subject,
+		"iat": issuedAt.Unix(),
+		"exp": expires.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the snowflake JWT: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// accountFromURL returns the account identifier of the account URL, e.g. MYORG-MYACCOUNT for
+// https://myorg-myaccount.snowflakecomputing.com or XY12345 for https://xy12345.us-east-2.aws.snowflakecomputing.com
+func accountFromURL(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	account, _, _ := strings.Cut(u.Hostname(), ".")
+	return strings.ToUpper(account)
+}
+
+// authToken returns the token authenticating the requests and its type,
+// the type is left empty for the programmatic access tokens
+func (c *SnowflakeClient) authToken() (string, string, error) {
+	if c.keyPair == nil {
+		return c.config.PAT, "", nil
+	}
+	token, err := c.keyPair.Token()
+	return token, keyPairTokenType, err
+}
@@ -23,9 +23,13 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gojek/heimdall/v7"
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/request"
 	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
 )
@@ -43,15 +47,67 @@ func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionP
 	// Extract connection parameters
 	pat, _ := connection["pat"].(string)
 	baseURL, _ := connection["base_url"].(string)
+	privateKey, _ := connection["private_key"].(string)
 
-	if pat == "" || baseURL == "" {
-		return nil, errors.New("missing required connection parameters for snowflake backend: pat and base_url are required")
+	if baseURL == "" || (pat == "" && privateKey == "") {
+		return nil, errors.New("missing required connection parameters for snowflake backend: " +
+			"base_url and either pat or private_key are required")
 	}
 
 	config := SnowflakeConfig{
 		PAT:     pat,
 		BaseURL: baseURL,
 	}
+
+	// the key pair takes precedence over the PAT, the account defaults to the one of the URL
+	var keyPair *keyPairAuth
+	if privateKey != "" {
+		config.User, _ = connection["user"].(string)
+		config.Account, _ = connection["account"].(string)
+		if config.Account == "" {
+			config.Account = accountFromURL(baseURL)
+		}
+		if config.User == "" || config.Account == "" {
+			return nil, errors.New("missing required connection parameters for snowflake backend: " +
+				"user and account are required with private_key")
+		}
+		var err error
+		keyPair, err = newKeyPairAuth(privateKey, config.Account, config.User)
+		if err != nil {
+			return nil, err
+		}
+	}
+	roleHierarchy, err := clients.ParseBoolConnection(connection, "role_hierarchy")
+	if err != nil {
+		return nil, fmt.Errorf("%w for snowflake backend", err)
+	}
+	config.RoleHierarchy = roleHierarchy
+	groupDefaultRole, err := clients.ParseBoolConnection(connection, "group_default_role")
+	if err != nil {
+		return nil, fmt.Errorf("%w for snowflake backend", err)
+	}
+	config.GroupDefaultRole = groupDefaultRole
+	config.DefaultWarehouse, _ = connection["default_warehouse"].(string)
+	config.LoginNameAttribute, _ = connection["login_name_attribute"].(string)
+	bulkGrants, err := clients.ParseBoolConnection(connection, "bulk_grants")
+	if err != nil {
+		return nil, fmt.Errorf("%w for snowflake backend", err)
+	}
+	config.BulkGrants = bulkGrants
+	config.BulkGrantBatchSize = defaultBulkGrantBatchSize
+	if batchSize, ok := connection["bulk_grant_batch_size"]; ok {
+		size, err := strconv.Atoi(fmt.Sprint(batchSize))
+		if err != nil || size < 1 {
+			return nil, fmt.Errorf("invalid bulk_grant_batch_size %v for snowflake backend", batchSize)
+		}
+		config.BulkGrantBatchSize = size
+	}
+	if userType, _ := connection["user_type"].(string); userType != "" {
+		if !validUserType(userType) {
+			return nil, fmt.Errorf("invalid user_type %q for snowflake backend: expected PERSON or SERVICE", userType)
+		}
+		config.UserType = strings.ToUpper(userType)
+	}
 	client, err := httpclient.InitializeClient(
 		"snowflake",
 		poolCfg,
@@ -64,8 +120,10 @@ func NewClient(connection map[string]interface{}, poolCfg httpclient.ConnectionP
 	}
 
 	return &SnowflakeClient{
-		config: &config,
-		client: client,
+		config:       &config,
+		client:       client,
+		keyPair:      keyPair,
+		pollInterval: defaultStatementPollInterval,
 	}, nil
 }
 
@@ -87,12 +145,20 @@ func (c *SnowflakeClient) prepareRequest(ctx context.Context, endpoint, method s
 		return nil, err
 	}
 
+	token, tokenType, err := c.authToken()
+	if err != nil {
+		return nil, err
+	}
+
 	// Set Snowflake-specific headers
 	headers := map[string]string{
-		"Authorization": "Bearer " + c.config.PAT,
+		"Authorization": "Bearer " + token,
 		"Content-Type":  "application/json",
 		"Accept":        "application/json",
 	}
+	if tokenType != "" {
+		headers["X-Snowflake-Authorization-Token-Type"] = tokenType
+	}
 	req.SetHeaders(headers)
 
 	return req, nil
@@ -101,16 +167,13 @@ func (c *SnowflakeClient) prepareRequest(ctx context.Context, endpoint, method s
 // makeRequest uses the common request package for standard HTTP requests (with logging, tracing, etc.)
 func (c *SnowflakeClient) makeRequest(ctx context.Context, endpoint,
 	method string, body interface{}) ([]byte, int, error) {
-	req, err := c.prepareRequest(ctx, endpoint, method, body)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return req.MakeRequest(c.client, method, "snowflake")
+	resp, _, status, err := c.makeRequestWithHeader(ctx, endpoint, method, body)
+	return resp, status, err
 }
 
 // makeRequestWithHeader uses the common request package for HTTP requests
-// and returns headers (with logging, tracing, etc.)
+// and returns headers (with logging, tracing, etc.). The calls without a response
+// fail with a transient error and the throttled ones with a rate limit error.
 func (c *SnowflakeClient) makeRequestWithHeader(ctx context.Context, endpoint,
 	method string, body interface{}) ([]byte, http.Header, int, error) {
 	req, err := c.prepareRequest(ctx, endpoint, method, body)
@@ -118,7 +181,15 @@ func (c *SnowflakeClient) makeRequestWithHeader(ctx context.Context, endpoint,
 		return nil, nil, 0, err
 	}
 
-	return req.MakeRequestWithHeader(c.client, method, "snowflake")
+	resp, header, status, err := req.MakeRequestWithHeader(c.client, method, "snowflake")
+	if err != nil {
+		return nil, nil, status, clients.Transient(err)
+	}
+	if status == http.StatusTooManyRequests {
+		return resp, header, status, clients.StatusError(status, header,
+			"rate limited on %s, body: %s", endpoint, string(resp))
+	}
+	return resp, header, status, nil
 }
 
 func (c *SnowflakeClient) fetchAllWithPagination(ctx context.Context,
@@ -129,7 +200,7 @@ func (c *SnowflakeClient) fetchAllWithPagination(ctx context.Context,
 		return err
 	}
 	if status != http.StatusOK {
-		return fmt.Errorf("failed to fetch data from %s, status: %s, body: %s",
+		return clients.StatusError(status, headers, "failed to fetch data from %s, status: %s, body: %s",
 			endpoint, http.StatusText(status), string(resp))
 	}
 
@@ -150,7 +221,8 @@ func (c *SnowflakeClient) fetchAllWithPagination(ctx context.Context,
 				return err
 			}
 			if status != http.StatusOK {
-				return fmt.Errorf("unexpected status during pagination: %s, body: %s", http.StatusText(status), string(resp))
+				return clients.StatusError(status, headers, "unexpected status during pagination: %s, body: %s",
+					http.StatusText(status), string(resp))
 			}
 
 			// Process this page
@@ -196,3 +268,13 @@ func parseLinkHeader(linkHeader, rel string) string {
 func (c *SnowflakeClient) GetConfig() *SnowflakeConfig {
 	return c.config
 }
+
+// Capabilities returns the operations supported by the Snowflake backend, the roles
+// are nested when the role hierarchy is enabled
+func (c *SnowflakeClient) Capabilities() structs.Capabilities {
+	capabilities := clients.DefaultCapabilities()
+	capabilities.NestedTeams = c.config.RoleHierarchy
+	capabilities.Grants = true
+	capabilities.UserDisable = true
+	return capabilities
+}
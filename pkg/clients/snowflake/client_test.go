@@ -0,0 +1,417 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/request/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSnowflake is an in-memory stand-in of the Snowflake user endpoints used by the client
+type fakeSnowflake struct {
+	t     *testing.T
+	mu    sync.Mutex
+	users map[string]map[string]interface{}
+	// publicKey verifies the JWTs of the clients authenticating with a key pair
+	publicKey *rsa.PublicKey
+	issuers   []string
+	// batches records the statements run through the SQL API, the ones on failingUsers fail
+	batches      [][]string
+	failingUsers map[string]bool
+	results      map[string]bool
+	polled       map[string]bool
+	roles        []SnowflakeRole
+	// grants holds the privilege grants by role, tables the tables by database.schema
+	grants map[string][]SnowflakePrivilegeGrant
+	tables map[string][]SnowflakeTable
+}
+
+func newFakeSnowflake(t *testing.T, connection map[string]interface{}) (*fakeSnowflake, *SnowflakeClient) {
+	fake := &fakeSnowflake{
+		t:            t,
+		users:        map[string]map[string]interface{}{},
+		failingUsers: map[string]bool{},
+		results:      map[string]bool{},
+		polled:       map[string]bool{},
+		grants:       map[string][]SnowflakePrivilegeGrant{},
+		tables:       map[string][]SnowflakeTable{},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	connection["base_url"] = server.URL
+	if connection["private_key"] == nil {
+		connection["pat"] = "snowflake_pat"
+	}
+	client, err := NewClient(connection, httpclient.ConnectionPoolConfig{Timeout: 5000},
+		httpclient.HystrixResiliencyConfig{MaxConcurrentRequests: 10, CircuitBreakerTimeout: 5000})
+	require.NoError(t, err)
+	client.pollInterval = time.Millisecond
+	return fake, client
+}
+
+func (f *fakeSnowflake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/v2/users/")
+	handle := strings.TrimPrefix(r.URL.Path, "/api/v2/statements/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/statements":
+		f.submitStatements(w, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/statements/"):
+		// the statements are still running on the first poll
+		switch {
+		case !f.polled[handle]:
+			f.polled[handle] = true
+			f.writeJSON(w, http.StatusAccepted, SnowflakeStatementStatus{StatementHandle: handle})
+		case f.results[handle]:
+			f.writeJSON(w, http.StatusOK, SnowflakeStatementStatus{StatementHandle: handle})
+		default:
+			f.writeJSON(w, http.StatusUnprocessableEntity, SnowflakeStatementStatus{
+				Code: "002003", SQLState: "02000", Message: "User does not exist or not authorized.",
+			})
+		}
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/future-grants"):
+		f.writeJSON(w, http.StatusOK, []SnowflakePrivilegeGrant{})
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/roles":
+		// LIKE patterns are case insensitive and match any character on '_'
+		like := strings.ReplaceAll(regexp.QuoteMeta(r.URL.Query().Get("like")), "_", ".")
+		pattern := regexp.MustCompile("(?i)^" + like + "$")
+		roles := make([]SnowflakeRole, 0)
+		for _, role := range f.roles {
+			if pattern.MatchString(role.Name) {
+				roles = append(roles, role)
+			}
+		}
+		f.writeJSON(w, http.StatusOK, roles)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/roles/"):
+		role := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v2/roles/"), "/grants")
+		f.writeJSON(w, http.StatusOK, f.grants[role])
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/tables"):
+		var database, schema string
+		_, err := fmt.Sscanf(strings.ReplaceAll(r.URL.Path, "/", " "), " api v2 databases %s schemas %s tables",
+			&database, &schema)
+		require.NoError(f.t, err)
+		f.writeJSON(w, http.StatusOK, f.tables[database+"."+schema])
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/users":
+		user := map[string]interface{}{}
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&user))
+		f.users[strings.ToLower(user["name"].(string))] = user
+		f.writeJSON(w, http.StatusCreated, user)
+	case r.Method == http.MethodGet && f.users[name] != nil:
+		f.writeJSON(w, http.StatusOK, f.users[name])
+	case r.Method == http.MethodPut && f.users[name] != nil:
+		user := map[string]interface{}{}
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&user))
+		f.users[name] = user
+		f.writeJSON(w, http.StatusOK, map[string]string{"status": "successful"})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// submitStatements records the statements of a SQL API request, which fails when
+// one of them is on a failing user
+func (f *fakeSnowflake) submitStatements(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "true", r.URL.Query().Get("async"))
+	body := struct {
+		Statement  string            `json:"statement"`
+		Parameters map[string]string `json:"parameters"`
+	}{}
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+
+	statements := strings.Split(body.Statement, ";\n")
+	assert.Equal(f.t, strconv.Itoa(len(statements)), body.Parameters["MULTI_STATEMENT_COUNT"])
+	f.batches = append(f.batches, statements)
+
+	handle := fmt.Sprintf("handle-%d", len(f.batches))
+	f.results[handle] = true
+	for _, statement := range statements {
+		fields := strings.Fields(statement)
+		if f.failingUsers[strings.Trim(fields[len(fields)-1], `"`)] {
+			f.results[handle] = false
+		}
+	}
+	f.writeJSON(w, http.StatusAccepted, SnowflakeStatementStatus{StatementHandle: handle})
+}
+
+// authorized checks the PAT, or the signature of the JWT and records its issuer
+func (f *fakeSnowflake) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if r.Header.Get("X-Snowflake-Authorization-Token-Type") != keyPairTokenType {
+		return token == "snowflake_pat"
+	}
+
+	parts := strings.Split(token, ".")
+	require.Len(f.t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(f.t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(f.publicKey, crypto.SHA256, digest[:], signature) != nil {
+		return false
+	}
+
+	claims := map[string]interface{}{}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(f.t, err)
+	require.NoError(f.t, json.Unmarshal(payload, &claims))
+	f.issuers = append(f.issuers, claims["iss"].(string))
+	return claims["sub"] == "MYORG-MYACCOUNT.USERNAUT"
+}
+
+func (f *fakeSnowflake) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(f.t, json.NewEncoder(w).Encode(body))
+}
+
+func TestNewClientUserType(t *testing.T) {
+	_, err := NewClient(map[string]interface{}{"pat": "pat", "base_url": "https://example.com", "user_type": "robot"},
+		httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "invalid user_type")
+}
+
+func TestNewClientBoolParameters(t *testing.T) {
+	newClient := func(parameters map[string]interface{}) (*SnowflakeClient, error) {
+		connection := map[string]interface{}{"pat": "pat", "base_url": "https://example.com"}
+		for key, value := range parameters {
+			connection[key] = value
+		}
+		return NewClient(connection, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	}
+
+	// the Backend CRs set the flags as strings
+	client, err := newClient(map[string]interface{}{
+		"role_hierarchy": "true", "group_default_role": "TRUE", "bulk_grants": "1",
+	})
+	require.NoError(t, err)
+	assert.True(t, client.GetConfig().RoleHierarchy)
+	assert.True(t, client.GetConfig().GroupDefaultRole)
+	assert.True(t, client.GetConfig().BulkGrants)
+
+	_, err = newClient(map[string]interface{}{"role_hierarchy": "yes please"})
+	assert.ErrorContains(t, err, "invalid role_hierarchy")
+	_, err = newClient(map[string]interface{}{"group_default_role": "on"})
+	assert.ErrorContains(t, err, "invalid group_default_role")
+	_, err = newClient(map[string]interface{}{"bulk_grants": "enabled"})
+	assert.ErrorContains(t, err, "invalid bulk_grants")
+}
+
+func TestCreateUserProperties(t *testing.T) {
+	fake, client := newFakeSnowflake(t, map[string]interface{}{
+		"default_warehouse":    "COMPUTE_WH",
+		"user_type":            "person",
+		"group_default_role":   true,
+		"login_name_attribute": "uid",
+	})
+
+	// the properties of the Group override the ones of the backend
+	_, err := client.CreateUser(context.Background(), &structs.User{
+		UserName:   "jdoe",
+		Email:      "jdoe@example.com",
+		LastName:   "Doe",
+		Attributes: map[string]string{"givenName": "John", "uid": "JDOE"},
+		Team:       "data_eng",
+		Properties: map[string]string{"default_warehouse": "REPORTING_WH"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":              "jdoe",
+		"email":             "jdoe@example.com",
+		"first_name":        "John",
+		"last_name":         "Doe",
+		"login_name":        "JDOE",
+		"default_role":      "data_eng",
+		"default_warehouse": "REPORTING_WH",
+		"type":              "PERSON",
+	}, fake.users["jdoe"])
+
+	_, err = client.CreateUser(context.Background(), &structs.User{
+		UserName:   "asmith",
+		Email:      "asmith@example.com",
+		Properties: map[string]string{"default_namespace": "ANALYTICS"},
+	})
+	assert.ErrorContains(t, err, "unsupported user property")
+}
+
+func TestDisableUser(t *testing.T) {
+	fake, client := newFakeSnowflake(t, map[string]interface{}{})
+	fake.users["jdoe"] = map[string]interface{}{
+		"name": "JDOE", "email": "jdoe@example.com", "disabled": false,
+		"created_on": "2025-01-01T00:00:00Z", "has_password": false, "owner": "USERADMIN",
+	}
+
+	require.NoError(t, client.DisableUser(context.Background(), "jdoe"))
+	assert.Equal(t, map[string]interface{}{"name": "JDOE", "email": "jdoe@example.com", "disabled": true},
+		fake.users["jdoe"])
+
+	assert.Error(t, client.DisableUser(context.Background(), "unknown"))
+}
+
+func TestKeyPairAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	fake, client := newFakeSnowflake(t, map[string]interface{}{
+		"private_key": privateKey,
+		"user":        "usernaut",
+		"account":     "myorg-myaccount",
+	})
+	fake.publicKey = &key.PublicKey
+	fake.users["jdoe"] = map[string]interface{}{"name": "JDOE", "email": "jdoe@example.com"}
+
+	now := time.Now()
+	client.keyPair.now = func() time.Time { return now }
+
+	// the JWT is reused until it is about to expire
+	_, err = client.FetchUserDetails(context.Background(), "jdoe")
+	require.NoError(t, err)
+	first := client.keyPair.token
+	_, err = client.FetchUserDetails(context.Background(), "jdoe")
+	require.NoError(t, err)
+	assert.Equal(t, first, client.keyPair.token)
+
+	now = now.Add(jwtLifetime - jwtRefreshMargin)
+	_, err = client.FetchUserDetails(context.Background(), "jdoe")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, client.keyPair.token)
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	fingerprint := sha256.Sum256(publicKey)
+	assert.Equal(t, "MYORG-MYACCOUNT.USERNAUT.SHA256:"+base64.StdEncoding.EncodeToString(fingerprint[:]),
+		fake.issuers[0])
+
+	_, err = NewClient(map[string]interface{}{"base_url": "https://example.com", "private_key": "invalid",
+		"user": "usernaut"}, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "invalid private_key")
+}
+
+func TestAccountFromURL(t *testing.T) {
+	assert.Equal(t, "MYORG-MYACCOUNT", accountFromURL("https://myorg-myaccount.snowflakecomputing.com"))
+	assert.Equal(t, "XY12345", accountFromURL("https://xy12345.us-east-2.aws.snowflakecomputing.com"))
+}
+
+func TestBulkGrants(t *testing.T) {
+	fake, client := newFakeSnowflake(t, map[string]interface{}{"bulk_grants": true, "bulk_grant_batch_size": 2})
+	fake.failingUsers["ghost"] = true
+
+	require.NoError(t, client.AddUserToTeam(context.Background(), "data_eng", []string{"jdoe", "john.doe", "asmith"}))
+	assert.Equal(t, [][]string{
+		{`GRANT ROLE data_eng TO USER jdoe`, `GRANT ROLE data_eng TO USER "john.doe"`},
+		{`GRANT ROLE data_eng TO USER asmith`},
+	}, fake.batches)
+
+	// the statements of a failed batch are run one by one to report the failing users
+	fake.batches = nil
+	err := client.RemoveUserFromTeam(context.Background(), "data_eng", []string{"jdoe", "ghost"})
+	var statementErr *StatementError
+	require.ErrorAs(t, err, &statementErr)
+	assert.Equal(t, "002003", statementErr.Code)
+	assert.ErrorContains(t, err, "user ghost:")
+	assert.NotContains(t, err.Error(), "user jdoe:")
+	assert.Equal(t, [][]string{
+		{`REVOKE ROLE data_eng FROM USER jdoe`, `REVOKE ROLE data_eng FROM USER ghost`},
+		{`REVOKE ROLE data_eng FROM USER jdoe`},
+		{`REVOKE ROLE data_eng FROM USER ghost`},
+	}, fake.batches)
+
+	_, err = NewClient(map[string]interface{}{"base_url": "https://example.com", "pat": "pat",
+		"bulk_grant_batch_size": "none"}, httpclient.ConnectionPoolConfig{}, httpclient.HystrixResiliencyConfig{})
+	assert.ErrorContains(t, err, "invalid bulk_grant_batch_size")
+}
+
+func TestFetchTeamGrantsTables(t *testing.T) {
+	fake, client := newFakeSnowflake(t, map[string]interface{}{})
+	tableGrant := func(table string, privileges ...string) SnowflakePrivilegeGrant {
+		return SnowflakePrivilegeGrant{
+			SecurableType: "TABLE",
+			Securable:     &SnowflakeSecurable{Database: "ANALYTICS", Schema: "REPORTING", Name: table},
+			Privileges:    privileges,
+		}
+	}
+	fake.tables["ANALYTICS.REPORTING"] = []SnowflakeTable{{Name: "ORDERS"}, {Name: "CUSTOMERS"}}
+	fake.grants["ANALYSTS"] = []SnowflakePrivilegeGrant{
+		{SecurableType: "WAREHOUSE", Securable: &SnowflakeSecurable{Name: "COMPUTE_WH"}, Privileges: []string{"USAGE"}},
+		tableGrant("ORDERS", "SELECT", "INSERT"),
+		tableGrant("CUSTOMERS", "SELECT"),
+	}
+
+	// a privilege held by every table is reported on the tables of the schema
+	grants, err := client.FetchTeamGrants(t.Context(), "ANALYSTS")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []structs.Grant{
+		{ObjectType: "warehouse", ObjectName: "COMPUTE_WH", Privilege: "USAGE"},
+		{ObjectType: structs.TableObjectType, ObjectName: "ANALYTICS.REPORTING", Privilege: "SELECT"},
+	}, grants)
+
+	// a table created without the privilege makes it missing on the schema
+	fake.tables["ANALYTICS.REPORTING"] = append(fake.tables["ANALYTICS.REPORTING"], SnowflakeTable{Name: "REFUNDS"})
+	grants, err = client.FetchTeamGrants(t.Context(), "ANALYSTS")
+	require.NoError(t, err)
+	assert.Equal(t, []structs.Grant{{ObjectType: "warehouse", ObjectName: "COMPUTE_WH", Privilege: "USAGE"}}, grants)
+}
+
+func TestFetchTeamDetails(t *testing.T) {
+	ctx := context.Background()
+	fake, client := newFakeSnowflake(t, map[string]interface{}{})
+	fake.roles = []SnowflakeRole{
+		{Name: "DATA_TEAM", Comment: "managed by usernaut"},
+		{Name: "DATAXTEAM"},
+	}
+
+	team, err := client.FetchTeamDetails(ctx, "data_team")
+	require.NoError(t, err)
+	assert.Equal(t, &structs.Team{ID: "data_team", Name: "data_team", Description: "managed by usernaut"}, team)
+
+	// the wildcard matches of the LIKE pattern aren't the role
+	fake.roles = fake.roles[1:]
+	_, err = client.FetchTeamDetails(ctx, "data_team")
+	assert.ErrorIs(t, err, clients.ErrNotFound)
+}
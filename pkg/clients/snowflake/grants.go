@@ -0,0 +1,268 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchTeamGrants returns the privileges of the role on warehouses, databases, schemas and
+// all the tables of the schemas, the ownership of the objects and the granted roles are left out
+func (c *SnowflakeClient) FetchTeamGrants(ctx context.Context, teamID string) ([]structs.Grant, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "snowflake",
+		"teamID":  teamID,
+	})
+	log.Info("fetching team grants")
+
+	grants, err := c.fetchPrivilegeGrants(ctx, fmt.Sprintf("/api/v2/roles/%s/grants", teamID))
+	if err != nil {
+		log.WithError(err).Error("error fetching team grants")
+		return nil, err
+	}
+	futureGrants, err := c.fetchPrivilegeGrants(ctx, fmt.Sprintf("/api/v2/roles/%s/future-grants", teamID))
+	if err != nil {
+		log.WithError(err).Error("error fetching team future grants")
+		return nil, err
+	}
+
+	seen := make(map[structs.Grant]struct{})
+	result := make([]structs.Grant, 0, len(grants)+len(futureGrants))
+	add := func(grant structs.Grant) {
+		if _, found := seen[grant]; !found {
+			seen[grant] = struct{}{}
+			result = append(result, grant)
+		}
+	}
+
+	// the grants on the tables are listed table by table, tableGrants holds the tables
+	// granted each privilege by schema
+	tableGrants := make(map[structs.Grant]map[string]struct{})
+	for _, grant := range grants {
+		objectType, objectName := grantObject(grant)
+		if objectType == "" {
+			continue
+		}
+		for _, privilege := range grant.Privileges {
+			if strings.EqualFold(privilege, "OWNERSHIP") {
+				continue
+			}
+			schemaGrant := structs.Grant{ObjectType: objectType, ObjectName: objectName, Privilege: privilege}
+			if objectType != structs.TableObjectType {
+				add(schemaGrant)
+				continue
+			}
+			if tableGrants[schemaGrant] == nil {
+				tableGrants[schemaGrant] = make(map[string]struct{})
+			}
+			tableGrants[schemaGrant][strings.ToUpper(grant.Securable.Name)] = struct{}{}
+		}
+	}
+
+	// a privilege is only reported on the tables of a schema when every table has it, the ones
+	// granted on some tables are neither reported as granted nor revoked from the others
+	schemaTables := make(map[string][]string)
+	for schemaGrant, granted := range tableGrants {
+		tables, found := schemaTables[schemaGrant.ObjectName]
+		if !found {
+			tables, err = c.fetchSchemaTables(ctx, schemaGrant.ObjectName)
+			if err != nil {
+				log.WithError(err).Error("error fetching schema tables")
+				return nil, err
+			}
+			schemaTables[schemaGrant.ObjectName] = tables
+		}
+		if !slices.ContainsFunc(tables, func(table string) bool {
+			_, ok := granted[strings.ToUpper(table)]
+			return !ok
+		}) {
+			add(schemaGrant)
+		}
+	}
+
+	for _, grant := range futureGrants {
+		// only the future grants on the tables of a schema are managed
+		scope := grant.ContainingScope
+		if !strings.EqualFold(grant.SecurableType, "TABLE") || scope == nil || scope.Schema == "" {
+			continue
+		}
+		for _, privilege := range grant.Privileges {
+			add(structs.Grant{
+				ObjectType: structs.TableObjectType,
+				ObjectName: scope.Database + "." + scope.Schema,
+				Privilege:  privilege,
+				Future:     true,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// GrantToTeam grants the privileges to the role
+func (c *SnowflakeClient) GrantToTeam(ctx context.Context, teamID string, grants []structs.Grant) error {
+	logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":     "snowflake",
+		"teamID":      teamID,
+		"grant_count": len(grants),
+	}).Info("granting privileges to team")
+
+	return c.sendPrivilegeGrants(ctx, teamID, grants, "")
+}
+
+// RevokeFromTeam revokes the privileges from the role
+func (c *SnowflakeClient) RevokeFromTeam(ctx context.Context, teamID string, grants []structs.Grant) error {
+	logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":     "snowflake",
+		"teamID":      teamID,
+		"grant_count": len(grants),
+	}).Info("revoking privileges from team")
+
+	return c.sendPrivilegeGrants(ctx, teamID, grants, ":revoke")
+}
+
+// fetchPrivilegeGrants returns the grants listed by the grants or future grants endpoint of a role
+func (c *SnowflakeClient) fetchPrivilegeGrants(ctx context.Context,
+	endpoint string) ([]SnowflakePrivilegeGrant, error) {
+	response, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to fetch grants: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, nil, "failed to fetch grants from %s, status: %s, body: %s",
+			endpoint, http.StatusText(status), string(response))
+	}
+
+	var grants []SnowflakePrivilegeGrant
+	if err := json.Unmarshal(response, &grants); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+	return grants, nil
+}
+
+// fetchSchemaTables returns the names of the tables of a schema qualified with its database
+func (c *SnowflakeClient) fetchSchemaTables(ctx context.Context, schema string) ([]string, error) {
+	database, name, _ := strings.Cut(schema, ".")
+	endpoint := fmt.Sprintf("/api/v2/databases/%s/schemas/%s/tables", database, name)
+	response, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to fetch tables: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, nil, "failed to fetch tables of schema %s, status: %s, body: %s",
+			schema, http.StatusText(status), string(response))
+	}
+
+	var tables []SnowflakeTable
+	if err := json.Unmarshal(response, &tables); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+	names := make([]string, 0, len(tables))
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+	return names, nil
+}
+
+// sendPrivilegeGrants grants or revokes the privileges one by one, the ones on the future
+// tables of a schema go through the future grants endpoint
+func (c *SnowflakeClient) sendPrivilegeGrants(ctx context.Context, teamID string,
+	grants []structs.Grant, action string) error {
+	for _, grant := range grants {
+		payload, err := privilegeGrantPayload(grant)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf("/api/v2/roles/%s/grants%s", teamID, action)
+		if grant.Future {
+			endpoint = fmt.Sprintf("/api/v2/roles/%s/future-grants%s", teamID, action)
+		}
+
+		resp, status, err := c.makeRequest(ctx, endpoint, http.MethodPost, payload)
+		if err != nil {
+			return fmt.Errorf("failed to update %s for role %s: %w", grant, teamID, err)
+		}
+		if status != http.StatusOK && status != http.StatusCreated && status != http.StatusNoContent {
+			return clients.StatusError(status, nil, "failed to update %s for role %s, status: %s, body: %s",
+				grant, teamID, http.StatusText(status), string(resp))
+		}
+	}
+
+	return nil
+}
+
+// privilegeGrantPayload returns the request body granting or revoking the privilege
+func privilegeGrantPayload(grant structs.Grant) (*SnowflakePrivilegeGrant, error) {
+	payload := &SnowflakePrivilegeGrant{
+		SecurableType: strings.ToUpper(grant.ObjectType),
+		Privileges:    []string{strings.ToUpper(grant.Privilege)},
+	}
+
+	switch strings.ToLower(grant.ObjectType) {
+	case "warehouse", "database":
+		payload.Securable = &SnowflakeSecurable{Name: grant.ObjectName}
+	case "schema":
+		database, schema, found := strings.Cut(grant.ObjectName, ".")
+		if !found {
+			return nil, fmt.Errorf("schema %s must be qualified with its database as database.schema",
+				grant.ObjectName)
+		}
+		payload.Securable = &SnowflakeSecurable{Database: database, Name: schema}
+	case structs.TableObjectType:
+		database, schema, found := strings.Cut(grant.ObjectName, ".")
+		if !found {
+			return nil, fmt.Errorf("schema %s must be qualified with its database as database.schema",
+				grant.ObjectName)
+		}
+		payload.ContainingScope = &SnowflakeContainingScope{Database: database, Schema: schema}
+	default:
+		return nil, fmt.Errorf("unsupported object type %s for snowflake grants", grant.ObjectType)
+	}
+
+	return payload, nil
+}
+
+// grantObject returns the object type and name of a grant as managed by the Group,
+// the type is empty for the objects not managed
+func grantObject(grant SnowflakePrivilegeGrant) (string, string) {
+	securable := grant.Securable
+	if securable == nil {
+		return "", ""
+	}
+
+	switch strings.ToUpper(grant.SecurableType) {
+	case "WAREHOUSE", "DATABASE":
+		return strings.ToLower(grant.SecurableType), securable.Name
+	case "SCHEMA":
+		return "schema", securable.Database + "." + securable.Name
+	case "TABLE":
+		return structs.TableObjectType, securable.Database + "." + securable.Schema
+	default:
+		return "", ""
+	}
+}
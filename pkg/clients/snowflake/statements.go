@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultBulkGrantBatchSize is the number of statements run per SQL API request
+	defaultBulkGrantBatchSize = 100
+	// statementTimeout is the time in seconds after which Snowflake cancels a batch
+	statementTimeout = 600
+	// defaultStatementPollInterval is the time between two polls of a running batch
+	defaultStatementPollInterval = 500 * time.Millisecond
+)
+
+// simpleIdentifier matches the identifiers used unquoted in the statements
+var simpleIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// StatementError is the failure of a SQL API statement
+type StatementError struct {
+	Code     string
+	SQLState string
+	Message  string
+}
+
+func (e *StatementError) Error() string {
+	return fmt.Sprintf("statement failed with code %s (sql state %s): %s", e.Code, e.SQLState, e.Message)
+}
+
+// grantRoleInBatches grants or revokes the role of the users through the SQL API, running the
+// statements in multi-statement batches. The users of a failed batch are retried one statement
+// at a time, so that the errors are reported per user.
+func (c *SnowflakeClient) grantRoleInBatches(ctx context.Context, teamID string, userIDs []string,
+	revoke bool) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "snowflake",
+		"teamID":     teamID,
+		"user_count": len(userIDs),
+		"revoke":     revoke,
+	})
+
+	statement := func(userID string) string {
+		if revoke {
+			return fmt.Sprintf("REVOKE ROLE %s FROM USER %s", quoteIdentifier(teamID), quoteIdentifier(userID))
+		}
+		return fmt.Sprintf("GRANT ROLE %s TO USER %s", quoteIdentifier(teamID), quoteIdentifier(userID))
+	}
+
+	var userErrors []error
+	for start := 0; start < len(userIDs); start += c.config.BulkGrantBatchSize {
+		batch := userIDs[start:min(start+c.config.BulkGrantBatchSize, len(userIDs))]
+		statements := make([]string, 0, len(batch))
+		for _, userID := range batch {
+			statements = append(statements, statement(userID))
+		}
+
+		err := c.executeStatements(ctx, statements)
+		var statementErr *StatementError
+		if !errors.As(err, &statementErr) {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		log.WithError(err).Warn("batch failed, running its statements one by one")
+		for _, userID := range batch {
+			if err := c.executeStatements(ctx, []string{statement(userID)}); err != nil {
+				if !errors.As(err, &statementErr) {
+					return err
+				}
+				userErrors = append(userErrors, fmt.Errorf("user %s: %w", userID, err))
+			}
+		}
+	}
+
+	if len(userErrors) > 0 {
+		return fmt.Errorf("failed to update role %s for %d users: %w", teamID, len(userErrors),
+			errors.Join(userErrors...))
+	}
+	return nil
+}
+
+// executeStatements runs the statements in a single asynchronous SQL API request and waits for
+// their completion, a failed statement is returned as a StatementError
+func (c *SnowflakeClient) executeStatements(ctx context.Context, statements []string) error {
+	payload := map[string]interface{}{
+		"statement": strings.Join(statements, ";\n"),
+		"timeout":   statementTimeout,
+		"parameters": map[string]string{
+			"MULTI_STATEMENT_COUNT": strconv.Itoa(len(statements)),
+		},
+	}
+
+	resp, status, err := c.makeRequest(ctx, "/api/v2/statements?async=true", http.MethodPost, payload)
+	for err == nil && status == http.StatusAccepted {
+		var running SnowflakeStatementStatus
+		if err := json.Unmarshal(resp, &running); err != nil {
+			return fmt.Errorf("failed to parse statement status: %w", err)
+		}
+		if running.StatementHandle == "" {
+			return errors.New("running statement has no handle")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.pollInterval):
+		}
+		resp, status, err = c.makeRequest(ctx, "/api/v2/statements/"+running.StatementHandle, http.MethodGet, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnprocessableEntity:
+		var failed SnowflakeStatementStatus
+		if err := json.Unmarshal(resp, &failed); err != nil {
+			return fmt.Errorf("failed to parse statement status: %w", err)
+		}
+		return &StatementError{Code: failed.Code, SQLState: failed.SQLState, Message: failed.Message}
+	default:
+		return clients.StatusError(status, nil, "failed to execute statements, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+}
+
+// quoteIdentifier returns the identifier as is when it can be used unquoted, quoted otherwise
+func quoteIdentifier(identifier string) string {
+	if simpleIdentifier.MatchString(identifier) {
+		return identifier
+	}
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
 	"github.com/sirupsen/logrus"
@@ -37,28 +38,16 @@ func (c *SnowflakeClient) FetchTeamMembersByTeamID(ctx context.Context,
 	})
 	log.Info("fetching team members by team ID")
 
-	// Use the correct endpoint: grants-of (not grants-on)
-	endpoint := fmt.Sprintf("/api/v2/roles/%s/grants-of", teamID)
-
-	response, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	grants, err := c.fetchGrantsOf(ctx, teamID)
 	if err != nil {
 		log.WithError(err).Error("error fetching team members by team ID")
-		return nil, fmt.Errorf("error making request to fetch team members: %w", err)
-	}
-	if status != http.StatusOK {
-		return nil,
-			fmt.Errorf("failed to fetch team members, status: %s, body: %s", http.StatusText(status), string(response))
-	}
-
-	var grants []SnowflakeGrant
-	if err := json.Unmarshal(response, &grants); err != nil {
-		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+		return nil, err
 	}
 
 	members := make(map[string]*structs.User)
 
 	for _, grant := range grants {
-		// Check if this grant is for a USER (not ROLE)
+		// The grants to roles are the member teams, see FetchMemberTeamsByTeamID
 		if grant.GrantedTo == "USER" && grant.GranteeName != "" {
 			members[strings.ToLower(grant.GranteeName)] = &structs.User{
 				ID:       strings.ToLower(grant.GranteeName),
@@ -71,6 +60,57 @@ func (c *SnowflakeClient) FetchTeamMembersByTeamID(ctx context.Context,
 	return members, nil
 }
 
+// FetchMemberTeamsByTeamID returns the roles the role is granted to, their users
+// inherit the privileges of the role as if they were granted it
+func (c *SnowflakeClient) FetchMemberTeamsByTeamID(ctx context.Context,
+	teamID string) (map[string]structs.Team, error) {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "snowflake",
+		"teamID":  teamID,
+	})
+	log.Info("fetching member teams by team ID")
+
+	grants, err := c.fetchGrantsOf(ctx, teamID)
+	if err != nil {
+		log.WithError(err).Error("error fetching member teams by team ID")
+		return nil, err
+	}
+
+	teams := make(map[string]structs.Team)
+	for _, grant := range grants {
+		if grant.GrantedTo == "ROLE" && grant.GranteeName != "" {
+			id := strings.ToLower(grant.GranteeName)
+			teams[id] = structs.Team{
+				ID:   id,
+				Name: id,
+			}
+		}
+	}
+
+	return teams, nil
+}
+
+// fetchGrantsOf returns the grants of the role to users and roles
+func (c *SnowflakeClient) fetchGrantsOf(ctx context.Context, teamID string) ([]SnowflakeGrant, error) {
+	// Use the correct endpoint: grants-of (not grants-on)
+	endpoint := fmt.Sprintf("/api/v2/roles/%s/grants-of", teamID)
+
+	response, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to fetch grants of role %s: %w", teamID, err)
+	}
+	if status != http.StatusOK {
+		return nil, clients.StatusError(status, nil, "failed to fetch grants of role %s, status: %s, body: %s",
+			teamID, http.StatusText(status), string(response))
+	}
+
+	var grants []SnowflakeGrant
+	if err := json.Unmarshal(response, &grants); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+	return grants, nil
+}
+
 // AddUserToTeam adds users to a team (grants role to users)
 func (c *SnowflakeClient) AddUserToTeam(ctx context.Context, teamID string, userIDs []string) error {
 	log := logger.Logger(ctx).WithFields(logrus.Fields{
@@ -80,6 +120,10 @@ func (c *SnowflakeClient) AddUserToTeam(ctx context.Context, teamID string, user
 	})
 	log.Info("adding users to team")
 
+	if c.config.BulkGrants {
+		return c.grantRoleInBatches(ctx, teamID, userIDs, false)
+	}
+
 	for _, userID := range userIDs {
 		endpoint := fmt.Sprintf("/api/v2/users/%s/grants", userID)
 
@@ -89,7 +133,7 @@ func (c *SnowflakeClient) AddUserToTeam(ctx context.Context, teamID string, user
 		}
 
 		if status != http.StatusOK && status != http.StatusCreated {
-			return fmt.Errorf("failed to add user %s to team %s, status: %s, body: %s",
+			return clients.StatusError(status, nil, "failed to add user %s to team %s, status: %s, body: %s",
 				userID, teamID, http.StatusText(status), string(resp))
 		}
 	}
@@ -106,6 +150,10 @@ func (c *SnowflakeClient) RemoveUserFromTeam(ctx context.Context, teamID string,
 	})
 	log.Info("removing users from team")
 
+	if c.config.BulkGrants {
+		return c.grantRoleInBatches(ctx, teamID, userIDs, true)
+	}
+
 	for _, userID := range userIDs {
 		endpoint := fmt.Sprintf("/api/v2/users/%s/grants:revoke", userID)
 
@@ -115,7 +163,7 @@ func (c *SnowflakeClient) RemoveUserFromTeam(ctx context.Context, teamID string,
 		}
 
 		if status != http.StatusOK && status != http.StatusNoContent {
-			return fmt.Errorf("failed to remove user %s from team %s, status: %s, body: %s",
+			return clients.StatusError(status, nil, "failed to remove user %s from team %s, status: %s, body: %s",
 				userID, teamID, http.StatusText(status), string(resp))
 		}
 	}
@@ -123,7 +171,60 @@ func (c *SnowflakeClient) RemoveUserFromTeam(ctx context.Context, teamID string,
 	return nil
 }
 
-// makeRoleRequest sends a role grant/revoke request for a user
+// AddTeamsToTeam grants the role to the member roles, the child role in the
+// Snowflake role hierarchy is the one of the team nesting the others
+func (c *SnowflakeClient) AddTeamsToTeam(ctx context.Context, teamID string, memberTeamIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "snowflake",
+		"teamID":     teamID,
+		"team_count": len(memberTeamIDs),
+	})
+	log.Info("adding member teams to team")
+
+	for _, memberTeamID := range memberTeamIDs {
+		endpoint := fmt.Sprintf("/api/v2/roles/%s/grants", memberTeamID)
+
+		resp, status, err := c.makeRoleRequest(ctx, teamID, endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to grant role %s to role %s: %w", teamID, memberTeamID, err)
+		}
+
+		if status != http.StatusOK && status != http.StatusCreated {
+			return clients.StatusError(status, nil, "failed to grant role %s to role %s, status: %s, body: %s",
+				teamID, memberTeamID, http.StatusText(status), string(resp))
+		}
+	}
+
+	return nil
+}
+
+// RemoveTeamsFromTeam revokes the role from the member roles
+func (c *SnowflakeClient) RemoveTeamsFromTeam(ctx context.Context, teamID string, memberTeamIDs []string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service":    "snowflake",
+		"teamID":     teamID,
+		"team_count": len(memberTeamIDs),
+	})
+	log.Info("removing member teams from team")
+
+	for _, memberTeamID := range memberTeamIDs {
+		endpoint := fmt.Sprintf("/api/v2/roles/%s/grants:revoke", memberTeamID)
+
+		resp, status, err := c.makeRoleRequest(ctx, teamID, endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to revoke role %s from role %s: %w", teamID, memberTeamID, err)
+		}
+
+		if status != http.StatusOK && status != http.StatusNoContent {
+			return clients.StatusError(status, nil, "failed to revoke role %s from role %s, status: %s, body: %s",
+				teamID, memberTeamID, http.StatusText(status), string(resp))
+		}
+	}
+
+	return nil
+}
+
+// makeRoleRequest sends a role grant/revoke request for a user or a role
 func (c *SnowflakeClient) makeRoleRequest(ctx context.Context, teamID, endpoint string) ([]byte, int, error) {
 	payload := map[string]interface{}{
 		"securable": map[string]string{
@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
 	"github.com/sirupsen/logrus"
@@ -87,7 +89,8 @@ func (c *SnowflakeClient) CreateTeam(ctx context.Context, team *structs.Team) (*
 
 	// Check for successful creation
 	if status != http.StatusOK && status != http.StatusCreated {
-		return nil, fmt.Errorf("failed to create role, status: %s, body: %s", http.StatusText(status), string(resp))
+		return nil, clients.StatusError(status, nil, "failed to create role, status: %s, body: %s",
+			http.StatusText(status), string(resp))
 	}
 
 	// Return the created team using the request data since Snowflake API
@@ -100,8 +103,8 @@ func (c *SnowflakeClient) CreateTeam(ctx context.Context, team *structs.Team) (*
 	return createdTeam, nil
 }
 
-// FetchTeamDetails returns basic team information without making API calls
-// since the detailed information is not consumed by the reconciliation workflow
+// FetchTeamDetails fetches the role from Snowflake using REST API. Roles can't be fetched by name,
+// they are listed with a LIKE pattern which treats '_' as a wildcard, so the exact name is looked for.
 func (c *SnowflakeClient) FetchTeamDetails(ctx context.Context, teamID string) (*structs.Team, error) {
 	log := logger.Logger(ctx).WithFields(logrus.Fields{
 		"service": "snowflake",
@@ -109,13 +112,32 @@ func (c *SnowflakeClient) FetchTeamDetails(ctx context.Context, teamID string) (
 	})
 
 	log.Info("fetching team details")
-	// Since we're not consuming the detailed information from this function
-	// and it's only required for interface, return basic team info
-	// without making any API calls
-	team := &structs.Team{
-		ID:   strings.ToLower(teamID),
-		Name: strings.ToLower(teamID),
+	var team *structs.Team
+	endpoint := "/api/v2/roles?like=" + url.QueryEscape(teamID)
+	err := c.fetchAllWithPagination(ctx, endpoint, func(resp []byte) error {
+		var roles []SnowflakeRole
+		if err := json.Unmarshal(resp, &roles); err != nil {
+			return fmt.Errorf("failed to parse roles response: %w", err)
+		}
+		for _, role := range roles {
+			if strings.EqualFold(role.Name, teamID) {
+				team = &structs.Team{
+					ID:          strings.ToLower(role.Name),
+					Name:        strings.ToLower(role.Name),
+					Description: role.Comment,
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error fetching team details")
+		return nil, err
+	}
+	if team == nil {
+		return nil, clients.NewError(clients.ErrNotFound, "snowflake role %s not found", teamID)
 	}
+
 	log.Info("successfully fetched team details")
 	return team, nil
 }
@@ -138,7 +160,8 @@ func (c *SnowflakeClient) DeleteTeamByID(ctx context.Context, teamID string) err
 
 	// Check for successful deletion
 	if status != http.StatusOK && status != http.StatusNoContent {
-		return fmt.Errorf("failed to delete role, status: %s, body: %s", http.StatusText(status), string(resp))
+		return clients.StatusError(status, nil, "failed to delete role, status: %s, body: %s",
+			http.StatusText(status), string(resp))
 	}
 
 	log.Info("team deleted successfully")
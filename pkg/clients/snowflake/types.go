@@ -16,18 +16,44 @@ limitations under the License.
 
 package snowflake
 
-import "github.com/gojek/heimdall/v7"
+import (
+	"time"
+
+	"github.com/gojek/heimdall/v7"
+)
 
 // SnowflakeConfig holds the configuration for Snowflake client
 type SnowflakeConfig struct {
 	PAT     string
 	BaseURL string
+	// Account and User identify the user authenticating with its key pair instead of a PAT
+	Account string
+	User    string
+	// RoleHierarchy grants the role of a Group to the roles of the nested Groups
+	// instead of granting it to their users
+	RoleHierarchy bool
+	// DefaultWarehouse is set as the default warehouse of the users created
+	DefaultWarehouse string
+	// UserType is set as the type of the users created, PERSON or SERVICE
+	UserType string
+	// GroupDefaultRole sets the role of the Group a user is created for as its default role
+	GroupDefaultRole bool
+	// LoginNameAttribute is the LDAP attribute set as the login name of the users created
+	LoginNameAttribute string
+	// BulkGrants grants and revokes the roles of the users in batches through the SQL API
+	BulkGrants bool
+	// BulkGrantBatchSize is the number of statements per batch
+	BulkGrantBatchSize int
 }
 
 // SnowflakeClient is the client for interacting with Snowflake REST API
 type SnowflakeClient struct {
 	config *SnowflakeConfig
 	client heimdall.Doer
+	// keyPair signs the JWTs of the requests when authenticating with a key pair
+	keyPair *keyPairAuth
+	// pollInterval is the time between two polls of a running SQL API statement
+	pollInterval time.Duration
 }
 
 // SnowflakeUser represents a user object from Snowflake API response
@@ -45,5 +71,41 @@ type SnowflakeGrant struct {
 
 // SnowflakeRole represents a role object from Snowflake roles API response
 type SnowflakeRole struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// SnowflakeTable represents a table object from Snowflake tables API response
+type SnowflakeTable struct {
 	Name string `json:"name"`
 }
+
+// SnowflakeSecurable identifies the object of a privilege grant
+type SnowflakeSecurable struct {
+	Database string `json:"database,omitempty"`
+	Schema   string `json:"schema,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// SnowflakeContainingScope is the schema of a grant on all or future tables
+type SnowflakeContainingScope struct {
+	Database string `json:"database"`
+	Schema   string `json:"schema,omitempty"`
+}
+
+// SnowflakePrivilegeGrant represents a grant of privileges to a role from the grants API
+type SnowflakePrivilegeGrant struct {
+	SecurableType   string                    `json:"securable_type"`
+	Securable       *SnowflakeSecurable       `json:"securable,omitempty"`
+	ContainingScope *SnowflakeContainingScope `json:"containing_scope,omitempty"`
+	Privileges      []string                  `json:"privileges"`
+}
+
+// SnowflakeStatementStatus represents the status of a SQL API statement, the failed
+// statements have a code and a message
+type SnowflakeStatementStatus struct {
+	Code            string `json:"code"`
+	SQLState        string `json:"sqlState"`
+	Message         string `json:"message"`
+	StatementHandle string `json:"statementHandle"`
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/redhat-data-and-ai/usernaut/pkg/clients"
 	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
 	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
 	"github.com/sirupsen/logrus"
@@ -99,14 +100,9 @@ func (c *SnowflakeClient) CreateUser(ctx context.Context, user *structs.User) (*
 		return nil, fmt.Errorf("email and username are required for Snowflake user creation")
 	}
 
-	payload := map[string]interface{}{
-		"name":  user.UserName,
-		"email": user.Email, // Email is now mandatory
-	}
-
-	// Add optional fields if provided
-	if user.DisplayName != "" {
-		payload["displayName"] = user.DisplayName
+	payload, err := c.userPayload(user)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, status, err := c.makeRequest(ctx, endpoint, http.MethodPost, payload)
@@ -117,7 +113,8 @@ func (c *SnowflakeClient) CreateUser(ctx context.Context, user *structs.User) (*
 
 	// Check for successful creation
 	if status != http.StatusOK && status != http.StatusCreated {
-		return nil, fmt.Errorf("failed to create user, status: %s, body: %s", http.StatusText(status), string(resp))
+		return nil, clients.StatusError(status, nil, "failed to create user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
 	}
 
 	// Parse response using type-safe struct unmarshaling
@@ -135,6 +132,63 @@ func (c *SnowflakeClient) CreateUser(ctx context.Context, user *structs.User) (*
 	}, nil
 }
 
+// userPayload returns the properties of the user to create: the ones of the backend, overridden by
+// the ones of the Group, and the names from LDAP
+func (c *SnowflakeClient) userPayload(user *structs.User) (map[string]interface{}, error) {
+	payload := map[string]interface{}{
+		"name":  user.UserName,
+		"email": user.Email, // Email is now mandatory
+	}
+
+	// Add optional fields if provided
+	if user.DisplayName != "" {
+		payload["displayName"] = user.DisplayName
+	}
+	// first names are only in LDAP as givenName, FirstName holds the display name
+	if firstName := user.GetAttribute("givenName"); firstName != "" {
+		payload["first_name"] = firstName
+	}
+	if user.LastName != "" {
+		payload["last_name"] = user.LastName
+	}
+	if c.config.LoginNameAttribute != "" {
+		if loginName := user.GetAttribute(c.config.LoginNameAttribute); loginName != "" {
+			payload["login_name"] = loginName
+		}
+	}
+
+	if c.config.GroupDefaultRole && user.GetTeam() != "" {
+		payload["default_role"] = user.GetTeam()
+	}
+	if c.config.DefaultWarehouse != "" {
+		payload["default_warehouse"] = c.config.DefaultWarehouse
+	}
+	if c.config.UserType != "" {
+		payload["type"] = c.config.UserType
+	}
+
+	for name, value := range user.Properties {
+		switch name {
+		case "default_role", "default_warehouse":
+			payload[name] = value
+		case "type":
+			if !validUserType(value) {
+				return nil, fmt.Errorf("invalid user type %q for snowflake user: expected PERSON or SERVICE", value)
+			}
+			payload[name] = strings.ToUpper(value)
+		default:
+			return nil, fmt.Errorf("unsupported user property %q for snowflake user", name)
+		}
+	}
+
+	return payload, nil
+}
+
+// validUserType returns whether the type of user is supported, the legacy service users aren't
+func validUserType(userType string) bool {
+	return strings.EqualFold(userType, "PERSON") || strings.EqualFold(userType, "SERVICE")
+}
+
 // FetchUserDetails fetches details for a specific user using REST API
 func (c *SnowflakeClient) FetchUserDetails(ctx context.Context, userID string) (*structs.User, error) {
 	log := logger.Logger(ctx).WithFields(logrus.Fields{
@@ -150,7 +204,8 @@ func (c *SnowflakeClient) FetchUserDetails(ctx context.Context, userID string) (
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch user details, status: %s, body: %s", http.StatusText(status), string(resp))
+		return nil, clients.StatusError(status, nil, "failed to fetch user details, status: %s, body: %s",
+			http.StatusText(status), string(resp))
 	}
 
 	// Parse the response using strongly-typed struct
@@ -188,9 +243,66 @@ func (c *SnowflakeClient) DeleteUser(ctx context.Context, userID string) error {
 
 	// Check for successful deletion
 	if status != http.StatusOK && status != http.StatusNoContent {
-		return fmt.Errorf("failed to delete user, status: %s, body: %s", http.StatusText(status), string(resp))
+		return clients.StatusError(status, nil, "failed to delete user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
 	}
 
 	log.Info("user deleted successfully")
 	return nil
 }
+
+// writableUserProperties are the properties of a user accepted by the PUT of the users endpoint
+var writableUserProperties = []string{
+	"name", "login_name", "display_name", "displayName", "first_name", "middle_name", "last_name", "email",
+	"comment", "must_change_password", "days_to_expiry", "mins_to_unlock", "default_warehouse",
+	"default_namespace", "default_role", "default_secondary_roles", "mins_to_bypass_mfa",
+	"rsa_public_key", "rsa_public_key_2", "type", "network_policy",
+}
+
+// DisableUser disables the user instead of deleting it, the user can't log in anymore
+// but keeps the objects it owns. The other writable properties of the user are sent back unchanged.
+func (c *SnowflakeClient) DisableUser(ctx context.Context, userID string) error {
+	log := logger.Logger(ctx).WithFields(logrus.Fields{
+		"service": "snowflake",
+		"userID":  userID,
+	})
+
+	log.Info("disabling user")
+	endpoint := fmt.Sprintf("/api/v2/users/%s", userID)
+
+	resp, status, err := c.makeRequest(ctx, endpoint, http.MethodGet, nil)
+	if err != nil {
+		log.WithError(err).Error("error fetching user to disable")
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+	if status != http.StatusOK {
+		return clients.StatusError(status, nil, "failed to fetch user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	// users are altered by sending all their properties, the read-only ones returned
+	// by the GET, e.g. created_on or has_password, are rejected
+	var user map[string]interface{}
+	if err := json.Unmarshal(resp, &user); err != nil {
+		return fmt.Errorf("failed to parse user response: %w", err)
+	}
+	payload := map[string]interface{}{"disabled": true}
+	for _, property := range writableUserProperties {
+		if value, found := user[property]; found && value != nil {
+			payload[property] = value
+		}
+	}
+
+	resp, status, err = c.makeRequest(ctx, endpoint, http.MethodPut, payload)
+	if err != nil {
+		log.WithError(err).Error("error disabling user")
+		return fmt.Errorf("failed to disable user: %w", err)
+	}
+	if status != http.StatusOK {
+		return clients.StatusError(status, nil, "failed to disable user, status: %s, body: %s",
+			http.StatusText(status), string(resp))
+	}
+
+	log.Info("user disabled successfully")
+	return nil
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/common/structs"
+)
+
+// TeamRenamer is implemented by the clients able to rename a team in place, keeping
+// its ID, members and permissions rather than recreating it under the new name
+type TeamRenamer interface {
+	RenameTeam(ctx context.Context, teamID, name string) (*structs.Team, error)
+}
+
+// GetTeamRenamer returns the client as a TeamRenamer when it can rename teams
+func GetTeamRenamer(c Client) (TeamRenamer, bool) {
+	renamer, ok := c.(TeamRenamer)
+	if !ok || !GetCapabilities(c).Rename {
+		return nil, false
+	}
+	return renamer, true
+}
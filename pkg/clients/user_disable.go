@@ -0,0 +1,34 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import "context"
+
+// UserDisabler is implemented by the clients able to disable a user as an alternative to
+// DeleteUser, the user is locked out but the objects it owns are kept
+type UserDisabler interface {
+	DisableUser(ctx context.Context, userID string) error
+}
+
+// GetUserDisabler returns the client as a UserDisabler when it can disable users
+func GetUserDisabler(c Client) (UserDisabler, bool) {
+	disabler, ok := c.(UserDisabler)
+	if !ok || !GetCapabilities(c).UserDisable {
+		return nil, false
+	}
+	return disabler, true
+}
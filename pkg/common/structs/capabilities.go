@@ -0,0 +1,39 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structs
+
+// Capabilities declares the operations a backend supports, so that the reconciler,
+// the cache preload and the API skip the ones it only stubs
+type Capabilities struct {
+	// UserProvisioning is set when CreateUser onboards the user, otherwise it only
+	// resolves the ID of an account provisioned elsewhere and may not find it
+	UserProvisioning bool `json:"user_provisioning"`
+	// UserListing is set when FetchAllUsers returns the users of the backend
+	UserListing bool `json:"user_listing"`
+	// TeamListing is set when FetchAllTeams returns the teams of the backend
+	TeamListing bool `json:"team_listing"`
+	// MembershipRoles is set when the members are given a role in their teams
+	MembershipRoles bool `json:"membership_roles"`
+	// NestedTeams is set when the teams can be nested in other teams
+	NestedTeams bool `json:"nested_teams"`
+	// Rename is set when a team can be renamed in place
+	Rename bool `json:"rename"`
+	// UserDisable is set when a user can be disabled instead of deleted
+	UserDisable bool `json:"user_disable"`
+	// Grants is set when the privileges of the teams on the objects of the backend are managed
+	Grants bool `json:"grants"`
+}
@@ -0,0 +1,32 @@
+package structs
+
+import "fmt"
+
+// TableObjectType is the type of the grants on all the tables of a schema
+const TableObjectType = "table"
+
+// Object types of the grants of the team memberships on destinations and connectors, e.g. on fivetran
+const (
+	DestinationObjectType = "destination"
+	ConnectorObjectType   = "connector"
+)
+
+// Grant is a privilege of a team on an object of the backend. The table grants are on
+// all the tables of the schema ObjectName, or on the ones created later when Future is set.
+type Grant struct {
+	ObjectType string `json:"object_type"`
+	ObjectName string `json:"object_name"`
+	Privilege  string `json:"privilege"`
+	Future     bool   `json:"future,omitempty"`
+}
+
+func (g Grant) String() string {
+	switch {
+	case g.ObjectType == TableObjectType && g.Future:
+		return fmt.Sprintf("%s on future tables in schema %s", g.Privilege, g.ObjectName)
+	case g.ObjectType == TableObjectType:
+		return fmt.Sprintf("%s on all tables in schema %s", g.Privilege, g.ObjectName)
+	default:
+		return fmt.Sprintf("%s on %s %s", g.Privilege, g.ObjectType, g.ObjectName)
+	}
+}
@@ -8,6 +8,14 @@ type User struct {
 	LastName    string `json:"last_name,omitempty"`
 	DisplayName string `json:"display_name,omitempty"`
 	Role        string `json:"role,omitempty"`
+	// Attributes holds the LDAP attributes of the user, for backends mapping
+	// users through an attribute other than the email, e.g. a GitHub login
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Team is the ID of the team the user is created for, backends may make it the default of the user
+	Team string `json:"team,omitempty"`
+	// Properties holds the backend specific properties of the user set by the Group,
+	// e.g. the default warehouse of a Snowflake user
+	Properties map[string]string `json:"properties,omitempty"`
 }
 
 func (u *User) GetID() string {
@@ -38,12 +46,23 @@ func (u *User) GetRole() string {
 	return u.Role
 }
 
+func (u *User) GetTeam() string {
+	return u.Team
+}
+
+// GetAttribute returns the value of the given attribute, empty if it isn't set
+func (u *User) GetAttribute(name string) string {
+	return u.Attributes[name]
+}
+
 type LDAPUser struct {
 	CN          string `json:"cn,omitempty"`
 	DisplayName string `json:"displayName,omitempty"`
 	Email       string `json:"mail,omitempty"`
 	SN          string `json:"sn,omitempty"`
 	UID         string `json:"uid,omitempty"`
+	// Attributes holds all the attributes fetched from LDAP, keyed by attribute name
+	Attributes map[string]string `json:"-"`
 }
 
 func (u *LDAPUser) GetCN() string {
@@ -65,3 +84,7 @@ func (u *LDAPUser) GetSN() string {
 func (u *LDAPUser) GetUID() string {
 	return u.UID
 }
+
+func (u *LDAPUser) GetAttributes() map[string]string {
+	return u.Attributes
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrBackendConflict is returned by RegisterBackend when the config files or another source already
+// define a backend of the same type and name, e.g. a Backend CR of the same name in another namespace
+var ErrBackendConflict = errors.New("backend already registered")
+
+// runtimeBackends holds the backends registered at runtime (e.g. from Backend CRs),
+// keyed by the identifier of their source so they can be replaced or removed later.
+var (
+	runtimeBackendsMu sync.RWMutex
+	runtimeBackends   = make(map[string]Backend)
+)
+
+// RegisterBackend adds or replaces a backend defined outside of the config files.
+// source uniquely identifies the origin of the definition, e.g. "namespace/name" of a Backend CR.
+// The Groups reference the backends by type and name, a backend of the config files or registered by
+// another source under the same type and name is kept and ErrBackendConflict returned.
+func (c *AppConfig) RegisterBackend(source string, backend Backend) error {
+	if _, found := c.BackendMap[backend.Type][backend.Name]; found {
+		return fmt.Errorf("%w: %s backend %s is defined by the config files", ErrBackendConflict,
+			backend.Type, backend.Name)
+	}
+
+	runtimeBackendsMu.Lock()
+	defer runtimeBackendsMu.Unlock()
+	for otherSource, other := range runtimeBackends {
+		if otherSource != source && other.Type == backend.Type && other.Name == backend.Name {
+			return fmt.Errorf("%w: %s backend %s is defined by %s", ErrBackendConflict,
+				backend.Type, backend.Name, otherSource)
+		}
+	}
+	backend.Source = source
+	runtimeBackends[source] = backend
+	return nil
+}
+
+// UnregisterBackend removes a backend previously added with RegisterBackend.
+// It returns the removed backend and whether it was registered.
+func UnregisterBackend(source string) (Backend, bool) {
+	runtimeBackendsMu.Lock()
+	defer runtimeBackendsMu.Unlock()
+	backend, ok := runtimeBackends[source]
+	delete(runtimeBackends, source)
+	return backend, ok
+}
+
+// GetBackendMap returns the backends from the config files merged with the ones
+// registered at runtime, keyed by type and name. The backends of the config files take
+// precedence, e.g. over a runtime backend registered before they were added by a reload.
+// The returned map is a copy and safe to use while backends are being registered.
+func (c *AppConfig) GetBackendMap() map[string]map[string]Backend {
+	backends := make(map[string]map[string]Backend, len(c.BackendMap))
+	for backendType, byName := range c.BackendMap {
+		backends[backendType] = make(map[string]Backend, len(byName))
+		for name, backend := range byName {
+			backends[backendType][name] = backend
+		}
+	}
+
+	runtimeBackendsMu.RLock()
+	defer runtimeBackendsMu.RUnlock()
+	for _, backend := range runtimeBackends {
+		if _, static := c.BackendMap[backend.Type][backend.Name]; static {
+			continue
+		}
+		if backends[backend.Type] == nil {
+			backends[backend.Type] = make(map[string]Backend)
+		}
+		backends[backend.Type][backend.Name] = backend
+	}
+	return backends
+}
+
+// GetBackendList returns all the backends, from the config files and registered at runtime,
+// sorted by type and name
+func (c *AppConfig) GetBackendList() []Backend {
+	backendMap := c.GetBackendMap()
+	backends := make([]Backend, 0, len(c.Backends))
+	for _, byName := range backendMap {
+		for _, backend := range byName {
+			backends = append(backends, backend)
+		}
+	}
+	slices.SortFunc(backends, func(a, b Backend) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name))
+	})
+	return backends
+}
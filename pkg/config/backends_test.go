@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeBackends(t *testing.T) {
+	appConfig := &AppConfig{
+		BackendMap: map[string]map[string]Backend{
+			"fivetran": {"fivetran": {Name: "fivetran", Type: "fivetran", Enabled: true}},
+		},
+	}
+
+	assert.NoError(t, appConfig.RegisterBackend("usernaut/analytics", Backend{Name: "analytics", Type: "snowflake", Enabled: true}))
+	defer UnregisterBackend("usernaut/analytics")
+
+	// a backend of the same type and name from another source is rejected, the first one is kept
+	err := appConfig.RegisterBackend("team-a/analytics", Backend{Name: "analytics", Type: "snowflake"})
+	assert.ErrorIs(t, err, ErrBackendConflict)
+	assert.ErrorContains(t, err, "defined by usernaut/analytics")
+	assert.True(t, appConfig.GetBackendMap()["snowflake"]["analytics"].Enabled)
+
+	backends := appConfig.GetBackendMap()
+	assert.Contains(t, backends["fivetran"], "fivetran")
+	assert.Contains(t, backends["snowflake"], "analytics")
+	// the static backend map must not be modified
+	assert.NotContains(t, appConfig.BackendMap, "snowflake")
+
+	list := appConfig.GetBackendList()
+	assert.Len(t, list, 2)
+	assert.Equal(t, "fivetran", list[0].Type)
+	assert.Equal(t, "snowflake", list[1].Type)
+
+	// a backend of the config files is kept over a Backend CR of the same type and name
+	err = appConfig.RegisterBackend("team-a/fivetran", Backend{Name: "fivetran", Type: "fivetran"})
+	assert.ErrorIs(t, err, ErrBackendConflict)
+	assert.ErrorContains(t, err, "defined by the config files")
+	assert.Empty(t, appConfig.GetBackendMap()["fivetran"]["fivetran"].Source)
+
+	// as well as over one registered before a reload added it to the config files
+	reloaded := &AppConfig{BackendMap: map[string]map[string]Backend{
+		"snowflake": {"analytics": {Name: "analytics", Type: "snowflake"}},
+	}}
+	assert.Empty(t, reloaded.GetBackendMap()["snowflake"]["analytics"].Source)
+
+	removed, ok := UnregisterBackend("usernaut/analytics")
+	assert.True(t, ok)
+	assert.Equal(t, "analytics", removed.Name)
+	assert.NotContains(t, appConfig.GetBackendMap(), "snowflake")
+}
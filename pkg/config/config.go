@@ -17,7 +17,11 @@ limitations under the License.
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"sync/atomic"
 
 	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
 	"github.com/redhat-data-and-ai/usernaut/pkg/clients/ldap"
@@ -26,17 +30,37 @@ import (
 
 // Config represents the top-level configuration structure
 type AppConfig struct {
-	App        App                       `yaml:"app"`
-	LDAP       ldap.LDAP                 `yaml:"ldap"`
-	Cache      cache.Config              `yaml:"cache"`
-	Backends   []Backend                 `yaml:"backends"`
-	Pattern    map[string][]PatternEntry `yaml:"pattern"`
-	HttpClient struct {
-		ConnectionPoolConfig    httpclient.ConnectionPoolConfig    `yaml:"connectionPoolConfig"`
-		HystrixResiliencyConfig httpclient.HystrixResiliencyConfig `yaml:"hystrixResiliencyConfig"`
-	} `yaml:"httpClient"`
+	App        App                           `yaml:"app"`
+	LDAP       ldap.LDAP                     `yaml:"ldap"`
+	Cache      cache.Config                  `yaml:"cache"`
+	Backends   []Backend                     `yaml:"backends"`
+	Pattern    map[string][]PatternEntry     `yaml:"pattern"`
+	HttpClient HttpClientConfig              `yaml:"httpClient"`
 	APIServer  APIServerConfig               `yaml:"apiServer"`
 	BackendMap map[string]map[string]Backend `yaml:"-"`
+	// references holds the references resolved while loading, keyed by resolver name
+	references map[string][]string
+	// values holds the values resolved while loading, keyed by resolver name and reference
+	values map[string]map[string]string
+}
+
+// References returns the references resolved while loading the configuration
+// through the given resolver, e.g. the file paths for "file" or the secrets for "secret"
+func (c *AppConfig) References(resolverName string) []string {
+	return c.references[resolverName]
+}
+
+// ResolvedValue returns the value the given resolver returned for the reference while
+// loading the configuration, empty when it wasn't found, and whether it was resolved at all
+func (c *AppConfig) ResolvedValue(resolverName, reference string) (string, bool) {
+	value, ok := c.values[resolverName][reference]
+	return value, ok
+}
+
+// HttpClientConfig holds the connection pool and circuit breaker settings of the backend HTTP clients
+type HttpClientConfig struct {
+	ConnectionPoolConfig    httpclient.ConnectionPoolConfig    `yaml:"connectionPoolConfig"`
+	HystrixResiliencyConfig httpclient.HystrixResiliencyConfig `yaml:"hystrixResiliencyConfig"`
 }
 
 type APIServerConfig struct {
@@ -79,6 +103,11 @@ type Backend struct {
 	Type       string                 `yaml:"type"`
 	Enabled    bool                   `yaml:"enabled"`
 	Connection map[string]interface{} `yaml:"connection"`
+	// HttpClient overrides the application wide HTTP client settings for this backend
+	HttpClient *HttpClientConfig `yaml:"httpClient"`
+	// Source identifies where a backend registered at runtime is defined, e.g. the namespace/name
+	// of its Backend CR, it is empty for the backends of the config files
+	Source string `yaml:"-"`
 }
 
 func (b *Backend) GetStringConnection(name string, defaultValue string) string {
@@ -88,16 +117,50 @@ func (b *Backend) GetStringConnection(name string, defaultValue string) string {
 	return defaultValue
 }
 
-var config *AppConfig
+// GetBoolConnection returns the boolean connection setting, set either as a boolean or as a string
+// by the Backend CRs, defaultValue when it isn't set or invalid
+func (b *Backend) GetBoolConnection(name string, defaultValue bool) bool {
+	switch val := b.Connection[name].(type) {
+	case bool:
+		return val
+	case string:
+		if parsed, err := strconv.ParseBool(val); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// current holds the active configuration, swapped atomically on reload
+var current atomic.Pointer[AppConfig]
 
 func LoadConfig(env string) (*AppConfig, error) {
-	// Init config
-	config = &AppConfig{}
-	err := NewDefaultConfig().Load(env, config)
+	config, err := loadAppConfig(env)
 	if err != nil {
 		return nil, err
 	}
 
+	current.Store(config)
+	return config, nil
+}
+
+// loadAppConfig reads and validates the configuration of the given environment without activating it
+func loadAppConfig(env string) (*AppConfig, error) {
+	return LoadAppConfig(NewDefaultConfig(), env)
+}
+
+// LoadAppConfig reads the configuration of the given environment through loader and validates it,
+// without activating it. It's used to check a configuration directory offline.
+func LoadAppConfig(loader *Config, env string) (*AppConfig, error) {
+	config := &AppConfig{}
+	if err := loader.read(env, config); err != nil {
+		return nil, err
+	}
+	// the values failing to resolve are reported along with the validation errors
+	resolveErr := loader.resolve(config)
+	config.references = loader.references
+	config.values = loader.values
+
 	// convert backends to a map for easier access
 	config.BackendMap = make(map[string]map[string]Backend)
 	for _, backend := range config.Backends {
@@ -107,6 +170,9 @@ func LoadConfig(env string) (*AppConfig, error) {
 		config.BackendMap[backend.Type][backend.Name] = backend
 	}
 
+	if err := errors.Join(resolveErr, config.Validate()); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
 	return config, nil
 }
 
@@ -119,10 +185,9 @@ func getOrDefaultEnv() string {
 }
 
 func GetConfig() (*AppConfig, error) {
-	var err error
-	if config == nil {
-		config, err = LoadConfig(getOrDefaultEnv())
+	if config := current.Load(); config != nil {
+		return config, nil
 	}
 
-	return config, err
+	return LoadConfig(getOrDefaultEnv())
 }
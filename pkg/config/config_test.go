@@ -111,7 +111,7 @@ func TestFileSubstitution(t *testing.T) {
 	fileConfig.Secret = "file|" + tmpfile.Name()
 
 	// Substitute
-	SubstituteConfigValues(reflect.ValueOf(&fileConfig))
+	assert.NoError(t, SubstituteConfigValues(reflect.ValueOf(&fileConfig)))
 
 	assert.Equal(t, secretValue, fileConfig.Secret)
 }
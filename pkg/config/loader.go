@@ -17,7 +17,7 @@ limitations under the License.
 package config
 
 import (
-	"fmt"
+	"errors"
 	"os"
 	"path"
 	"reflect"
@@ -35,6 +35,8 @@ const (
 	WorkDirEnv            = "WORKDIR"
 	EnvPrefix             = "env|"
 	FilePrefix            = "file|"
+	SecretPrefix          = "secret|"
+	Base64Prefix          = "base64|"
 )
 
 // Options is config options.
@@ -48,6 +50,10 @@ type Options struct {
 type Config struct {
 	opts  Options
 	viper *viper.Viper
+	// references holds the references resolved by the last Load, keyed by resolver name
+	references map[string][]string
+	// values holds the values resolved by the last Load, keyed by resolver name and reference
+	values map[string]map[string]string
 }
 
 func NewDefaultOptions() Options {
@@ -74,25 +80,79 @@ func NewDefaultConfig() *Config {
 
 // NewConfig returns new config struct.
 func NewConfig(opts Options) *Config {
-	return &Config{opts, viper.New()}
+	return &Config{opts: opts, viper: viper.New()}
+}
+
+// ConfigPath returns the directory the configuration files are read from.
+func (c *Config) ConfigPath() string {
+	return c.opts.configPath
+}
+
+// References returns the references resolved by the given resolver during the last call to Load,
+// e.g. the paths of the 'file|/path' values for the "file" resolver.
+func (c *Config) References(resolverName string) []string {
+	return c.references[resolverName]
 }
 
 // Load reads environment specific configurations and along with the defaults
 // unmarshalls into config.
 func (c *Config) Load(env string, config interface{}) error {
-	if err := c.loadByConfigName(c.opts.defaultConfigFileName, config); err != nil {
+	if err := c.read(env, config); err != nil {
 		return err
 	}
-	if err := c.loadByConfigName(env, config); err != nil {
+	return c.resolve(config)
+}
+
+// read unmarshalls the default and the environment specific configurations into config
+func (c *Config) read(env string, config interface{}) error {
+	if err := c.loadByConfigName(c.opts.defaultConfigFileName, config); err != nil {
 		return err
 	}
-	SubstituteConfigValues(reflect.ValueOf(config))
-	return nil
+	return c.loadByConfigName(env, config)
+}
+
+// resolve substitutes the values of config referencing a resolver, recording the references
+func (c *Config) resolve(config interface{}) error {
+	c.references = make(map[string][]string)
+	c.values = make(map[string]map[string]string)
+	return substituteConfigValues(reflect.ValueOf(config), func(s string) (string, error) {
+		return resolveValue(s, func(resolverName, reference, value string) {
+			c.references[resolverName] = append(c.references[resolverName], reference)
+			if c.values[resolverName] == nil {
+				c.values[resolverName] = make(map[string]string)
+			}
+			c.values[resolverName][reference] = value
+		})
+	})
 }
 
 // SubstituteConfigValues recursively walks through the config struct and replaces
-// string values of the form 'env|VAR' or 'file|/path' with the corresponding value.
-func SubstituteConfigValues(v reflect.Value) {
+// string values referencing a registered resolver, e.g. 'env|VAR' or 'file|/path',
+// with the corresponding value. All the resolution errors are returned.
+func SubstituteConfigValues(v reflect.Value) error {
+	return substituteConfigValues(v, func(s string) (string, error) {
+		return resolveValue(s, nil)
+	})
+}
+
+// substituteConfigValues walks through the config struct and replaces every string value
+// with the result of substitute, collecting the errors. Values failing to resolve are left as is.
+func substituteConfigValues(v reflect.Value, substitute func(string) (string, error)) error {
+	var errs []error
+	walkConfigValues(v, func(s string) string {
+		resolved, err := substitute(s)
+		if err != nil {
+			errs = append(errs, err)
+			return s
+		}
+		return resolved
+	})
+	return errors.Join(errs...)
+}
+
+// walkConfigValues walks through the config struct and replaces every string value
+// with the result of substitute.
+func walkConfigValues(v reflect.Value, substitute func(string) string) {
 	if !v.IsValid() {
 		return
 	}
@@ -101,7 +161,7 @@ func SubstituteConfigValues(v reflect.Value) {
 		if v.IsNil() {
 			return
 		}
-		SubstituteConfigValues(v.Elem())
+		walkConfigValues(v.Elem(), substitute)
 		return
 	}
 	// If it's a struct, process its fields
@@ -111,7 +171,7 @@ func SubstituteConfigValues(v reflect.Value) {
 			if field.CanSet() || field.Kind() == reflect.Ptr ||
 				field.Kind() == reflect.Struct || field.Kind() == reflect.Map ||
 				field.Kind() == reflect.Slice {
-				SubstituteConfigValues(field)
+				walkConfigValues(field, substitute)
 			}
 		}
 		return
@@ -125,13 +185,13 @@ func SubstituteConfigValues(v reflect.Value) {
 			}
 			// Only settable if map value is addressable, so we replace by setting
 			if val.Kind() == reflect.String {
-				newVal := reflect.ValueOf(substituteString(val.String()))
+				newVal := reflect.ValueOf(substitute(val.String()))
 				v.SetMapIndex(key, newVal)
 			} else {
 				// Recursively process nested maps/structs
 				copyVal := reflect.New(val.Type()).Elem()
 				copyVal.Set(val)
-				SubstituteConfigValues(copyVal)
+				walkConfigValues(copyVal, substitute)
 				v.SetMapIndex(key, copyVal)
 			}
 		}
@@ -140,29 +200,14 @@ func SubstituteConfigValues(v reflect.Value) {
 	// If it's a slice or array, process its elements
 	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
 		for i := 0; i < v.Len(); i++ {
-			SubstituteConfigValues(v.Index(i))
+			walkConfigValues(v.Index(i), substitute)
 		}
 		return
 	}
 	// If it's a string, substitute if needed
 	if v.Kind() == reflect.String && v.CanSet() {
-		v.SetString(substituteString(v.String()))
-	}
-}
-
-// substituteString replaces 'env|VAR' and 'file|/path' patterns with their values
-func substituteString(s string) string {
-	if len(s) > len(EnvPrefix) && s[:len(EnvPrefix)] == EnvPrefix {
-		return os.Getenv(s[len(EnvPrefix):])
-	}
-	if len(s) > len(FilePrefix) && s[:len(FilePrefix)] == FilePrefix {
-		b, err := os.ReadFile(s[len(FilePrefix):])
-		if err != nil {
-			panic(fmt.Sprintf("ERROR: %v", err))
-		}
-		return strings.TrimSpace(string(b))
+		v.SetString(substitute(v.String()))
 	}
-	return s
 }
 
 // loadByConfigName reads configuration from file and unmarshalls into config.
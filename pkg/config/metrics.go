@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	reloadResultSuccess = "success"
+	reloadResultFailure = "failure"
+)
+
+var (
+	// configReloadsTotal counts the configuration reloads by result
+	configReloadsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "usernaut_config_reloads_total",
+		Help: "Total number of configuration reloads, partitioned by result",
+	}, []string{"result"})
+
+	// configLastReloadSuccess is the timestamp of the last successful configuration reload
+	configLastReloadSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "usernaut_config_last_reload_success_timestamp_seconds",
+		Help: "Timestamp of the last successful configuration reload",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(configReloadsTotal, configLastReloadSuccess)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/logger"
+)
+
+// defaultReloadDebounce is how long the watcher waits for file events to settle before reloading.
+// Kubernetes updates mounted ConfigMaps and Secrets through several symlink swaps.
+const defaultReloadDebounce = 2 * time.Second
+
+// ChangeHandler is called with the previous and the new configuration after a successful reload
+type ChangeHandler func(oldConfig, newConfig *AppConfig)
+
+var (
+	changeHandlersMu sync.Mutex
+	changeHandlers   []ChangeHandler
+
+	// reloadMu serialises reloads so handlers observe configurations in order
+	reloadMu sync.Mutex
+)
+
+// OnChange registers a handler notified whenever a new configuration is activated
+func OnChange(handler ChangeHandler) {
+	changeHandlersMu.Lock()
+	defer changeHandlersMu.Unlock()
+	changeHandlers = append(changeHandlers, handler)
+}
+
+// Reload reads the configuration of the current environment, validates it and,
+// when valid, atomically replaces the active configuration and notifies the
+// registered handlers. The active configuration is left untouched on error.
+func Reload() (*AppConfig, error) {
+	return reload(getOrDefaultEnv())
+}
+
+func reload(env string) (*AppConfig, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	log := logger.Logger(context.Background()).WithFields(logrus.Fields{
+		"component": "configReload",
+		"env":       env,
+	})
+
+	newConfig, err := loadAppConfig(env)
+	if err != nil {
+		configReloadsTotal.WithLabelValues(reloadResultFailure).Inc()
+		log.WithError(err).Error("configuration reload failed, keeping the active configuration")
+		return nil, err
+	}
+
+	oldConfig := current.Swap(newConfig)
+	configReloadsTotal.WithLabelValues(reloadResultSuccess).Inc()
+	configLastReloadSuccess.SetToCurrentTime()
+	log.Info("configuration reloaded")
+
+	changeHandlersMu.Lock()
+	handlers := append([]ChangeHandler(nil), changeHandlers...)
+	changeHandlersMu.Unlock()
+	for _, handler := range handlers {
+		handler(oldConfig, newConfig)
+	}
+
+	return newConfig, nil
+}
+
+// Watcher reloads the configuration when the config files or the files
+// referenced through 'file|/path' values change
+type Watcher struct {
+	env      string
+	debounce time.Duration
+}
+
+// NewWatcher returns a watcher for the configuration of the current environment
+func NewWatcher() *Watcher {
+	return &Watcher{
+		env:      getOrDefaultEnv(),
+		debounce: defaultReloadDebounce,
+	}
+}
+
+// NeedLeaderElection returns false as every replica has to keep its configuration up to date
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start watches the configuration until the context is cancelled
+func (w *Watcher) Start(ctx context.Context) error {
+	log := logger.Logger(ctx).WithField("component", "configWatcher")
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	defer func() {
+		_ = fsWatcher.Close()
+	}()
+
+	activeConfig, err := GetConfig()
+	if err != nil {
+		return err
+	}
+	w.watchDirs(fsWatcher, activeConfig.References(FileResolver))
+	log.WithField("directories", fsWatcher.WatchList()).Info("watching configuration for changes")
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			log.WithField("file", event.Name).Debug("configuration file changed")
+			timer.Reset(w.debounce)
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.WithError(err).Error("error watching configuration files")
+		case <-timer.C:
+			if newConfig, err := reload(w.env); err == nil {
+				// files referenced by the new configuration may live in new directories
+				w.watchDirs(fsWatcher, newConfig.References(FileResolver))
+			}
+		}
+	}
+}
+
+// watchDirs watches the config directory and the directories of the referenced files.
+// Directories are watched instead of files since mounted volumes are updated by replacing symlinks.
+func (w *Watcher) watchDirs(fsWatcher *fsnotify.Watcher, referencedFiles []string) {
+	dirs := []string{NewDefaultOptions().configPath}
+	for _, file := range referencedFiles {
+		dirs = append(dirs, filepath.Dir(file))
+	}
+
+	watched := make(map[string]struct{})
+	for _, dir := range fsWatcher.WatchList() {
+		watched[dir] = struct{}{}
+	}
+	for _, dir := range dirs {
+		if _, ok := watched[dir]; ok {
+			continue
+		}
+		if err := fsWatcher.Add(dir); err != nil {
+			logrus.WithError(err).WithField("directory", dir).Warn("unable to watch directory for changes")
+			continue
+		}
+		watched[dir] = struct{}{}
+	}
+}
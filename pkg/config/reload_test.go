@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reloadTestConfig = `
+app:
+  name: usernaut
+cache:
+  driver: memory
+pattern:
+  default:
+    - input: "%s"
+      output: "$1"
+backends:
+  - name: fivetran
+    type: fivetran
+    enabled: true
+    connection:
+      apikey: key
+      apisecret: secret
+`
+
+func writeReloadTestConfig(t *testing.T, dir, pattern string) {
+	t.Helper()
+	content := []byte(fmt.Sprintf(reloadTestConfig, pattern))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "default.yaml"), content, 0o600))
+}
+
+func TestReload(t *testing.T) {
+	workDir := t.TempDir()
+	configDir := filepath.Join(workDir, DefaultConfigDir)
+	require.NoError(t, os.MkdirAll(configDir, 0o755))
+	t.Setenv(WorkDirEnv, workDir)
+	t.Setenv("APP_ENV", "default")
+
+	writeReloadTestConfig(t, configDir, "^team-(.*)$")
+	initial, err := LoadConfig("default")
+	require.NoError(t, err)
+
+	var notified *AppConfig
+	OnChange(func(oldConfig, newConfig *AppConfig) {
+		assert.Same(t, initial, oldConfig)
+		notified = newConfig
+	})
+
+	// a valid change is activated and handlers are notified
+	writeReloadTestConfig(t, configDir, "^group-(.*)$")
+	reloaded, err := Reload()
+	require.NoError(t, err)
+	assert.Same(t, reloaded, notified)
+	assert.Equal(t, "^group-(.*)$", reloaded.Pattern["default"][0].Input)
+	active, _ := GetConfig()
+	assert.Same(t, reloaded, active)
+
+	// an invalid change is rejected and the active configuration is kept
+	writeReloadTestConfig(t, configDir, "^group-(.*$")
+	_, err = Reload()
+	assert.Error(t, err)
+	active, _ = GetConfig()
+	assert.Same(t, reloaded, active)
+}
+
+func TestLoadAppConfigCollectsErrors(t *testing.T) {
+	configDir := t.TempDir()
+	content := strings.Replace(fmt.Sprintf(reloadTestConfig, "^group-(.*$"), "apikey: key", "apikey: file|/missing/key", 1)
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "default.yaml"), []byte(content), 0o600))
+
+	// the references failing to resolve don't hide the validation errors
+	loader := NewConfig(NewOptions(DefaultConfigType, configDir, DefaultConfigFileName))
+	_, err := LoadAppConfig(loader, DefaultConfigFileName)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "/missing/key")
+	assert.ErrorContains(t, err, "^group-(.*$")
+}
+
+func TestLoadAppConfigRecordsResolvedValues(t *testing.T) {
+	configDir := t.TempDir()
+	keyFile := filepath.Join(configDir, "apikey")
+	require.NoError(t, os.WriteFile(keyFile, []byte("from-file\n"), 0o600))
+	content := strings.Replace(fmt.Sprintf(reloadTestConfig, "^team-(.*)$"), "apikey: key", "apikey: file|"+keyFile, 1)
+	content = strings.Replace(content, "apisecret: secret", "apisecret: file|/missing/secret:-fallback", 1)
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "default.yaml"), []byte(content), 0o600))
+
+	loader := NewConfig(NewOptions(DefaultConfigType, configDir, DefaultConfigFileName))
+	appConfig, err := LoadAppConfig(loader, DefaultConfigFileName)
+	require.NoError(t, err)
+
+	value, ok := appConfig.ResolvedValue(FileResolver, keyFile)
+	assert.True(t, ok)
+	assert.Equal(t, "from-file", value)
+	// a reference replaced by its fallback is recorded as not found
+	value, ok = appConfig.ResolvedValue(FileResolver, "/missing/secret")
+	assert.True(t, ok)
+	assert.Empty(t, value)
+	_, ok = appConfig.ResolvedValue(FileResolver, "/not/referenced")
+	assert.False(t, ok)
+}
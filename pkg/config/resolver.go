@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Names of the built-in resolvers
+const (
+	EnvResolver    = "env"
+	FileResolver   = "file"
+	SecretResolver = "secret"
+	Base64Resolver = "base64"
+)
+
+const (
+	// resolverSeparator separates the resolver name from the reference, as in 'env|VAR'
+	resolverSeparator = "|"
+	// defaultSeparator separates the reference from its fallback value, as in 'env|VAR:-fallback'
+	defaultSeparator = ":-"
+)
+
+// ErrValueNotFound is returned by resolvers when the referenced value doesn't exist.
+// The fallback value is used instead when one is provided.
+var ErrValueNotFound = errors.New("value not found")
+
+// ResolverFunc returns the value of a reference, e.g. the content of the file for 'file|/path'
+type ResolverFunc func(reference string) (string, error)
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = map[string]ResolverFunc{
+		strings.TrimSuffix(EnvPrefix, resolverSeparator):  resolveEnv,
+		strings.TrimSuffix(FilePrefix, resolverSeparator): resolveFile,
+	}
+)
+
+func init() {
+	// registered here as it refers back to the registry to resolve nested references
+	RegisterResolver(Base64Resolver, resolveBase64)
+}
+
+// RegisterResolver makes config values of the form 'name|reference' resolve through resolver.
+// Registering a name twice replaces the previous resolver.
+func RegisterResolver(name string, resolver ResolverFunc) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers[name] = resolver
+}
+
+func getResolver(name string) (ResolverFunc, bool) {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	resolver, ok := resolvers[name]
+	return resolver, ok
+}
+
+// resolveValue resolves a config value of the form 'name|reference[:-fallback]'.
+// Values not prefixed by a registered resolver are returned unchanged.
+// record, when set, is called with every reference that gets resolved along with
+// the value the resolver returned for it, empty when it failed.
+func resolveValue(s string, record func(resolverName, reference, value string)) (string, error) {
+	name, reference, found := strings.Cut(s, resolverSeparator)
+	if !found || reference == "" {
+		return s, nil
+	}
+	resolver, ok := getResolver(name)
+	if !ok {
+		return s, nil
+	}
+
+	reference, fallback, hasFallback := strings.Cut(reference, defaultSeparator)
+	value, err := resolver(reference)
+	if record != nil {
+		record(name, reference, value)
+	}
+	if hasFallback && (errors.Is(err, ErrValueNotFound) || (err == nil && value == "")) {
+		return fallback, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s%s%s: %w", name, resolverSeparator, reference, err)
+	}
+	return value, nil
+}
+
+// resolveEnv returns the value of the environment variable, empty when it isn't set
+func resolveEnv(name string) (string, error) {
+	return os.Getenv(name), nil
+}
+
+// resolveFile returns the trimmed content of the file
+func resolveFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %w", ErrValueNotFound, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// resolveBase64 decodes the base64 encoded value. The value may itself be a reference,
+// e.g. 'base64|file|/path' decodes the content of the file.
+func resolveBase64(value string) (string, error) {
+	value, err := resolveValue(value, nil)
+	if err != nil {
+		return "", err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 value: %w", err)
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveValue(t *testing.T) {
+	t.Setenv("RESOLVER_TEST_VALUE", "from-env")
+	t.Setenv("RESOLVER_TEST_EMPTY", "")
+
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	assert.NoError(t, os.WriteFile(secretFile, []byte("from-file\n"), 0o600))
+	encodedFile := filepath.Join(dir, "encoded")
+	encoded := base64.StdEncoding.EncodeToString([]byte("decoded-file"))
+	assert.NoError(t, os.WriteFile(encodedFile, []byte(encoded), 0o600))
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain value", value: "plain", want: "plain"},
+		{name: "unknown resolver", value: "unknown|value", want: "unknown|value"},
+		{name: "env", value: "env|RESOLVER_TEST_VALUE", want: "from-env"},
+		{name: "env fallback when unset", value: "env|RESOLVER_TEST_UNSET:-default", want: "default"},
+		{name: "env fallback when empty", value: "env|RESOLVER_TEST_EMPTY:-default", want: "default"},
+		{name: "env without fallback when unset", value: "env|RESOLVER_TEST_UNSET", want: ""},
+		{name: "file", value: "file|" + secretFile, want: "from-file"},
+		{name: "file fallback", value: "file|" + filepath.Join(dir, "missing") + ":-default", want: "default"},
+		{name: "base64", value: "base64|" + base64.StdEncoding.EncodeToString([]byte("decoded")), want: "decoded"},
+		{name: "nested base64 file", value: "base64|file|" + encodedFile, want: "decoded-file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveValue(tt.value, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveValueErrors(t *testing.T) {
+	_, err := resolveValue("file|"+filepath.Join(t.TempDir(), "missing"), nil)
+	assert.ErrorIs(t, err, ErrValueNotFound)
+
+	_, err = resolveValue("base64|not base64", nil)
+	assert.Error(t, err)
+
+	type nested struct {
+		First  string
+		Second string
+	}
+	value := nested{
+		First:  "file|" + filepath.Join(t.TempDir(), "first"),
+		Second: "base64|%%%",
+	}
+	err = substituteConfigValues(reflect.ValueOf(&value), func(s string) (string, error) {
+		return resolveValue(s, nil)
+	})
+	// every failing value is reported
+	assert.ErrorIs(t, err, ErrValueNotFound)
+	assert.ErrorContains(t, err, "invalid base64 value")
+}
+
+func TestResolveValueRecordsReferences(t *testing.T) {
+	t.Setenv("RESOLVER_TEST_VALUE", "from-env")
+
+	recorded := map[string][]string{}
+	values := map[string]string{}
+	record := func(name, reference, value string) {
+		recorded[name] = append(recorded[name], reference)
+		values[reference] = value
+	}
+	_, err := resolveValue("env|RESOLVER_TEST_VALUE:-default", record)
+	assert.NoError(t, err)
+	_, err = resolveValue("file|/nonexistent/resolver-test:-default", record)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		EnvResolver:  {"RESOLVER_TEST_VALUE"},
+		FileResolver: {"/nonexistent/resolver-test"},
+	}, recorded)
+	// the value the resolver returned, not the fallback, is recorded
+	assert.Equal(t, map[string]string{
+		"RESOLVER_TEST_VALUE":        "from-env",
+		"/nonexistent/resolver-test": "",
+	}, values)
+}
+
+func TestRegisterResolver(t *testing.T) {
+	RegisterResolver("test", func(reference string) (string, error) {
+		if reference == "missing" {
+			return "", ErrValueNotFound
+		}
+		return "resolved-" + reference, nil
+	})
+
+	got, err := resolveValue("test|value", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "resolved-value", got)
+
+	got, err = resolveValue("test|missing:-default", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", got)
+}
+
+func TestSecretResolver(t *testing.T) {
+	reader := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "usernaut", Name: "credentials"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t\n")},
+	}).Build()
+	resolve := NewSecretResolver(reader)
+
+	got, err := resolve("usernaut/credentials/token")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", got)
+
+	_, err = resolve("usernaut/credentials/missing")
+	assert.True(t, errors.Is(err, ErrValueNotFound))
+
+	_, err = resolve("usernaut/missing/token")
+	assert.True(t, errors.Is(err, ErrValueNotFound))
+
+	_, err = resolve("credentials/token")
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// secretResolveTimeout bounds the time spent fetching a single Secret
+const secretResolveTimeout = 10 * time.Second
+
+// NewSecretResolver returns a resolver for 'secret|namespace/name/key' values,
+// reading the Secrets through the given Kubernetes reader
+func NewSecretResolver(reader client.Reader) ResolverFunc {
+	return func(reference string) (string, error) {
+		secretKey, key, err := ParseSecretReference(reference)
+		if err != nil {
+			return "", err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+		defer cancel()
+
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, secretKey, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("%w: secret %s", ErrValueNotFound, secretKey.String())
+			}
+			return "", err
+		}
+
+		value, ok := secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("%w: key %s in secret %s", ErrValueNotFound, key, secretKey.String())
+		}
+		return strings.TrimSpace(string(value)), nil
+	}
+}
+
+// ParseSecretReference splits a 'namespace/name/key' reference into the Secret and the key
+func ParseSecretReference(reference string) (types.NamespacedName, string, error) {
+	parts := strings.Split(reference, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return types.NamespacedName{}, "",
+			fmt.Errorf("invalid secret reference %q, expected namespace/name/key", reference)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, parts[2], nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+)
+
+var (
+	// requiredConnectionFields lists, per backend type, the connection fields the backend client needs.
+	// The types are registered by the backend packages through clients.Register.
+	requiredConnectionFields   = map[string][]string{}
+	requiredConnectionFieldsMu sync.RWMutex
+)
+
+// outputPlaceholder matches the capture group references of a pattern output template,
+// optionally followed by a transformation, e.g. '$1' or '$1|replace(-,_)'
+var outputPlaceholder = regexp.MustCompile(`\$(\d+)(\|[a-z]+\([^)]*\))?`)
+
+// supportedOutputTransforms are the transformations understood by the group name templates
+var supportedOutputTransforms = map[string]bool{
+	"|replace(-,_)": true,
+}
+
+// Validate checks that the configuration is usable before it gets activated.
+// All the problems found are returned together.
+func (c *AppConfig) Validate() error {
+	var errs []error
+	errs = append(errs, c.validateBackends()...)
+	errs = append(errs, c.validatePatterns()...)
+	errs = append(errs, c.validateCache()...)
+	errs = append(errs, c.validateAPIServer()...)
+	return errors.Join(errs...)
+}
+
+// RegisterBackendType makes backends of the given type configurable, requiring the given connection fields.
+// The field names are lower case as the config loader is case insensitive.
+func RegisterBackendType(backendType string, requiredFields ...string) {
+	requiredConnectionFieldsMu.Lock()
+	defer requiredConnectionFieldsMu.Unlock()
+	requiredConnectionFields[strings.ToLower(backendType)] = requiredFields
+}
+
+// IsSupportedBackendType reports whether backends of the given type can be configured
+func IsSupportedBackendType(backendType string) bool {
+	_, ok := getRequiredConnectionFields(backendType)
+	return ok
+}
+
+func getRequiredConnectionFields(backendType string) ([]string, bool) {
+	requiredConnectionFieldsMu.RLock()
+	defer requiredConnectionFieldsMu.RUnlock()
+	required, ok := requiredConnectionFields[strings.ToLower(backendType)]
+	return required, ok
+}
+
+// ValidateBackend checks the fields of a single backend, including the connection
+// fields required by its type. Disabled backends are only checked for name and type.
+func ValidateBackend(backend Backend) []error {
+	var errs []error
+	if backend.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if backend.Type == "" {
+		return append(errs, errors.New("type is required"))
+	}
+	required, ok := getRequiredConnectionFields(backend.Type)
+	if !ok {
+		return append(errs, fmt.Errorf("unsupported type %q", backend.Type))
+	}
+	if !backend.Enabled {
+		return errs
+	}
+
+	connection := make(map[string]interface{}, len(backend.Connection))
+	for key, value := range backend.Connection {
+		connection[strings.ToLower(key)] = value
+	}
+	for _, field := range required {
+		value, ok := connection[field]
+		if !ok || value == nil {
+			errs = append(errs, fmt.Errorf("connection.%s is required", field))
+			continue
+		}
+		if s, isString := value.(string); isString && strings.TrimSpace(s) == "" {
+			errs = append(errs, fmt.Errorf("connection.%s must not be empty", field))
+		}
+	}
+	return errs
+}
+
+func (c *AppConfig) validateBackends() []error {
+	var errs []error
+	seen := make(map[string]int, len(c.Backends))
+	for i, backend := range c.Backends {
+		for _, err := range ValidateBackend(backend) {
+			errs = append(errs, fmt.Errorf("backends[%d] (%s): %w", i, backend.Name, err))
+		}
+
+		// backends are looked up by type and name, a duplicate would silently replace the first one
+		key := strings.ToLower(backend.Type) + "/" + backend.Name
+		if first, ok := seen[key]; ok && backend.Name != "" {
+			errs = append(errs, fmt.Errorf("backends[%d]: duplicate backend %q of type %q, already defined at backends[%d]",
+				i, backend.Name, backend.Type, first))
+			continue
+		}
+		seen[key] = i
+	}
+	return errs
+}
+
+func (c *AppConfig) validatePatterns() []error {
+	var errs []error
+	for backendType, patterns := range c.Pattern {
+		if backendType != "default" && !IsSupportedBackendType(backendType) {
+			errs = append(errs, fmt.Errorf("pattern.%s: unsupported backend type", backendType))
+		}
+		for i, p := range patterns {
+			re, err := regexp.Compile(p.Input)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("pattern.%s[%d]: invalid regex pattern %q: %w", backendType, i, p.Input, err))
+				continue
+			}
+			if p.Output == "" {
+				errs = append(errs, fmt.Errorf("pattern.%s[%d]: output is required", backendType, i))
+				continue
+			}
+			for _, placeholder := range outputPlaceholder.FindAllStringSubmatch(p.Output, -1) {
+				group, _ := strconv.Atoi(placeholder[1])
+				if group < 1 || group > re.NumSubexp() {
+					errs = append(errs, fmt.Errorf("pattern.%s[%d]: output %q references $%d but %q has %d capture groups",
+						backendType, i, p.Output, group, p.Input, re.NumSubexp()))
+				}
+				if transform := placeholder[2]; transform != "" && !supportedOutputTransforms[transform] {
+					errs = append(errs, fmt.Errorf("pattern.%s[%d]: unsupported transformation %q in output %q",
+						backendType, i, transform, p.Output))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func (c *AppConfig) validateCache() []error {
+	var errs []error
+	switch c.Cache.Driver {
+	case "":
+		errs = append(errs, errors.New("cache.driver is required"))
+	case cache.DriverMemory:
+	case cache.DriverRedis:
+		if c.Cache.Redis == nil || c.Cache.Redis.Host == "" {
+			errs = append(errs, errors.New("cache.redis.host is required with the redis driver"))
+		}
+		if c.Cache.Redis != nil && c.Cache.Redis.Port != "" {
+			if port, err := strconv.Atoi(c.Cache.Redis.Port); err != nil || port < 1 || port > 65535 {
+				errs = append(errs, fmt.Errorf("cache.redis.port: invalid port %q", c.Cache.Redis.Port))
+			}
+		}
+		if c.Cache.Redis != nil && c.Cache.Redis.Database < 0 {
+			errs = append(errs, fmt.Errorf("cache.redis.database: must not be negative, got %d", c.Cache.Redis.Database))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("cache.driver: unsupported driver %q, expected %q or %q",
+			c.Cache.Driver, cache.DriverMemory, cache.DriverRedis))
+	}
+	return errs
+}
+
+func (c *AppConfig) validateAPIServer() []error {
+	var errs []error
+	if c.APIServer.Address != "" {
+		if _, _, err := net.SplitHostPort(c.APIServer.Address); err != nil {
+			errs = append(errs, fmt.Errorf("apiServer.address: %w", err))
+		}
+	}
+	if c.APIServer.Auth.Enabled {
+		if len(c.APIServer.Auth.BasicUsers) == 0 {
+			errs = append(errs, errors.New("apiServer.auth: at least one basic user is required when auth is enabled"))
+		}
+		for i, user := range c.APIServer.Auth.BasicUsers {
+			if user.Username == "" || user.Password == "" {
+				errs = append(errs, fmt.Errorf("apiServer.auth.basic_users[%d]: username and password are required", i))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache"
+	"github.com/redhat-data-and-ai/usernaut/pkg/cache/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// the backend packages register their types, the config package doesn't import them
+	RegisterBackendType("fivetran", "apikey", "apisecret")
+	RegisterBackendType("rover", "url", "cert_path", "private_key_path", "service_account_name")
+	RegisterBackendType("snowflake", "pat", "base_url")
+}
+
+func validTestConfig() *AppConfig {
+	return &AppConfig{
+		Cache: cache.Config{Driver: cache.DriverMemory},
+		Pattern: map[string][]PatternEntry{
+			"default": {{Input: "dataverse-consumer-([a-z0-9]+-[a-z0-9]+)", Output: "$1|replace(-,_)_group"}},
+			"rover":   {{Input: "^([^\\_]+)$", Output: "$1"}},
+		},
+		Backends: []Backend{
+			{
+				Name:       "fivetran",
+				Type:       "fivetran",
+				Enabled:    true,
+				Connection: map[string]interface{}{"apikey": "key", "apisecret": "secret"},
+			},
+			{Name: "snowflake", Type: "snowflake", Enabled: false},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, validTestConfig().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(c *AppConfig)
+		errs   []string
+	}{
+		{
+			name: "unsupported backend type",
+			modify: func(c *AppConfig) {
+				c.Backends[0].Type = "fivtran"
+			},
+			errs: []string{`backends[0] (fivetran): unsupported type "fivtran"`},
+		},
+		{
+			name: "missing connection fields",
+			modify: func(c *AppConfig) {
+				c.Backends[0].Connection = map[string]interface{}{"apiKey": " "}
+			},
+			errs: []string{
+				"backends[0] (fivetran): connection.apikey must not be empty",
+				"backends[0] (fivetran): connection.apisecret is required",
+			},
+		},
+		{
+			name: "duplicate backend",
+			modify: func(c *AppConfig) {
+				c.Backends = append(c.Backends, Backend{Name: "snowflake", Type: "snowflake"})
+			},
+			errs: []string{`backends[2]: duplicate backend "snowflake" of type "snowflake"`},
+		},
+		{
+			name: "invalid patterns",
+			modify: func(c *AppConfig) {
+				c.Pattern["default"] = []PatternEntry{
+					{Input: "team-(.*", Output: "$1"},
+					{Input: "team-(.*)", Output: "$2"},
+					{Input: "team-(.*)", Output: "$1|upper()"},
+					{Input: "team-(.*)"},
+				}
+				c.Pattern["fivtran"] = []PatternEntry{{Input: "(.*)", Output: "$1"}}
+			},
+			errs: []string{
+				`pattern.default[0]: invalid regex pattern "team-(.*"`,
+				`pattern.default[1]: output "$2" references $2`,
+				`pattern.default[2]: unsupported transformation "|upper()"`,
+				"pattern.default[3]: output is required",
+				"pattern.fivtran: unsupported backend type",
+			},
+		},
+		{
+			name: "invalid cache driver",
+			modify: func(c *AppConfig) {
+				c.Cache.Driver = "memcached"
+			},
+			errs: []string{`cache.driver: unsupported driver "memcached"`},
+		},
+		{
+			name: "invalid redis settings",
+			modify: func(c *AppConfig) {
+				c.Cache = cache.Config{Driver: cache.DriverRedis, Redis: &redis.Config{Port: "redis"}}
+			},
+			errs: []string{
+				"cache.redis.host is required with the redis driver",
+				`cache.redis.port: invalid port "redis"`,
+			},
+		},
+		{
+			name: "auth without users",
+			modify: func(c *AppConfig) {
+				c.APIServer.Auth.Enabled = true
+			},
+			errs: []string{"apiServer.auth: at least one basic user is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validTestConfig()
+			tt.modify(c)
+
+			err := c.Validate()
+			assert.Error(t, err)
+			for _, msg := range tt.errs {
+				assert.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}